@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
@@ -30,9 +32,19 @@ func GetPolicyArnFromName(accountId string, policyName string) string {
 // If the awsAccessKey is specified, the config will be created for the combination of awsAccessKey, awsSecretKey, awsSessionToken.
 // Else it will use the default AWS SDK logic to load the configuration. See https://aws.github.io/aws-sdk-go-v2/docs/configuring-sdk/
 // If assumeRoleArn is provided, it will use the evaluated configuration to then assume the specified role.
-func GetConfig(ctx context.Context, awsAccessKey, awsSecretKey, awsSessionToken, assumeRoleArn string, externalId *string) (aws.Config, error) {
+// If endpoints is not nil, every client built from the configuration, the assume role one included, calls its endpoints.
+func GetConfig(ctx context.Context, awsAccessKey, awsSecretKey, awsSessionToken, assumeRoleArn string, externalId *string, endpoints *EndpointConfig) (aws.Config, error) {
 	var opts []func(*config.LoadOptions) error
 
+	if endpoints != nil && endpoints.Anonymous {
+		if awsAccessKey != "" || assumeRoleArn != "" {
+			return aws.Config{}, fmt.Errorf("anonymous credentials can't be used with an access key or a role to assume")
+		}
+		opts = append(opts, config.WithCredentialsProvider(aws.AnonymousCredentials{}))
+	}
+	if endpoints != nil && endpoints.S3ForcePathStyle && !endpoints.hasS3Endpoint() {
+		return aws.Config{}, fmt.Errorf("the S3 path style can only be forced with an S3 endpoint, set the endpoint url or the s3 one")
+	}
 	if awsAccessKey != "" {
 		opts = append(opts, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(awsAccessKey, awsSecretKey, awsSessionToken)))
 	}
@@ -44,6 +56,7 @@ func GetConfig(ctx context.Context, awsAccessKey, awsSecretKey, awsSessionToken,
 	if cfg.Region == "" {
 		cfg.Region = "us-east-1"
 	}
+	endpoints.Apply(&cfg)
 
 	if externalId != nil && *externalId == "" {
 		externalId = nil
@@ -65,11 +78,84 @@ func GetConfig(ctx context.Context, awsAccessKey, awsSecretKey, awsSessionToken,
 		if err != nil {
 			return aws.Config{}, fmt.Errorf("failed to assume role: %w", err)
 		}
+		endpoints.Apply(&cfg)
 	}
 
 	return cfg, nil
 }
 
+// EndpointConfig points the clients built from a configuration at other endpoints than the
+// AWS ones, an emulator such as LocalStack or moto for instance.
+type EndpointConfig struct {
+	// URL is the endpoint of every service without an endpoint in Services.
+	URL string `json:"url,omitempty"`
+	// Services are the endpoints of single services, by service id, "s3" or "dynamodb" for
+	// instance. Ids are matched ignoring case, spaces and dashes.
+	Services map[string]string `json:"services,omitempty"`
+	// S3ForcePathStyle addresses buckets in the path of the S3 endpoint rather than in its host.
+	// It requires an S3 endpoint, URL or the "s3" one of Services.
+	S3ForcePathStyle bool `json:"s3ForcePathStyle,omitempty"`
+	// Anonymous sends unsigned requests, for emulators that don't check credentials.
+	Anonymous bool `json:"anonymous,omitempty"`
+}
+
+// Apply points the clients built from cfg at the endpoints of c: URL is the base endpoint of
+// cfg and the endpoints of Services are resolved by the clients like the endpoint_url of the
+// services section of a shared config. Services without an endpoint keep their AWS one.
+func (c *EndpointConfig) Apply(cfg *aws.Config) {
+	if c == nil || (c.URL == "" && len(c.Services) == 0) {
+		return
+	}
+
+	if c.URL != "" {
+		cfg.BaseEndpoint = aws.String(c.URL)
+	}
+	services := make(map[string]string, len(c.Services))
+	for service, url := range c.Services {
+		services[normalizeServiceID(service)] = url
+	}
+	// the first source with an endpoint for a service wins, ahead of the environment and the
+	// shared config
+	cfg.ConfigSources = append([]interface{}{endpointSource{services: services, s3UsePathStyle: c.S3ForcePathStyle}}, cfg.ConfigSources...)
+}
+
+// endpointSource is the config source of the endpoints of an EndpointConfig.
+type endpointSource struct {
+	services       map[string]string
+	s3UsePathStyle bool
+}
+
+// GetServiceBaseEndpoint returns the endpoint of the service with the sdk id sdkID, "DynamoDB"
+// or "S3" for instance.
+func (s endpointSource) GetServiceBaseEndpoint(_ context.Context, sdkID string) (string, bool, error) {
+	url, ok := s.services[normalizeServiceID(sdkID)]
+	return url, ok, nil
+}
+
+// GetS3UsePathStyle returns whether the buckets are addressed in the path of the S3 endpoint,
+// as the S3 clients of the
+// describers read it.
+func (s endpointSource) GetS3UsePathStyle(context.Context) (bool, bool, error) {
+	return s.s3UsePathStyle, s.s3UsePathStyle, nil
+}
+
+// hasS3Endpoint reports whether the S3 clients call an endpoint of c rather than the AWS one.
+func (c *EndpointConfig) hasS3Endpoint() bool {
+	if c.URL != "" {
+		return true
+	}
+	for service := range c.Services {
+		if normalizeServiceID(service) == normalizeServiceID(s3.ServiceID) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeServiceID(service string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(service))
+}
+
 type AccountConfig struct {
 	AccountID            string   `json:"accountId"`
 	Regions              []string `json:"regions"`
@@ -80,6 +166,8 @@ type AccountConfig struct {
 	ExternalID           *string  `json:"externalId,omitempty"`
 	AssumeAdminRoleName  string   `json:"assumeAdminRoleName,omitempty"`
 	AssumeRolePolicyName string   `json:"assumeRolePolicyName,omitempty"`
+	// Endpoints overrides the AWS endpoints the account is described from.
+	Endpoints *EndpointConfig `json:"endpoints,omitempty"`
 }
 
 func AccountConfigFromMap(m map[string]any) (AccountConfig, error) {
@@ -0,0 +1,194 @@
+package aws
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+const assumeRoleResponse = `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleResult>
+    <Credentials>
+      <AccessKeyId>ASIAEMULATOR</AccessKeyId>
+      <SecretAccessKey>secret</SecretAccessKey>
+      <SessionToken>token</SessionToken>
+      <Expiration>2100-01-01T00:00:00Z</Expiration>
+    </Credentials>
+    <AssumedRoleUser>
+      <Arn>arn:aws:sts::123456789012:assumed-role/describer/session</Arn>
+      <AssumedRoleId>AROAEMULATOR:session</AssumedRoleId>
+    </AssumedRoleUser>
+  </AssumeRoleResult>
+</AssumeRoleResponse>`
+
+// emulator records the requests it receives and answers AssumeRole, other calls get an empty
+// response.
+type emulator struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []*http.Request
+	actions  []string
+}
+
+func newEmulator(t *testing.T) *emulator {
+	e := &emulator{}
+	e.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		form, _ := url.ParseQuery(string(body))
+		action := form.Get("Action")
+		if target := r.Header.Get("X-Amz-Target"); target != "" {
+			action = target
+		}
+
+		e.mu.Lock()
+		e.requests = append(e.requests, r)
+		e.actions = append(e.actions, action)
+		e.mu.Unlock()
+
+		if action == "AssumeRole" {
+			w.Header().Set("Content-Type", "text/xml")
+			io.WriteString(w, assumeRoleResponse)
+		}
+	}))
+	t.Cleanup(e.Close)
+	return e
+}
+
+func (e *emulator) received() ([]*http.Request, []string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.requests, e.actions
+}
+
+func TestGetConfigEndpoints(t *testing.T) {
+	base := newEmulator(t)
+	dynamo := newEmulator(t)
+
+	endpoints := &EndpointConfig{
+		URL:              base.URL,
+		Services:         map[string]string{"DynamoDB": dynamo.URL},
+		S3ForcePathStyle: true,
+	}
+	cfg, err := GetConfig(context.Background(), "AKIAEMULATOR", "secret", "", "arn:aws:iam::123456789012:role/describer", nil, endpoints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.BaseEndpoint == nil || *cfg.BaseEndpoint != base.URL {
+		t.Errorf("base endpoint = %v, want %s", cfg.BaseEndpoint, base.URL)
+	}
+	cfg.Region = "us-east-1"
+	cfg.Retryer = func() aws.Retryer { return aws.NopRetryer{} }
+
+	ctx := context.Background()
+	ec2.NewFromConfig(cfg).DescribeVolumes(ctx, &ec2.DescribeVolumesInput{})
+	s3.NewFromConfig(cfg).ListObjectsV2(ctx, &s3.ListObjectsV2Input{Bucket: aws.String("bucket")})
+	dynamodb.NewFromConfig(cfg).ListTables(ctx, &dynamodb.ListTablesInput{})
+
+	requests, actions := base.received()
+	if want := []string{"AssumeRole", "DescribeVolumes", ""}; strings.Join(actions, ",") != strings.Join(want, ",") {
+		t.Fatalf("base endpoint received %q, want %q", actions, want)
+	}
+	if got := requests[1].Header.Get("Authorization"); !strings.Contains(got, "Credential=ASIAEMULATOR/") {
+		t.Errorf("DescribeVolumes is not signed with the assumed role credentials: %s", got)
+	}
+	if got := requests[2].URL.Path; got != "/bucket" {
+		t.Errorf("ListObjectsV2 path = %s, want the bucket in the path", got)
+	}
+
+	if _, actions := dynamo.received(); len(actions) != 1 || actions[0] != "DynamoDB_20120810.ListTables" {
+		t.Errorf("dynamodb endpoint received %q, want ListTables", actions)
+	}
+}
+
+func TestGetConfigAnonymous(t *testing.T) {
+	server := newEmulator(t)
+
+	cfg, err := GetConfig(context.Background(), "", "", "", "", nil, &EndpointConfig{URL: server.URL, Anonymous: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Retryer = func() aws.Retryer { return aws.NopRetryer{} }
+	ec2.NewFromConfig(cfg).DescribeVolumes(context.Background(), &ec2.DescribeVolumesInput{})
+
+	requests, _ := server.received()
+	if len(requests) != 1 {
+		t.Fatalf("received %d requests, want 1", len(requests))
+	}
+	if got := requests[0].Header.Get("Authorization"); got != "" {
+		t.Errorf("anonymous request is signed: %s", got)
+	}
+
+	if _, err := GetConfig(context.Background(), "AKIAEMULATOR", "secret", "", "", nil, &EndpointConfig{Anonymous: true}); err == nil {
+		t.Errorf("GetConfig() accepted anonymous credentials with an access key")
+	}
+}
+
+func TestGetConfigS3ForcePathStyle(t *testing.T) {
+	server := newEmulator(t)
+
+	// without an S3 endpoint, the S3 clients would call AWS with the bucket in the host.
+	for _, endpoints := range []*EndpointConfig{
+		{S3ForcePathStyle: true},
+		{Services: map[string]string{"dynamodb": server.URL}, S3ForcePathStyle: true},
+	} {
+		if _, err := GetConfig(context.Background(), "AKIAEMULATOR", "secret", "", "", nil, endpoints); err == nil {
+			t.Errorf("GetConfig() accepted S3ForcePathStyle with the endpoints %v", endpoints.Services)
+		}
+	}
+
+	cfg, err := GetConfig(context.Background(), "AKIAEMULATOR", "secret", "", "", nil, &EndpointConfig{
+		Services:         map[string]string{"S3": server.URL},
+		S3ForcePathStyle: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.BaseEndpoint != nil {
+		t.Errorf("base endpoint = %s, want none without an endpoint url", *cfg.BaseEndpoint)
+	}
+	if !s3UsePathStyle(cfg) {
+		t.Errorf("the config sources don't force the S3 path style")
+	}
+	cfg.Region = "us-east-1"
+	cfg.Retryer = func() aws.Retryer { return aws.NopRetryer{} }
+	s3.NewFromConfig(cfg, func(o *s3.Options) { o.UsePathStyle = s3UsePathStyle(cfg) }).
+		ListObjectsV2(context.Background(), &s3.ListObjectsV2Input{Bucket: aws.String("bucket")})
+
+	if requests, _ := server.received(); len(requests) != 1 || requests[0].URL.Path != "/bucket" {
+		t.Errorf("got %d requests, want ListObjectsV2 with the bucket in the path", len(requests))
+	}
+
+	cfg, err = GetConfig(context.Background(), "AKIAEMULATOR", "secret", "", "", nil, &EndpointConfig{URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s3UsePathStyle(cfg) {
+		t.Errorf("the config sources force the S3 path style without S3ForcePathStyle")
+	}
+}
+
+// s3UsePathStyle reports whether a config source of cfg forces the S3 path style, as the S3
+// clients of the describers read it.
+func s3UsePathStyle(cfg aws.Config) bool {
+	for _, source := range cfg.ConfigSources {
+		if p, ok := source.(interface {
+			GetS3UsePathStyle(ctx context.Context) (bool, bool, error)
+		}); ok {
+			if value, found, err := p.GetS3UsePathStyle(context.Background()); err == nil && found {
+				return value
+			}
+		}
+	}
+	return false
+}
@@ -207,6 +207,8 @@ func BackupSelection(ctx context.Context, cfg aws.Config, stream *StreamSender)
 						BackupSelection: v,
 						ListOfTags:      out.BackupSelection.ListOfTags,
 						Resources:       out.BackupSelection.Resources,
+						NotResources:    out.BackupSelection.NotResources,
+						Conditions:      out.BackupSelection.Conditions,
 					},
 				}
 				if stream != nil {
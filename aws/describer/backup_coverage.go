@@ -0,0 +1,361 @@
+package describer
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/backup"
+	backuptypes "github.com/aws/aws-sdk-go-v2/service/backup/types"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/efs"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/opengovern/og-aws-describer/aws/model"
+	"github.com/opengovern/og-aws-describer/pkg/coverage"
+)
+
+// backupCoverageResource is a resource AWS Backup can protect, with the tags backup selections
+// are evaluated against. TagsUnknown is set when the tags could not be read, Tags is then empty.
+type backupCoverageResource struct {
+	Arn         string
+	Type        string
+	Tags        map[string]string
+	TagsUnknown bool
+}
+
+// backupCoverageRecoveryPoint is the latest completed recovery point of a resource.
+type backupCoverageRecoveryPoint struct {
+	Arn          *string
+	CreationDate *time.Time
+}
+
+// BackupResourceCoverage describes, for every RDS, Aurora, Neptune, DocumentDB, EBS, EFS, DynamoDB
+// and S3 resource of the region, the backup plan rules whose selections assign it, and its latest
+// completed recovery point. Services the credentials can't list are left out and resources whose
+// tags can't be read are flagged with TagsUnknown, see isBackupCoverageAccessError.
+//
+// The resources are listed again by backupCoverageInventory rather than read from the indexed
+// resources of their own types: a describer only sees the account it describes, so the coverage
+// is evaluated against the resources and tags as they are when it runs, which can differ from
+// the last describe of those types.
+func BackupResourceCoverage(ctx context.Context, cfg aws.Config, stream *StreamSender) ([]Resource, error) {
+	plans, err := BackupPlan(ctx, cfg, nil)
+	if err != nil {
+		return nil, err
+	}
+	selections, err := BackupSelection(ctx, cfg, nil)
+	if err != nil {
+		return nil, err
+	}
+	recoveryPoints, err := backupLatestRecoveryPoints(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := backupCoverageInventory(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	planDetails := map[string]model.BackupPlanDescription{}
+	for _, plan := range plans {
+		description := plan.Description.(model.BackupPlanDescription)
+		planDetails[aws.ToString(description.BackupPlan.BackupPlanId)] = description
+	}
+
+	var values []Resource
+	for _, resource := range resources {
+		item := backupResourceCoverageHandle(ctx, resource, planDetails, selections, recoveryPoints[resource.Arn])
+		if stream != nil {
+			if err := (*stream)(item); err != nil {
+				return nil, err
+			}
+		} else {
+			values = append(values, item)
+		}
+	}
+
+	return values, nil
+}
+
+func backupResourceCoverageHandle(ctx context.Context, resource backupCoverageResource, plans map[string]model.BackupPlanDescription,
+	selections []Resource, recoveryPoint backupCoverageRecoveryPoint) Resource {
+	describeCtx := GetDescribeContext(ctx)
+
+	var rules []model.BackupCoverageRule
+	for _, item := range selections {
+		description := item.Description.(model.BackupSelectionDescription)
+		selection := coverage.Selection{
+			BackupPlanId:  aws.ToString(description.BackupSelection.BackupPlanId),
+			SelectionId:   aws.ToString(description.BackupSelection.SelectionId),
+			SelectionName: aws.ToString(description.BackupSelection.SelectionName),
+			Resources:     description.Resources,
+			NotResources:  description.NotResources,
+			ListOfTags:    description.ListOfTags,
+			Conditions:    description.Conditions,
+		}
+		if !selection.Covers(resource.Arn, resource.Tags) {
+			continue
+		}
+
+		plan := plans[selection.BackupPlanId]
+		for _, rule := range plan.PlanDetails.Rules {
+			rules = append(rules, model.BackupCoverageRule{
+				BackupPlanId:          selection.BackupPlanId,
+				BackupPlanName:        aws.ToString(plan.PlanDetails.BackupPlanName),
+				RuleName:              aws.ToString(rule.RuleName),
+				TargetBackupVaultName: aws.ToString(rule.TargetBackupVaultName),
+				ScheduleExpression:    aws.ToString(rule.ScheduleExpression),
+				SelectionId:           selection.SelectionId,
+				SelectionName:         selection.SelectionName,
+			})
+		}
+	}
+
+	return Resource{
+		Region: describeCtx.KaytuRegion,
+		ARN:    resource.Arn,
+		Name:   nameFromArn(resource.Arn),
+		Description: model.BackupResourceCoverageDescription{
+			ResourceArn:           resource.Arn,
+			ResourceType:          resource.Type,
+			Tags:                  resource.Tags,
+			TagsUnknown:           resource.TagsUnknown,
+			IsProtected:           len(rules) > 0,
+			CoveringRules:         rules,
+			LastRecoveryPointArn:  recoveryPoint.Arn,
+			LastRecoveryPointTime: recoveryPoint.CreationDate,
+		},
+	}
+}
+
+// backupLatestRecoveryPoints returns the latest completed recovery point of every resource
+// backed up to a vault of the region, by resource ARN.
+func backupLatestRecoveryPoints(ctx context.Context, cfg aws.Config) (map[string]backupCoverageRecoveryPoint, error) {
+	client := backup.NewFromConfig(cfg)
+
+	latest := map[string]backupCoverageRecoveryPoint{}
+	vaults := backup.NewListBackupVaultsPaginator(client, &backup.ListBackupVaultsInput{})
+	for vaults.HasMorePages() {
+		page, err := vaults.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, vault := range page.BackupVaultList {
+			paginator := backup.NewListRecoveryPointsByBackupVaultPaginator(client, &backup.ListRecoveryPointsByBackupVaultInput{
+				BackupVaultName: vault.BackupVaultName,
+			})
+			for paginator.HasMorePages() {
+				page, err := paginator.NextPage(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, v := range page.RecoveryPoints {
+					if v.Status != backuptypes.RecoveryPointStatusCompleted || v.ResourceArn == nil || v.CreationDate == nil {
+						continue
+					}
+					current, ok := latest[*v.ResourceArn]
+					if ok && !v.CreationDate.After(*current.CreationDate) {
+						continue
+					}
+					latest[*v.ResourceArn] = backupCoverageRecoveryPoint{
+						Arn:          v.RecoveryPointArn,
+						CreationDate: v.CreationDate,
+					}
+				}
+			}
+		}
+	}
+
+	return latest, nil
+}
+
+// isBackupCoverageAccessError reports whether err is an access error. The inventory skips the
+// services it can't list and reports the resources it can't read the tags of with unknown tags,
+// rather than failing the whole coverage on a single denied call.
+func isBackupCoverageAccessError(err error) bool {
+	return isErr(err, "AccessDenied") || isErr(err, "AccessDeniedException") || isErr(err, "UnauthorizedOperation")
+}
+
+// backupClusterResourceType returns the AWS Backup type of a cluster DescribeDBClusters returns,
+// which also lists the Neptune and DocumentDB clusters.
+func backupClusterResourceType(engine string) string {
+	switch {
+	case strings.HasPrefix(engine, "aurora"):
+		return "Aurora"
+	case engine == "neptune":
+		return "Neptune"
+	case engine == "docdb":
+		return "DocumentDB"
+	default:
+		return "RDS"
+	}
+}
+
+// backupCoverageInventory lists the resources of the region AWS Backup can protect, typed like
+// AWS Backup types them.
+func backupCoverageInventory(ctx context.Context, cfg aws.Config) ([]backupCoverageResource, error) {
+	describeCtx := GetDescribeContext(ctx)
+	var resources []backupCoverageResource
+
+	rdsClient := rds.NewFromConfig(cfg)
+	clusters := rds.NewDescribeDBClustersPaginator(rdsClient, &rds.DescribeDBClustersInput{})
+	for clusters.HasMorePages() {
+		page, err := clusters.NextPage(ctx)
+		if err != nil {
+			if isBackupCoverageAccessError(err) {
+				break
+			}
+			return nil, err
+		}
+		for _, v := range page.DBClusters {
+			resourceType := backupClusterResourceType(aws.ToString(v.Engine))
+			tags := map[string]string{}
+			for _, t := range v.TagList {
+				tags[aws.ToString(t.Key)] = aws.ToString(t.Value)
+			}
+			resources = append(resources, backupCoverageResource{Arn: aws.ToString(v.DBClusterArn), Type: resourceType, Tags: tags})
+		}
+	}
+
+	instances := rds.NewDescribeDBInstancesPaginator(rdsClient, &rds.DescribeDBInstancesInput{})
+	for instances.HasMorePages() {
+		page, err := instances.NextPage(ctx)
+		if err != nil {
+			if isBackupCoverageAccessError(err) {
+				break
+			}
+			return nil, err
+		}
+		for _, v := range page.DBInstances {
+			// Members of a DB cluster are backed up with the cluster.
+			if v.DBClusterIdentifier != nil {
+				continue
+			}
+			tags := map[string]string{}
+			for _, t := range v.TagList {
+				tags[aws.ToString(t.Key)] = aws.ToString(t.Value)
+			}
+			resources = append(resources, backupCoverageResource{Arn: aws.ToString(v.DBInstanceArn), Type: "RDS", Tags: tags})
+		}
+	}
+
+	ec2Client := ec2.NewFromConfig(cfg)
+	volumes := ec2.NewDescribeVolumesPaginator(ec2Client, &ec2.DescribeVolumesInput{})
+	for volumes.HasMorePages() {
+		page, err := volumes.NextPage(ctx)
+		if err != nil {
+			if isBackupCoverageAccessError(err) {
+				break
+			}
+			return nil, err
+		}
+		for _, v := range page.Volumes {
+			tags := map[string]string{}
+			for _, t := range v.Tags {
+				tags[aws.ToString(t.Key)] = aws.ToString(t.Value)
+			}
+			arn := "arn:" + describeCtx.Partition + ":ec2:" + describeCtx.Region + ":" + describeCtx.AccountID + ":volume/" + aws.ToString(v.VolumeId)
+			resources = append(resources, backupCoverageResource{Arn: arn, Type: "EBS", Tags: tags})
+		}
+	}
+
+	efsClient := efs.NewFromConfig(cfg)
+	fileSystems := efs.NewDescribeFileSystemsPaginator(efsClient, &efs.DescribeFileSystemsInput{})
+	for fileSystems.HasMorePages() {
+		page, err := fileSystems.NextPage(ctx)
+		if err != nil {
+			if isBackupCoverageAccessError(err) {
+				break
+			}
+			return nil, err
+		}
+		for _, v := range page.FileSystems {
+			tags := map[string]string{}
+			for _, t := range v.Tags {
+				tags[aws.ToString(t.Key)] = aws.ToString(t.Value)
+			}
+			resources = append(resources, backupCoverageResource{Arn: aws.ToString(v.FileSystemArn), Type: "EFS", Tags: tags})
+		}
+	}
+
+	dynamodbClient := dynamodb.NewFromConfig(cfg)
+	tables := dynamodb.NewListTablesPaginator(dynamodbClient, &dynamodb.ListTablesInput{})
+	for tables.HasMorePages() {
+		page, err := tables.NextPage(ctx)
+		if err != nil {
+			if isBackupCoverageAccessError(err) {
+				break
+			}
+			return nil, err
+		}
+		for _, name := range page.TableNames {
+			arn := "arn:" + describeCtx.Partition + ":dynamodb:" + describeCtx.Region + ":" + describeCtx.AccountID + ":table/" + name
+			resource := backupCoverageResource{Arn: arn, Type: "DynamoDB", Tags: map[string]string{}}
+			input := &dynamodb.ListTagsOfResourceInput{ResourceArn: aws.String(arn)}
+			for {
+				out, err := dynamodbClient.ListTagsOfResource(ctx, input)
+				if err != nil {
+					if !isBackupCoverageAccessError(err) {
+						return nil, err
+					}
+					resource.Tags, resource.TagsUnknown = map[string]string{}, true
+					break
+				}
+				for _, t := range out.Tags {
+					resource.Tags[aws.ToString(t.Key)] = aws.ToString(t.Value)
+				}
+				if out.NextToken == nil {
+					break
+				}
+				input.NextToken = out.NextToken
+			}
+			resources = append(resources, resource)
+		}
+	}
+
+	s3Client := newS3Client(cfg)
+	buckets, err := s3Client.ListBuckets(ctx, &s3.ListBucketsInput{})
+	if err != nil {
+		if isBackupCoverageAccessError(err) {
+			return resources, nil
+		}
+		return nil, err
+	}
+	for _, bucket := range buckets.Buckets {
+		region, err := getBucketLocation(ctx, s3Client, bucket)
+		if err != nil {
+			// a bucket whose region can't be read isn't reported in any region
+			if isBackupCoverageAccessError(err) {
+				continue
+			}
+			return nil, err
+		}
+		if region != describeCtx.Region {
+			continue
+		}
+		resource := backupCoverageResource{
+			Arn:  "arn:" + describeCtx.Partition + ":s3:::" + aws.ToString(bucket.Name),
+			Type: "S3",
+			Tags: map[string]string{},
+		}
+		tagging, err := getBucketTagging(ctx, s3Client, bucket)
+		if err != nil {
+			if !isBackupCoverageAccessError(err) {
+				return nil, err
+			}
+			resource.TagsUnknown = true
+		} else {
+			for _, t := range tagging.TagSet {
+				resource.Tags[aws.ToString(t.Key)] = aws.ToString(t.Value)
+			}
+		}
+		resources = append(resources, resource)
+	}
+
+	return resources, nil
+}
@@ -13,6 +13,8 @@ import (
 	iam2 "github.com/aws/aws-sdk-go/service/iam"
 	"github.com/gocarina/gocsv"
 	"github.com/opengovern/og-aws-describer/aws/model"
+	"github.com/opengovern/og-aws-describer/pkg/policy"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -1961,3 +1963,303 @@ func GetIAMOpenIdConnectProvider(ctx context.Context, cfg aws.Config, fields map
 	values = append(values, resource)
 	return values, nil
 }
+
+func IAMEffectivePermission(ctx context.Context, cfg aws.Config, stream *StreamSender) ([]Resource, error) {
+	client := iam.NewFromConfig(cfg)
+	documents := &iamPolicyDocuments{client: client, managed: map[string]policy.Policy{}}
+
+	var values []Resource
+	send := func(resource *Resource) error {
+		if resource == nil {
+			return nil
+		}
+		if stream != nil {
+			return (*stream)(*resource)
+		}
+		values = append(values, *resource)
+		return nil
+	}
+
+	users := iam.NewListUsersPaginator(client, &iam.ListUsersInput{})
+	for users.HasMorePages() {
+		page, err := users.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range page.Users {
+			resource, err := iAMUserEffectivePermissionHandle(ctx, client, documents, v)
+			if err != nil {
+				return nil, err
+			}
+			if err := send(resource); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	roles := iam.NewListRolesPaginator(client, &iam.ListRolesInput{})
+	for roles.HasMorePages() {
+		page, err := roles.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range page.Roles {
+			resource, err := iAMRoleEffectivePermissionHandle(ctx, client, documents, v)
+			if err != nil {
+				return nil, err
+			}
+			if err := send(resource); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	groups := iam.NewListGroupsPaginator(client, &iam.ListGroupsInput{})
+	for groups.HasMorePages() {
+		page, err := groups.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range page.Groups {
+			inline, err := getGroupPolicies(ctx, client, v.GroupName)
+			if err != nil {
+				if isIAMEntityGone(err) {
+					continue
+				}
+				return nil, err
+			}
+			attached, err := getGroupAttachedPolicyArns(ctx, client, v.GroupName)
+			if err != nil {
+				if isIAMEntityGone(err) {
+					continue
+				}
+				return nil, err
+			}
+			resource, err := iAMEffectivePermissionHandle(ctx, documents, iamPrincipalPolicies{
+				arn:           *v.Arn,
+				name:          *v.GroupName,
+				principalType: "group",
+				inline:        inline,
+				attached:      attached,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err := send(resource); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return values, nil
+}
+
+func iAMUserEffectivePermissionHandle(ctx context.Context, client *iam.Client, documents *iamPolicyDocuments, v types.User) (*Resource, error) {
+	// ListUsers leaves the permissions boundary out
+	user, err := client.GetUser(ctx, &iam.GetUserInput{UserName: v.UserName})
+	if err != nil {
+		if isIAMEntityGone(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	principal := iamPrincipalPolicies{
+		arn:           *v.Arn,
+		name:          *v.UserName,
+		principalType: "user",
+	}
+	if user.User.PermissionsBoundary != nil {
+		principal.boundaryArn = aws.ToString(user.User.PermissionsBoundary.PermissionsBoundaryArn)
+	}
+
+	if principal.inline, err = getUserPolicies(ctx, client, v.UserName); err != nil {
+		if isIAMEntityGone(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if principal.attached, err = getUserAttachedPolicyArns(ctx, client, v.UserName); err != nil {
+		if isIAMEntityGone(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	groups, err := getUserGroups(ctx, client, v.UserName)
+	if err != nil {
+		if isIAMEntityGone(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	for _, group := range groups {
+		inline, err := getGroupPolicies(ctx, client, group.GroupName)
+		if err != nil {
+			return nil, err
+		}
+		attached, err := getGroupAttachedPolicyArns(ctx, client, group.GroupName)
+		if err != nil {
+			return nil, err
+		}
+		principal.groupNames = append(principal.groupNames, *group.GroupName)
+		principal.groupInline = append(principal.groupInline, inline...)
+		principal.groupAttached = append(principal.groupAttached, attached...)
+	}
+
+	return iAMEffectivePermissionHandle(ctx, documents, principal)
+}
+
+func iAMRoleEffectivePermissionHandle(ctx context.Context, client *iam.Client, documents *iamPolicyDocuments, v types.Role) (*Resource, error) {
+	// ListRoles leaves the permissions boundary out
+	role, err := client.GetRole(ctx, &iam.GetRoleInput{RoleName: v.RoleName})
+	if err != nil {
+		if isIAMEntityGone(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	principal := iamPrincipalPolicies{
+		arn:           *v.Arn,
+		name:          *v.RoleName,
+		principalType: "role",
+	}
+	if role.Role.PermissionsBoundary != nil {
+		principal.boundaryArn = aws.ToString(role.Role.PermissionsBoundary.PermissionsBoundaryArn)
+	}
+
+	if principal.inline, err = getRolePolicies(ctx, client, v.RoleName); err != nil {
+		if isIAMEntityGone(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if principal.attached, err = getRoleAttachedPolicyArns(ctx, client, v.RoleName); err != nil {
+		if isIAMEntityGone(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return iAMEffectivePermissionHandle(ctx, documents, principal)
+}
+
+// iamPrincipalPolicies are the policies of a user, role or group the effective permissions are
+// computed from. The group fields are set for users only.
+type iamPrincipalPolicies struct {
+	arn           string
+	name          string
+	principalType string
+	inline        []model.InlinePolicy
+	attached      []string
+	boundaryArn   string
+	groupNames    []string
+	groupInline   []model.InlinePolicy
+	groupAttached []string
+}
+
+func iAMEffectivePermissionHandle(ctx context.Context, documents *iamPolicyDocuments, principal iamPrincipalPolicies) (*Resource, error) {
+	describeCtx := GetDescribeContext(ctx)
+
+	var identity []policy.Policy
+	var inlineNames []string
+	for _, p := range append(append([]model.InlinePolicy(nil), principal.inline...), principal.groupInline...) {
+		document, err := parseIAMPolicyDocument(p.PolicyDocument)
+		if err != nil {
+			return nil, fmt.Errorf("inline policy %s of %s: %w", p.PolicyName, principal.arn, err)
+		}
+		identity = append(identity, document)
+	}
+	for _, p := range principal.inline {
+		inlineNames = append(inlineNames, p.PolicyName)
+	}
+	for _, arn := range append(append([]string(nil), principal.attached...), principal.groupAttached...) {
+		document, err := documents.get(ctx, arn)
+		if err != nil {
+			return nil, err
+		}
+		identity = append(identity, document)
+	}
+
+	var boundary *policy.Policy
+	if principal.boundaryArn != "" {
+		document, err := documents.get(ctx, principal.boundaryArn)
+		if err != nil {
+			return nil, err
+		}
+		boundary = &document
+	}
+
+	effective := policy.Effective(identity, boundary)
+	var paths []model.IAMPrivilegeEscalationPath
+	for _, path := range effective.PrivilegeEscalationPaths {
+		paths = append(paths, model.IAMPrivilegeEscalationPath{
+			Name:    path.Name,
+			Actions: path.Actions,
+		})
+	}
+
+	resource := Resource{
+		Region: describeCtx.KaytuRegion,
+		ARN:    principal.arn,
+		Name:   principal.name,
+		Description: model.IAMEffectivePermissionDescription{
+			PrincipalArn:             principal.arn,
+			PrincipalName:            principal.name,
+			PrincipalType:            principal.principalType,
+			AttachedPolicyArns:       principal.attached,
+			InlinePolicyNames:        inlineNames,
+			GroupNames:               principal.groupNames,
+			PermissionsBoundaryArn:   principal.boundaryArn,
+			AllowedActions:           effective.AllowedActions,
+			IsAdminEquivalent:        effective.IsAdminEquivalent,
+			PrivilegeEscalationPaths: paths,
+		},
+	}
+	return &resource, nil
+}
+
+// iamPolicyDocuments resolves managed policies to the document of their default version, once
+// per describe as the same policies are attached to many principals.
+type iamPolicyDocuments struct {
+	client  *iam.Client
+	managed map[string]policy.Policy
+}
+
+func (d *iamPolicyDocuments) get(ctx context.Context, arn string) (policy.Policy, error) {
+	if document, ok := d.managed[arn]; ok {
+		return document, nil
+	}
+
+	p, err := d.client.GetPolicy(ctx, &iam.GetPolicyInput{PolicyArn: aws.String(arn)})
+	if err != nil {
+		return policy.Policy{}, err
+	}
+	version, err := d.client.GetPolicyVersion(ctx, &iam.GetPolicyVersionInput{
+		PolicyArn: aws.String(arn),
+		VersionId: p.Policy.DefaultVersionId,
+	})
+	if err != nil {
+		return policy.Policy{}, err
+	}
+	document, err := parseIAMPolicyDocument(aws.ToString(version.PolicyVersion.Document))
+	if err != nil {
+		return policy.Policy{}, fmt.Errorf("managed policy %s: %w", arn, err)
+	}
+	d.managed[arn] = document
+	return document, nil
+}
+
+// parseIAMPolicyDocument parses a policy document as IAM returns it, URL encoded.
+func parseIAMPolicyDocument(document string) (policy.Policy, error) {
+	decoded, err := url.QueryUnescape(document)
+	if err != nil {
+		return policy.Policy{}, err
+	}
+	return policy.Parse(decoded)
+}
+
+// isIAMEntityGone reports whether the principal was deleted since it was listed. Access errors
+// are not, the principal would be missing from the effective permissions without a trace.
+func isIAMEntityGone(err error) bool {
+	return isErr(err, "NoSuchEntity")
+}
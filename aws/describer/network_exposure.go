@@ -0,0 +1,261 @@
+package describer
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/opengovern/og-aws-describer/aws/model"
+	"github.com/opengovern/og-aws-describer/pkg/exposure"
+)
+
+const (
+	networkExposureInterface    = "network_interface"
+	networkExposureLoadBalancer = "load_balancer"
+)
+
+// EC2NetworkExposure describes the internet reachability of every network interface and every
+// ELBv2 load balancer of the region, from the route tables, network ACLs and security groups
+// of their subnets. Exposure is reported per network interface rather than per instance, each
+// interface of an instance has its own subnet, addresses and security groups, the resources of
+// an instance are those with its InstanceId.
+func EC2NetworkExposure(ctx context.Context, cfg aws.Config, stream *StreamSender) ([]Resource, error) {
+	client := ec2.NewFromConfig(cfg)
+
+	network, groups, err := describeNetworkExposureInputs(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []Resource
+	send := func(resource Resource) error {
+		if stream != nil {
+			return (*stream)(resource)
+		}
+		values = append(values, resource)
+		return nil
+	}
+
+	interfaces := ec2.NewDescribeNetworkInterfacesPaginator(client, &ec2.DescribeNetworkInterfacesInput{})
+	for interfaces.HasMorePages() {
+		page, err := interfaces.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range page.NetworkInterfaces {
+			if err := send(eC2NetworkInterfaceExposureHandle(ctx, network, groups, v)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	elbClient := elasticloadbalancingv2.NewFromConfig(cfg)
+	loadBalancers := elasticloadbalancingv2.NewDescribeLoadBalancersPaginator(elbClient, &elasticloadbalancingv2.DescribeLoadBalancersInput{})
+	for loadBalancers.HasMorePages() {
+		page, err := loadBalancers.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range page.LoadBalancers {
+			var listeners []elbv2types.Listener
+			paginator := elasticloadbalancingv2.NewDescribeListenersPaginator(elbClient, &elasticloadbalancingv2.DescribeListenersInput{
+				LoadBalancerArn: v.LoadBalancerArn,
+			})
+			for paginator.HasMorePages() {
+				listenersPage, err := paginator.NextPage(ctx)
+				if err != nil {
+					return nil, err
+				}
+				listeners = append(listeners, listenersPage.Listeners...)
+			}
+
+			if err := send(eC2LoadBalancerExposureHandle(ctx, network, groups, v, listeners)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return values, nil
+}
+
+func describeNetworkExposureInputs(ctx context.Context, client *ec2.Client) (exposure.Network, map[string]types.SecurityGroup, error) {
+	var network exposure.Network
+
+	routeTables := ec2.NewDescribeRouteTablesPaginator(client, &ec2.DescribeRouteTablesInput{})
+	for routeTables.HasMorePages() {
+		page, err := routeTables.NextPage(ctx)
+		if err != nil {
+			return network, nil, err
+		}
+		network.RouteTables = append(network.RouteTables, page.RouteTables...)
+	}
+
+	acls := ec2.NewDescribeNetworkAclsPaginator(client, &ec2.DescribeNetworkAclsInput{})
+	for acls.HasMorePages() {
+		page, err := acls.NextPage(ctx)
+		if err != nil {
+			return network, nil, err
+		}
+		network.NetworkAcls = append(network.NetworkAcls, page.NetworkAcls...)
+	}
+
+	groups := map[string]types.SecurityGroup{}
+	securityGroups := ec2.NewDescribeSecurityGroupsPaginator(client, &ec2.DescribeSecurityGroupsInput{})
+	for securityGroups.HasMorePages() {
+		page, err := securityGroups.NextPage(ctx)
+		if err != nil {
+			return network, nil, err
+		}
+		for _, group := range page.SecurityGroups {
+			groups[*group.GroupId] = group
+		}
+	}
+
+	return network, groups, nil
+}
+
+func eC2NetworkInterfaceExposureHandle(ctx context.Context, network exposure.Network, groups map[string]types.SecurityGroup, v types.NetworkInterface) Resource {
+	describeCtx := GetDescribeContext(ctx)
+
+	description := model.EC2NetworkExposureDescription{
+		ResourceId:   *v.NetworkInterfaceId,
+		ResourceType: networkExposureInterface,
+		VpcId:        aws.ToString(v.VpcId),
+	}
+	if v.Attachment != nil {
+		description.InstanceId = aws.ToString(v.Attachment.InstanceId)
+	}
+	if v.SubnetId != nil {
+		description.SubnetIds = []string{*v.SubnetId}
+	}
+
+	// the address families the interface has a public address of
+	var families exposure.AddressFamily
+	seen := map[string]bool{}
+	addPublicIp := func(ip *string, family exposure.AddressFamily) {
+		if ip != nil && *ip != "" && !seen[*ip] {
+			seen[*ip] = true
+			description.PublicIps = append(description.PublicIps, *ip)
+			families |= family
+		}
+	}
+	if v.Association != nil {
+		addPublicIp(v.Association.PublicIp, exposure.IPv4)
+	}
+	for _, address := range v.PrivateIpAddresses {
+		if address.Association != nil {
+			addPublicIp(address.Association.PublicIp, exposure.IPv4)
+		}
+	}
+	for _, address := range v.Ipv6Addresses {
+		addPublicIp(address.Ipv6Address, exposure.IPv6)
+	}
+
+	var securityGroups []types.SecurityGroup
+	for _, identifier := range v.Groups {
+		description.SecurityGroupIds = append(description.SecurityGroupIds, aws.ToString(identifier.GroupId))
+		if group, ok := groups[aws.ToString(identifier.GroupId)]; ok {
+			securityGroups = append(securityGroups, group)
+		}
+	}
+
+	acl := network.NetworkAcl(description.VpcId, aws.ToString(v.SubnetId))
+	if acl != nil {
+		description.NetworkAclIds = []string{aws.ToString(acl.NetworkAclId)}
+	}
+	gateway, routed := network.InternetGateway(description.VpcId, aws.ToString(v.SubnetId), families)
+	description.InternetGatewayId = gateway
+
+	if gateway != "" {
+		for _, r := range exposure.InterfaceExposure(securityGroups, acl, routed) {
+			description.ExposedPorts = append(description.ExposedPorts, model.EC2NetworkExposurePortRange(r))
+		}
+	}
+	description.IsInternetReachable = len(description.ExposedPorts) > 0
+
+	return Resource{
+		Region:      describeCtx.KaytuRegion,
+		ARN:         "arn:" + describeCtx.Partition + ":ec2:" + describeCtx.Region + ":" + describeCtx.AccountID + ":network-interface/" + *v.NetworkInterfaceId,
+		Name:        *v.NetworkInterfaceId,
+		Description: description,
+	}
+}
+
+func eC2LoadBalancerExposureHandle(ctx context.Context, network exposure.Network, groups map[string]types.SecurityGroup, v elbv2types.LoadBalancer, listeners []elbv2types.Listener) Resource {
+	describeCtx := GetDescribeContext(ctx)
+
+	description := model.EC2NetworkExposureDescription{
+		ResourceId:       *v.LoadBalancerArn,
+		ResourceType:     networkExposureLoadBalancer,
+		VpcId:            aws.ToString(v.VpcId),
+		SecurityGroupIds: v.SecurityGroups,
+	}
+
+	families := exposure.IPv4
+	if v.IpAddressType == elbv2types.IpAddressTypeDualstack {
+		families |= exposure.IPv6
+	}
+
+	// the subnets of a load balancer are routed alike, the families routed by any of them are
+	// taken for all
+	var acls []*types.NetworkAcl
+	var routed exposure.AddressFamily
+	for _, zone := range v.AvailabilityZones {
+		subnetId := aws.ToString(zone.SubnetId)
+		description.SubnetIds = append(description.SubnetIds, subnetId)
+		for _, address := range zone.LoadBalancerAddresses {
+			if address.IpAddress != nil {
+				description.PublicIps = append(description.PublicIps, *address.IpAddress)
+			}
+		}
+
+		acl := network.NetworkAcl(description.VpcId, subnetId)
+		if acl != nil {
+			description.NetworkAclIds = append(description.NetworkAclIds, aws.ToString(acl.NetworkAclId))
+		}
+		gateway, subnetRouted := network.InternetGateway(description.VpcId, subnetId, families)
+		if gateway == "" {
+			continue
+		}
+		description.InternetGatewayId = gateway
+		routed |= subnetRouted
+		acls = append(acls, acl)
+	}
+
+	var securityGroups []types.SecurityGroup
+	for _, id := range v.SecurityGroups {
+		if group, ok := groups[id]; ok {
+			securityGroups = append(securityGroups, group)
+		}
+	}
+
+	var ports []exposure.Listener
+	for _, listener := range listeners {
+		port := aws.ToInt32(listener.Port)
+		switch listener.Protocol {
+		case elbv2types.ProtocolEnumHttp, elbv2types.ProtocolEnumHttps, elbv2types.ProtocolEnumTcp, elbv2types.ProtocolEnumTls:
+			ports = append(ports, exposure.Listener{Protocol: "tcp", Port: port})
+		case elbv2types.ProtocolEnumUdp:
+			ports = append(ports, exposure.Listener{Protocol: "udp", Port: port})
+		case elbv2types.ProtocolEnumTcpUdp:
+			ports = append(ports, exposure.Listener{Protocol: "tcp", Port: port}, exposure.Listener{Protocol: "udp", Port: port})
+		}
+	}
+
+	if v.Scheme == elbv2types.LoadBalancerSchemeEnumInternetFacing {
+		for _, r := range exposure.LoadBalancerExposure(securityGroups, ports, acls, routed) {
+			description.ExposedPorts = append(description.ExposedPorts, model.EC2NetworkExposurePortRange(r))
+		}
+	}
+	description.IsInternetReachable = len(description.ExposedPorts) > 0
+
+	return Resource{
+		Region:      describeCtx.KaytuRegion,
+		ARN:         *v.LoadBalancerArn,
+		Name:        aws.ToString(v.LoadBalancerName),
+		Description: description,
+	}
+}
@@ -2,12 +2,14 @@ package describer
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/organizations"
 	"github.com/aws/aws-sdk-go-v2/service/organizations/types"
 	"github.com/opengovern/og-aws-describer/aws/model"
+	"github.com/opengovern/og-aws-describer/pkg/policy"
 )
 
 // OrganizationOrganization Retrieves information about the organization that the
@@ -476,3 +478,206 @@ func organizationsPolicyForTargetByPolicyType(ctx context.Context, svc *organiza
 	}
 	return values, nil
 }
+
+func OrganizationsAccountEffectivePolicy(ctx context.Context, cfg aws.Config, stream *StreamSender) ([]Resource, error) {
+	client := organizations.NewFromConfig(cfg)
+	policies := &organizationsTargetPolicies{client: client, contents: map[string]string{}}
+
+	var values []Resource
+	send := func(resource Resource) error {
+		if stream != nil {
+			return (*stream)(resource)
+		}
+		values = append(values, resource)
+		return nil
+	}
+
+	paginator := organizations.NewListRootsPaginator(client, &organizations.ListRootsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			// member accounts can't list the roots, only the management account sees the policies
+			if isErr(err, organizationsNotInUseException) || isErr(err, "AccessDeniedException") {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		for _, root := range page.Roots {
+			path := []model.OrganizationsParent{{
+				Id:   *root.Id,
+				Name: aws.ToString(root.Name),
+				Type: string(types.TargetTypeRoot),
+			}}
+			if err := organizationsWalkEffectivePolicies(ctx, client, policies, *root.Id, path, nil, send); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// organizationsWalkEffectivePolicies sends the effective policies of the accounts under parentId
+// and of its nested OUs. path is the chain of parents from the root down to parentId, levels the
+// policies attached to every parent above parentId.
+func organizationsWalkEffectivePolicies(ctx context.Context, svc *organizations.Client, policies *organizationsTargetPolicies,
+	parentId string, path []model.OrganizationsParent, levels [][]model.OrganizationsInheritedPolicy, send func(Resource) error) error {
+	parent := path[len(path)-1]
+	attached, err := policies.forTarget(ctx, parentId, parent.Type)
+	if err != nil {
+		return err
+	}
+	levels = append(levels[:len(levels):len(levels)], attached)
+
+	accounts := organizations.NewListAccountsForParentPaginator(svc, &organizations.ListAccountsForParentInput{
+		ParentId: aws.String(parentId),
+	})
+	for accounts.HasMorePages() {
+		page, err := accounts.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, account := range page.Accounts {
+			own, err := policies.forTarget(ctx, *account.Id, string(types.TargetTypeAccount))
+			if err != nil {
+				return err
+			}
+			resource, err := organizationsAccountEffectivePolicyHandle(ctx, account, path, append(levels[:len(levels):len(levels)], own))
+			if err != nil {
+				return err
+			}
+			if err := send(resource); err != nil {
+				return err
+			}
+		}
+	}
+
+	units := organizations.NewListOrganizationalUnitsForParentPaginator(svc, &organizations.ListOrganizationalUnitsForParentInput{
+		ParentId: aws.String(parentId),
+	})
+	for units.HasMorePages() {
+		page, err := units.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, unit := range page.OrganizationalUnits {
+			unitPath := append(path[:len(path):len(path)], model.OrganizationsParent{
+				Id:   *unit.Id,
+				Name: aws.ToString(unit.Name),
+				Type: string(types.TargetTypeOrganizationalUnit),
+			})
+			if err := organizationsWalkEffectivePolicies(ctx, svc, policies, *unit.Id, unitPath, levels, send); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func organizationsAccountEffectivePolicyHandle(ctx context.Context, account types.Account, path []model.OrganizationsParent, levels [][]model.OrganizationsInheritedPolicy) (Resource, error) {
+	describeCtx := GetDescribeContext(ctx)
+
+	description := model.OrganizationsAccountEffectivePolicyDescription{
+		AccountId:   *account.Id,
+		AccountName: aws.ToString(account.Name),
+		RootId:      path[0].Id,
+		ParentId:    path[len(path)-1].Id,
+		OUPath:      path,
+	}
+	var ids []string
+	for _, parent := range path {
+		ids = append(ids, strings.Replace(parent.Id, "-", "_", -1))
+	}
+	description.Path = strings.Join(ids, ".")
+
+	var tagLevels, backupLevels [][]string
+	for i, level := range levels {
+		var tagDocuments, backupDocuments []string
+		for _, p := range level {
+			p.Inherited = i < len(levels)-1
+			switch p.PolicySummary.Type {
+			case types.PolicyTypeServiceControlPolicy:
+				description.ServiceControlPolicies = append(description.ServiceControlPolicies, p)
+			case types.PolicyTypeTagPolicy:
+				description.TagPolicies = append(description.TagPolicies, p)
+				tagDocuments = append(tagDocuments, p.PolicyContent)
+			case types.PolicyTypeBackupPolicy:
+				description.BackupPolicies = append(description.BackupPolicies, p)
+				backupDocuments = append(backupDocuments, p.PolicyContent)
+			}
+		}
+		tagLevels = append(tagLevels, tagDocuments)
+		backupLevels = append(backupLevels, backupDocuments)
+	}
+
+	var err error
+	if description.EffectiveTagPolicy, err = policy.MergeManagementPolicies(tagLevels); err != nil {
+		return Resource{}, fmt.Errorf("tag policies of account %s: %w", *account.Id, err)
+	}
+	if description.EffectiveBackupPolicy, err = policy.MergeManagementPolicies(backupLevels); err != nil {
+		return Resource{}, fmt.Errorf("backup policies of account %s: %w", *account.Id, err)
+	}
+
+	return Resource{
+		Region:      describeCtx.KaytuRegion,
+		ARN:         aws.ToString(account.Arn),
+		Name:        aws.ToString(account.Name),
+		ID:          *account.Id,
+		Description: description,
+	}, nil
+}
+
+// organizationsTargetPolicies lists the policies attached to a root, OU or account, caching the
+// policy contents as the same policies are attached to many targets.
+type organizationsTargetPolicies struct {
+	client   *organizations.Client
+	contents map[string]string
+}
+
+func (p *organizationsTargetPolicies) forTarget(ctx context.Context, targetId, targetType string) ([]model.OrganizationsInheritedPolicy, error) {
+	var values []model.OrganizationsInheritedPolicy
+	for _, pType := range []types.PolicyType{types.PolicyTypeServiceControlPolicy, types.PolicyTypeTagPolicy, types.PolicyTypeBackupPolicy} {
+		paginator := organizations.NewListPoliciesForTargetPaginator(p.client, &organizations.ListPoliciesForTargetInput{
+			Filter:   pType,
+			TargetId: aws.String(targetId),
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				if isErr(err, "PolicyTypeNotEnabledException") {
+					break
+				}
+				return nil, err
+			}
+			for _, summary := range page.Policies {
+				content, err := p.content(ctx, *summary.Id)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, model.OrganizationsInheritedPolicy{
+					PolicySummary: summary,
+					PolicyContent: content,
+					TargetId:      targetId,
+					TargetType:    targetType,
+				})
+			}
+		}
+	}
+	return values, nil
+}
+
+func (p *organizationsTargetPolicies) content(ctx context.Context, policyId string) (string, error) {
+	if content, ok := p.contents[policyId]; ok {
+		return content, nil
+	}
+	output, err := p.client.DescribePolicy(ctx, &organizations.DescribePolicyInput{
+		PolicyId: aws.String(policyId),
+	})
+	if err != nil {
+		return "", err
+	}
+	content := aws.ToString(output.Policy.Content)
+	p.contents[policyId] = content
+	return content, nil
+}
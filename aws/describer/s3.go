@@ -27,6 +27,26 @@ const (
 	s3BucketNoOfWorkers                              = 8
 )
 
+// s3PathStyleSource is a config source forcing the path style of the S3 clients, such as the one
+// of the endpoints of an account config.
+type s3PathStyleSource interface {
+	GetS3UsePathStyle(ctx context.Context) (value, found bool, err error)
+}
+
+// newS3Client returns an S3 client of cfg, addressing the buckets in the path of the endpoint when
+// a config source of cfg forces it.
+func newS3Client(cfg aws.Config, optFns ...func(*s3.Options)) *s3.Client {
+	for _, source := range cfg.ConfigSources {
+		if p, ok := source.(s3PathStyleSource); ok {
+			if value, found, err := p.GetS3UsePathStyle(context.Background()); err == nil && found {
+				optFns = append([]func(*s3.Options){func(o *s3.Options) { o.UsePathStyle = value }}, optFns...)
+				break
+			}
+		}
+	}
+	return s3.NewFromConfig(cfg, optFns...)
+}
+
 type s3bucketResult struct {
 	Bucket   types.Bucket
 	Resource Resource
@@ -38,7 +58,7 @@ type s3bucketResult struct {
 // ListBuckets returns buckets in all regions. However, this function categorizes the buckets based
 // on their location constaint, aka the regions they reside in.
 func S3Bucket(ctx context.Context, cfg aws.Config, stream *StreamSender) ([]Resource, error) {
-	client := s3.NewFromConfig(cfg)
+	client := newS3Client(cfg)
 	output, err := client.ListBuckets(ctx, &s3.ListBucketsInput{})
 	if err != nil {
 		return nil, fmt.Errorf("error listing buckets: %w", err)
@@ -86,7 +106,7 @@ func s3BucketHandle(ctx context.Context, region string, desc *model.S3BucketDesc
 func GetS3Bucket(ctx context.Context, cfg aws.Config, fields map[string]string) ([]Resource, error) {
 	bucketName := fields["buketName"]
 
-	client := s3.NewFromConfig(cfg)
+	client := newS3Client(cfg)
 	output, err := client.ListBuckets(ctx, &s3.ListBucketsInput{})
 	if err != nil {
 		return nil, fmt.Errorf("error listing buckets: %w", err)
@@ -133,7 +153,7 @@ func getBucketLocation(ctx context.Context, client *s3.Client, bucket types.Buck
 }
 
 func getBucketDescription(ctx context.Context, cfg aws.Config, bucket types.Bucket, region string) (*model.S3BucketDescription, error) {
-	rClient := s3.NewFromConfig(cfg, func(o *s3.Options) { o.Region = region })
+	rClient := newS3Client(cfg, func(o *s3.Options) { o.Region = region })
 	o1, err := getBucketIsPublic(ctx, rClient, bucket)
 	if err != nil {
 		return nil, err
@@ -582,7 +602,7 @@ func S3AccountSetting(ctx context.Context, cfg aws.Config, stream *StreamSender)
 
 func S3Object(ctx context.Context, cfg aws.Config, stream *StreamSender) ([]Resource, error) {
 	describeCtx := GetDescribeContext(ctx)
-	client := s3.NewFromConfig(cfg)
+	client := newS3Client(cfg)
 	buckets, err := client.ListBuckets(ctx, &s3.ListBucketsInput{})
 	if err != nil {
 		return nil, err
@@ -593,7 +613,7 @@ func S3Object(ctx context.Context, cfg aws.Config, stream *StreamSender) ([]Reso
 		if err != nil {
 			return nil, err
 		}
-		regionalClient := s3.NewFromConfig(cfg, func(o *s3.Options) { o.Region = region })
+		regionalClient := newS3Client(cfg, func(o *s3.Options) { o.Region = region })
 		paginator := s3.NewListObjectsV2Paginator(regionalClient, &s3.ListObjectsV2Input{Bucket: bucket.Name})
 		for paginator.HasMorePages() {
 			page, err := paginator.NextPage(ctx)
@@ -662,7 +682,7 @@ func S3Object(ctx context.Context, cfg aws.Config, stream *StreamSender) ([]Reso
 }
 
 func S3BucketIntelligentTieringConfiguration(ctx context.Context, cfg aws.Config, stream *StreamSender) ([]Resource, error) {
-	client := s3.NewFromConfig(cfg)
+	client := newS3Client(cfg)
 	buckets, err := client.ListBuckets(ctx, &s3.ListBucketsInput{})
 	if err != nil {
 		return nil, err
@@ -673,7 +693,7 @@ func S3BucketIntelligentTieringConfiguration(ctx context.Context, cfg aws.Config
 		if err != nil {
 			return nil, err
 		}
-		regionalClient := s3.NewFromConfig(cfg, func(o *s3.Options) { o.Region = region })
+		regionalClient := newS3Client(cfg, func(o *s3.Options) { o.Region = region })
 		conf, err := regionalClient.ListBucketIntelligentTieringConfigurations(ctx, &s3.ListBucketIntelligentTieringConfigurationsInput{
 			Bucket: bucket.Name,
 		})
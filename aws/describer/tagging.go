@@ -39,3 +39,32 @@ func TaggingResources(ctx context.Context, cfg aws.Config, stream *StreamSender)
 	}
 	return values, nil
 }
+
+// TaggingResourceTags returns the tags of every resource visible to the Resource Groups
+// Tagging API in the configured region, keyed by resource ARN.
+func TaggingResourceTags(ctx context.Context, cfg aws.Config) (map[string]map[string]string, error) {
+	client := resourcegroupstaggingapi.NewFromConfig(cfg)
+	paginator := resourcegroupstaggingapi.NewGetResourcesPaginator(client, &resourcegroupstaggingapi.GetResourcesInput{})
+
+	tags := make(map[string]map[string]string)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range page.ResourceTagMappingList {
+			if v.ResourceARN == nil || len(v.Tags) == 0 {
+				continue
+			}
+			m := make(map[string]string, len(v.Tags))
+			for _, t := range v.Tags {
+				if t.Key == nil {
+					continue
+				}
+				m[*t.Key] = aws.ToString(t.Value)
+			}
+			tags[*v.ResourceARN] = m
+		}
+	}
+	return tags, nil
+}
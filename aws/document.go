@@ -0,0 +1,61 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/opengovern/og-aws-describer/aws/describer"
+	"github.com/opengovern/og-aws-describer/aws/model"
+	"github.com/opengovern/og-util/pkg/es"
+)
+
+// ResourceMetadata sets the account, type and partition of r, described in accountID, and
+// returns the metadata of its resource document. sourceID is the id of the account in
+// opengovernance. The worker and the live fallback of the tables build documents with it, so
+// the columns read from either are the same.
+func ResourceMetadata(r *describer.Resource, resourceType, accountID, sourceID string) (map[string]string, error) {
+	partition, _ := PartitionOf(r.Region)
+	if partition == "" {
+		partition = "aws"
+	}
+	r.Account = accountID
+	r.Type = strings.ToLower(resourceType)
+	r.Partition = partition
+
+	awsMetadata := model.Metadata{
+		Name:         r.Name,
+		AccountID:    accountID,
+		SourceID:     sourceID,
+		Region:       r.Region,
+		Partition:    partition,
+		ResourceType: strings.ToLower(resourceType),
+	}
+	if version, ok := model.DescriptionSchemaVersions[resourceType]; ok {
+		awsMetadata.SchemaVersion = strconv.Itoa(version)
+	}
+
+	awsMetadataBytes, err := json.Marshal(awsMetadata)
+	if err != nil {
+		return nil, fmt.Errorf("marshal metadata: %v", err.Error())
+	}
+	metadata := make(map[string]string)
+	if err := json.Unmarshal(awsMetadataBytes, &metadata); err != nil {
+		return nil, fmt.Errorf("unmarshal metadata: %v", err.Error())
+	}
+	return metadata, nil
+}
+
+// CanonicalTags returns tags as the canonical_tags of a resource document.
+func CanonicalTags(tags map[string]string) []es.Tag {
+	canonical := make([]es.Tag, 0, len(tags))
+	for k, v := range tags {
+		canonical = append(canonical, es.Tag{
+			// tags should be case-insensitive
+			Key:   strings.ToLower(k),
+			Value: strings.ToLower(v),
+		})
+	}
+	return canonical
+}
@@ -0,0 +1,46 @@
+package aws
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/opengovern/og-aws-describer/aws/describer"
+	"github.com/opengovern/og-aws-describer/aws/model"
+	"github.com/opengovern/og-util/pkg/es"
+)
+
+func TestResourceMetadata(t *testing.T) {
+	r := describer.Resource{ARN: "arn:aws-cn:ec2:cn-north-1:123456789012:instance/i-1", Name: "i-1", Region: "cn-north-1"}
+	metadata, err := ResourceMetadata(&r, "AWS::EC2::Instance", "123456789012", "source")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Account != "123456789012" || r.Partition != "aws-cn" || r.Type != "aws::ec2::instance" {
+		t.Errorf("got resource %+v, want its account, partition and type set", r)
+	}
+
+	want := map[string]string{
+		"Name":         "i-1",
+		"AccountID":    "123456789012",
+		"SourceID":     "source",
+		"Region":       "cn-north-1",
+		"Partition":    "aws-cn",
+		"ResourceType": "aws::ec2::instance",
+	}
+	if version, ok := model.DescriptionSchemaVersions["AWS::EC2::Instance"]; ok {
+		want["SchemaVersion"] = strconv.Itoa(version)
+	}
+	if !reflect.DeepEqual(metadata, want) {
+		t.Errorf("got metadata %v, want %v", metadata, want)
+	}
+}
+
+func TestCanonicalTags(t *testing.T) {
+	got := CanonicalTags(map[string]string{"Env": "Prod", "team": "a"})
+	sort.Slice(got, func(i, j int) bool { return got[i].Key < got[j].Key })
+	if want := []es.Tag{{Key: "env", Value: "prod"}, {Key: "team", Value: "a"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
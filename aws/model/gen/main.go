@@ -21,14 +21,23 @@ var (
 	file       = flag.String("file", "", "Location of the model file")
 	sourceType = flag.String("type", "", "Type of resource clients (e.g. aws, azure). Should match the model import path")
 	output     = flag.String("output", "", "Location of the output file")
+
+	schemaDir      = flag.String("schema-dir", "", "Directory to write the json schema of each model to, skipped if empty")
+	schemaVersions = flag.String("schema-versions", "", "Location of the generated schema versions file")
+	indexTemplates = flag.String("index-templates", "", "Directory to write the index template of each index to, skipped if empty")
+	tagsFieldType  = flag.String("tags-field-type", "flattened", "Mapping type of tag fields in the index templates, flat_object for OpenSearch")
+	validateModels = flag.Bool("validate", false, "Cross check resource types, table maps, models, filters and plugin tables and fail on inconsistencies")
+	baselineFile   = flag.String("validation-baseline", "", "File of the known inconsistencies, one \"<subject>: <issue>\" per line, --validate fails on the others and on the ones fixed")
+	updateBaseline = flag.Bool("update-validation-baseline", false, "Rewrite the validation baseline with the inconsistencies found rather than failing on them")
 )
 
 type SourceType struct {
-	Name        string
-	Index       string
-	ListFilters map[string]string
-	GetFilters  map[string]string
-	SourceType  string
+	Name         string
+	Index        string
+	ResourceName string
+	ListFilters  map[string]string
+	GetFilters   map[string]string
+	SourceType   string
 }
 
 type ResourceType struct {
@@ -41,12 +50,13 @@ type ResourceType struct {
 	TerraformNameString  string `json:"-"`
 	TerraformServiceName string
 	FastDiscovery        bool
+	Discovery            string
 	SteampipeTable       string
 	Model                string
 }
 
 func main() {
-	rt := "../inventory-data/aws-resource-types.json"
+	rt := "../../inventory-data/aws-resource-types.json"
 	b, err := os.ReadFile(rt)
 	if err != nil {
 		panic(err)
@@ -60,6 +70,13 @@ func main() {
 	flag.CommandLine.Init("gen", flag.ExitOnError)
 	flag.Parse()
 
+	if *schemaDir != "" {
+		generateSchemas(resourceTypes, *schemaDir, *schemaVersions)
+	}
+	if *output == "" && *indexTemplates == "" && !*validateModels {
+		return
+	}
+
 	tpl := template.New("types")
 	_, err = tpl.Parse(`
 // ==========================  START: {{ .Name }} =============================
@@ -101,7 +118,11 @@ type {{ .Name }}Paginator struct {
 }
 
 func (k Client) New{{ .Name }}Paginator(filters []essdk.BoolFilter, limit *int64) ({{ .Name }}Paginator, error) {
-	paginator, err := essdk.NewPaginator(k.ES(), "{{ .Index }}", filters, limit)
+	return k.New{{ .Name }}IndexPaginator("{{ .Index }}", filters, limit)
+}
+
+func (k Client) New{{ .Name }}IndexPaginator(index string, filters []essdk.BoolFilter, limit *int64) ({{ .Name }}Paginator, error) {
+	paginator, err := essdk.NewPaginator(k.ES(), index, filters, limit)
 	if err != nil {
 		return {{ .Name }}Paginator{}, err
 	}
@@ -152,7 +173,7 @@ func List{{ .Name }}(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 	plugin.Logger(ctx).Trace("List{{ .Name }}")
 	runtime.GC()
 	// create service
-	cfg := essdk.GetConfig(d.Connection)
+	cfg := GetConfig(d.Connection)
 	ke, err := essdk.NewClientCached(cfg, d.ConnectionCache, ctx)
 	if err != nil {
 		plugin.Logger(ctx).Error("List{{ .Name }} NewClientCached", "error", err)
@@ -181,7 +202,36 @@ func List{{ .Name }}(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 		return nil, err
 	}
 
-	paginator, err := k.New{{ .Name }}Paginator(essdk.BuildFilter(ctx, d.QueryContext, list{{ .Name }}Filters, "{{ .SourceType }}", accountId, encodedResourceCollectionFilters, clientType), d.QueryContext.Limit)
+	filters := essdk.BuildFilter(ctx, d.QueryContext, list{{ .Name }}Filters, "{{ .SourceType }}", accountId, encodedResourceCollectionFilters, clientType)
+	filters = append(filters, BuildTagFilters(ctx, d.QueryContext)...)
+	index, historyFilters, err := HistoryFilters(ctx, d, k, "{{ .Index }}")
+	if err != nil {
+		plugin.Logger(ctx).Error("List{{ .Name }} HistoryFilters", "error", err)
+		return nil, err
+	}
+	filters = append(filters, historyFilters...)
+
+	if index == "{{ .Index }}" {
+		live, err := LiveFallbackNeeded(ctx, d, k, index, filters)
+		if err != nil {
+			plugin.Logger(ctx).Error("List{{ .Name }} LiveFallbackNeeded", "error", err)
+			return nil, err
+		}
+		if live {
+			return nil, ListLive[{{ .Name }}](ctx, d, "{{ .ResourceName }}")
+		}
+	}
+
+	aggregated, err := ListAggregated[{{ .Name }}](ctx, d, k, index, list{{ .Name }}Filters, filters)
+	if err != nil {
+		plugin.Logger(ctx).Error("List{{ .Name }} ListAggregated", "error", err)
+		return nil, err
+	}
+	if aggregated {
+		return nil, nil
+	}
+
+	paginator, err := k.New{{ .Name }}IndexPaginator(index, filters, d.QueryContext.Limit)
 	if err != nil {
 		plugin.Logger(ctx).Error("List{{ .Name }} New{{ .Name }}Paginator", "error", err)
 		return nil, err
@@ -217,7 +267,7 @@ func Get{{ .Name }}(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 	plugin.Logger(ctx).Trace("Get{{ .Name }}")
 	runtime.GC()
 	// create service
-	cfg := essdk.GetConfig(d.Connection)
+	cfg := GetConfig(d.Connection)
 	ke, err := essdk.NewClientCached(cfg, d.ConnectionCache, ctx)
 	if err != nil {
 		return nil, err
@@ -241,8 +291,27 @@ func Get{{ .Name }}(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 		return nil, err
 	}
 
+	filters := essdk.BuildFilter(ctx, d.QueryContext, get{{ .Name }}Filters, "{{ .SourceType }}", accountId, encodedResourceCollectionFilters, clientType)
+	index, historyFilters, err := HistoryFilters(ctx, d, k, "{{ .Index }}")
+	if err != nil {
+		return nil, err
+	}
+	filters = append(filters, historyFilters...)
+
+	// a stale document is not returned when the live fallback applies, resource types without a
+	// get describer keep being read from the index.
+	if index == "{{ .Index }}" && liveGetSupported("{{ .ResourceName }}") {
+		live, err := LiveFallbackNeeded(ctx, d, k, index, filters)
+		if err != nil {
+			return nil, err
+		}
+		if live {
+			return GetLive[{{ .Name }}](ctx, d, "{{ .ResourceName }}")
+		}
+	}
+
 	limit := int64(1)
-	paginator, err := k.New{{ .Name }}Paginator(essdk.BuildFilter(ctx, d.QueryContext, get{{ .Name }}Filters, "{{ .SourceType }}", accountId, encodedResourceCollectionFilters, clientType), &limit)
+	paginator, err := k.New{{ .Name }}IndexPaginator(index, filters, &limit)
 	if err != nil {
 		return nil, err
 	}
@@ -273,11 +342,6 @@ func Get{{ .Name }}(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 		log.Fatal(err)
 	}
 
-	out, err := os.Create(*output)
-	if err != nil {
-		log.Fatal(err)
-	}
-
 	var buf bytes.Buffer
 
 	fset := token.NewFileSet()
@@ -330,6 +394,7 @@ func Get{{ .Name }}(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 					index := stopWordsRe.ReplaceAllString(resourceType.ResourceName, "_")
 					index = strings.ToLower(index)
 					s.Index = index
+					s.ResourceName = resourceType.ResourceName
 
 					tableAliasMap := map[string]string{
 						"aws_api_gateway_authorizer": "table_aws_api_gateway_api_authorizer.go",
@@ -413,6 +478,16 @@ func Get{{ .Name }}(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 		return false
 	})
 
+	if *validateModels {
+		validate(resourceTypes, sources, node)
+	}
+	if *indexTemplates != "" {
+		generateIndexTemplates(resourceTypes, sources, *indexTemplates)
+	}
+	if *output == "" {
+		return
+	}
+
 	if len(sources) > 0 {
 		fmt.Fprintln(&buf, `
 		import (
@@ -437,11 +512,33 @@ func Get{{ .Name }}(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 		}
 	}
 
+	if len(sources) > 0 {
+		fmt.Fprintln(&buf, `
+		// ListFilters are the list filters of every resource type, column -> document field, by resource type.
+		var ListFilters = map[string]map[string]string{`)
+		for _, source := range sources {
+			fmt.Fprintf(&buf, "%q: list%sFilters,\n", source.ResourceName, source.Name)
+		}
+		fmt.Fprintln(&buf, `}
+
+		// GetFilters are the get filters of every resource type, column -> document field, by resource type.
+		var GetFilters = map[string]map[string]string{`)
+		for _, source := range sources {
+			fmt.Fprintf(&buf, "%q: get%sFilters,\n", source.ResourceName, source.Name)
+		}
+		fmt.Fprintln(&buf, "}")
+	}
+
 	source, err := format.Source(buf.Bytes())
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	out, err := os.Create(*output)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	_, err = out.Write(source)
 	if err != nil {
 		log.Fatal(err)
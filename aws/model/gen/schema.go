@@ -0,0 +1,274 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	modelPackage  = "github.com/opengovern/og-aws-describer/aws/model"
+	schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+	schemaIDBase  = "https://opengovern.io/schemas/aws/"
+
+	versionsFile = "versions.json"
+)
+
+// SchemaVersion is the recorded version of a model schema. The version is bumped whenever
+// the hash of the generated schema changes.
+type SchemaVersion struct {
+	Version int    `json:"version"`
+	Hash    string `json:"hash"`
+}
+
+type schemaBuilder struct {
+	defs map[string]map[string]any
+}
+
+// generateSchemas writes a json schema for every description model to schemaDir, bumps the
+// versions of the models whose schema changed and writes the version map to versionsOutput.
+func generateSchemas(resourceTypes []ResourceType, schemaDir, versionsOutput string) {
+	scope := loadModelScope()
+
+	versions := map[string]SchemaVersion{}
+	versionsPath := filepath.Join(schemaDir, versionsFile)
+	if b, err := os.ReadFile(versionsPath); err == nil {
+		if err := json.Unmarshal(b, &versions); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if err := os.MkdirAll(schemaDir, os.ModePerm); err != nil {
+		log.Fatal(err)
+	}
+
+	resourceTypeVersions := map[string]int{}
+	for _, resourceType := range resourceTypes {
+		if resourceType.Model == "" {
+			continue
+		}
+		obj, ok := scope.Lookup(resourceType.Model + "Description").(*types.TypeName)
+		if !ok {
+			fmt.Println("model not found for schema", resourceType.Model)
+			continue
+		}
+
+		schema := descriptionSchema(obj)
+		schema["$id"] = schemaIDBase + resourceType.Model + ".json"
+
+		content, err := json.Marshal(schema)
+		if err != nil {
+			log.Fatal(err)
+		}
+		hash := fmt.Sprintf("%x", sha256.Sum256(content))
+
+		version, ok := versions[resourceType.Model]
+		if !ok || version.Hash != hash {
+			version = SchemaVersion{Version: version.Version + 1, Hash: hash}
+			versions[resourceType.Model] = version
+		}
+		resourceTypeVersions[resourceType.ResourceName] = version.Version
+
+		schema["x-schema-version"] = version.Version
+		content, err = json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(schemaDir, resourceType.Model+".json"), append(content, '\n'), 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	content, err := json.MarshalIndent(versions, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(versionsPath, append(content, '\n'), 0644); err != nil {
+		log.Fatal(err)
+	}
+
+	writeSchemaVersions(resourceTypeVersions, versionsOutput)
+}
+
+var modelScope *types.Scope
+
+// loadModelScope type checks the model package. It's done from source so the types follow the
+// sdk versions pinned in go.mod.
+func loadModelScope() *types.Scope {
+	if modelScope != nil {
+		return modelScope
+	}
+	pkg, err := importer.ForCompiler(token.NewFileSet(), "source", nil).Import(modelPackage)
+	if err != nil {
+		log.Fatal(err)
+	}
+	modelScope = pkg.Scope()
+	return modelScope
+}
+
+// descriptionSchema returns the json schema of a description model type.
+func descriptionSchema(obj *types.TypeName) map[string]any {
+	b := schemaBuilder{defs: map[string]map[string]any{}}
+	schema := b.schemaOf(obj.Type().Underlying())
+	schema["$schema"] = schemaDialect
+	schema["title"] = obj.Name()
+	if len(b.defs) > 0 {
+		schema["$defs"] = b.defs
+	}
+	return schema
+}
+
+func writeSchemaVersions(resourceTypeVersions map[string]int, versionsOutput string) {
+	names := make([]string, 0, len(resourceTypeVersions))
+	for k := range resourceTypeVersions {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code is generated by go generate. DO NOT EDIT.")
+	fmt.Fprintln(&buf, "package model")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "// DescriptionSchemaVersions is the version of the json schema of each resource type description.")
+	fmt.Fprintln(&buf, "// The schemas are generated into aws/model/schemas.")
+	fmt.Fprintln(&buf, "var DescriptionSchemaVersions = map[string]int{")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "%q: %d,\n", name, resourceTypeVersions[name])
+	}
+	fmt.Fprintln(&buf, "}")
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(versionsOutput, source, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// schemaOf returns the schema of the json encoding of t, following the rules of encoding/json.
+func (b *schemaBuilder) schemaOf(t types.Type) map[string]any {
+	switch t := t.(type) {
+	case *types.Pointer:
+		return b.schemaOf(t.Elem())
+	case *types.Named:
+		return b.schemaOfNamed(t)
+	case *types.Alias:
+		return b.schemaOf(types.Unalias(t))
+	case *types.Basic:
+		return schemaOfBasic(t)
+	case *types.Slice:
+		if basic, ok := t.Elem().(*types.Basic); ok && basic.Kind() == types.Byte {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]any{"type": []string{"array", "null"}, "items": b.schemaOf(t.Elem())}
+	case *types.Array:
+		return map[string]any{"type": "array", "items": b.schemaOf(t.Elem())}
+	case *types.Map:
+		return map[string]any{"type": []string{"object", "null"}, "additionalProperties": b.schemaOf(t.Elem())}
+	case *types.Struct:
+		return b.schemaOfStruct(t)
+	default:
+		// interfaces, documents and anything encoding/json can't tell ahead of time
+		return map[string]any{}
+	}
+}
+
+func (b *schemaBuilder) schemaOfNamed(t *types.Named) map[string]any {
+	obj := t.Obj()
+	if obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Time" {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Struct:
+		if obj.Pkg() == nil {
+			return b.schemaOfStruct(u)
+		}
+		name := definitionName(obj)
+		if _, ok := b.defs[name]; !ok {
+			// placeholder so recursive types terminate
+			b.defs[name] = map[string]any{}
+			b.defs[name] = b.schemaOfStruct(u)
+		}
+		return map[string]any{"$ref": "#/$defs/" + name}
+	case *types.Basic:
+		s := schemaOfBasic(u)
+		if u.Info()&types.IsString != 0 {
+			s["x-go-type"] = obj.Pkg().Name() + "." + obj.Name()
+		}
+		return s
+	default:
+		return b.schemaOf(u)
+	}
+}
+
+func (b *schemaBuilder) schemaOfStruct(t *types.Struct) map[string]any {
+	properties := map[string]any{}
+	for i := 0; i < t.NumFields(); i++ {
+		field := t.Field(i)
+		tag := reflect.StructTag(t.Tag(i)).Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Embedded() && name == "" {
+			embedded := b.schemaOf(field.Type())
+			if ref, ok := embedded["$ref"].(string); ok {
+				embedded = b.defs[strings.TrimPrefix(ref, "#/$defs/")]
+			}
+			if props, ok := embedded["properties"].(map[string]any); ok {
+				for k, v := range props {
+					if _, exists := properties[k]; !exists {
+						properties[k] = v
+					}
+				}
+			}
+			continue
+		}
+		if !field.Exported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name()
+		}
+		properties[name] = b.schemaOf(field.Type())
+	}
+	return map[string]any{"type": "object", "properties": properties}
+}
+
+func schemaOfBasic(t *types.Basic) map[string]any {
+	info := t.Info()
+	switch {
+	case info&types.IsBoolean != 0:
+		return map[string]any{"type": "boolean"}
+	case info&types.IsInteger != 0:
+		return map[string]any{"type": "integer"}
+	case info&types.IsFloat != 0:
+		return map[string]any{"type": "number"}
+	case info&types.IsString != 0:
+		return map[string]any{"type": "string"}
+	default:
+		return map[string]any{}
+	}
+}
+
+func definitionName(obj *types.TypeName) string {
+	path := obj.Pkg().Path()
+	path = strings.TrimPrefix(path, "github.com/aws/aws-sdk-go-v2/service/")
+	path = strings.TrimPrefix(path, "github.com/opengovern/og-aws-describer/aws/")
+	path = strings.TrimPrefix(path, "github.com/")
+	return strings.ReplaceAll(path, "/", ".") + "." + obj.Name()
+}
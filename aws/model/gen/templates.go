@@ -0,0 +1,351 @@
+package main
+
+import (
+	"encoding/json"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const (
+	// templatePriority is above the priority of the catch-all templates so these mappings win
+	templatePriority = 100
+	// maxMappingDepth bounds the walk over deeply nested sdk types
+	maxMappingDepth = 10
+	// historyIndexSuffix matches describer.HistoryIndexSuffix
+	historyIndexSuffix = "_history"
+)
+
+// tagFields are the description fields that hold resource tags, mapped as a single flattened field.
+var tagFields = map[string]bool{
+	"Tags":    true,
+	"TagList": true,
+	"TagSet":  true,
+}
+
+// policyDocumentFields are the description fields that hold policy documents. They are large,
+// their shape differs per statement and they are never queried through the index, so they are
+// stored without being indexed.
+var policyDocumentFields = map[string]bool{
+	"AccessPolicies":           true,
+	"AssumeRolePolicyDocument": true,
+	"Document":                 true,
+	"Policy":                   true,
+	"PolicyContent":            true,
+	"PolicyDocument":           true,
+	"PolicyText":               true,
+	"ResourcePolicy":           true,
+}
+
+// generateIndexTemplates writes an index template with explicit mappings for every index.
+func generateIndexTemplates(resourceTypes []ResourceType, sources []SourceType, templatesDir string) {
+	scope := loadModelScope()
+
+	if err := os.MkdirAll(templatesDir, os.ModePerm); err != nil {
+		log.Fatal(err)
+	}
+
+	for _, source := range sources {
+		var resourceType ResourceType
+		for _, rt := range resourceTypes {
+			if rt.Model == source.Name {
+				resourceType = rt
+				break
+			}
+		}
+
+		description := mapping{}
+		var schema map[string]any
+		var defs map[string]map[string]any
+		if obj, ok := scope.Lookup(source.Name + "Description").(*types.TypeName); ok {
+			schema = descriptionSchema(obj)
+			defs, _ = schema["$defs"].(map[string]map[string]any)
+			walkSchema(&description, schema, defs, map[string]bool{}, 0)
+		}
+
+		properties := baseProperties()
+		properties["description"] = description.toMapping()
+		for _, path := range filterPaths(source) {
+			setFilterMapping(properties, path, filterFieldMapping(path, schema, defs))
+		}
+
+		template := map[string]any{
+			// snapshots of past describe jobs share the mappings of the resource index
+			"index_patterns": []string{source.Index, source.Index + historyIndexSuffix},
+			"priority":       templatePriority,
+			"template": map[string]any{
+				"mappings": map[string]any{
+					"date_detection":    false,
+					"numeric_detection": false,
+					"dynamic_templates": []any{
+						map[string]any{
+							"metadata_as_keyword": map[string]any{
+								"path_match": "metadata.*",
+								"mapping":    map[string]any{"type": "keyword"},
+							},
+						},
+					},
+					"properties": properties,
+				},
+			},
+			"_meta": map[string]any{
+				"resource_type": resourceType.ResourceName,
+				"model":         source.Name,
+			},
+		}
+
+		content, err := json.MarshalIndent(template, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(templatesDir, source.Index+".json"), append(content, '\n'), 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// mapping is a node of the explicit description mapping. Leaves carry their field mapping,
+// inner nodes only exist to reach them.
+type mapping struct {
+	field    map[string]any
+	children map[string]*mapping
+}
+
+func (m *mapping) child(name string) *mapping {
+	if m.children == nil {
+		m.children = map[string]*mapping{}
+	}
+	if _, ok := m.children[name]; !ok {
+		m.children[name] = &mapping{}
+	}
+	return m.children[name]
+}
+
+func (m *mapping) toMapping() map[string]any {
+	if m.field != nil {
+		return m.field
+	}
+	properties := map[string]any{}
+	for name, c := range m.children {
+		properties[name] = c.toMapping()
+	}
+	return map[string]any{"properties": properties}
+}
+
+// walkSchema adds the tag and policy document fields found under schema to m.
+func walkSchema(m *mapping, schema map[string]any, defs map[string]map[string]any, visiting map[string]bool, depth int) bool {
+	if depth > maxMappingDepth {
+		return false
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/$defs/")
+		if visiting[name] {
+			return false
+		}
+		visiting[name] = true
+		defer delete(visiting, name)
+		return walkSchema(m, defs[name], defs, visiting, depth)
+	}
+	if items, ok := schema["items"].(map[string]any); ok {
+		return walkSchema(m, items, defs, visiting, depth)
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	found := false
+	for name, p := range properties {
+		propSchema, _ := p.(map[string]any)
+		switch {
+		case tagFields[name]:
+			m.child(name).field = map[string]any{"type": *tagsFieldType}
+			found = true
+		case policyDocumentFields[name] && isDocument(propSchema):
+			// disabled objects accept any json value, so this also covers policies kept as strings
+			m.child(name).field = map[string]any{"type": "object", "enabled": false}
+			found = true
+		default:
+			c := m.child(name)
+			if walkSchema(c, propSchema, defs, visiting, depth+1) {
+				found = true
+			} else {
+				delete(m.children, name)
+			}
+		}
+	}
+	return found
+}
+
+// isDocument reports whether the schema is a json string or a free form object, as opposed to
+// an sdk struct that only happens to be named like a policy.
+func isDocument(schema map[string]any) bool {
+	if schema["type"] == "string" {
+		return true
+	}
+	if _, ok := schema["$ref"]; ok {
+		return false
+	}
+	_, hasProperties := schema["properties"]
+	return !hasProperties
+}
+
+// baseProperties are the mappings of the fields every resource document has.
+func baseProperties() map[string]any {
+	keyword := map[string]any{"type": "keyword"}
+	long := map[string]any{"type": "long"}
+	return map[string]any{
+		"es_id":           keyword,
+		"es_index":        keyword,
+		"id":              keyword,
+		"arn":             keyword,
+		"name":            keyword,
+		"source_type":     keyword,
+		"resource_type":   keyword,
+		"resource_group":  keyword,
+		"location":        keyword,
+		"source_id":       keyword,
+		"resource_job_id": long,
+		"source_job_id":   long,
+		"schedule_job_id": long,
+		"created_at":      long,
+		"canonical_tags": map[string]any{
+			"type": "nested",
+			"properties": map[string]any{
+				"key":   keyword,
+				"value": keyword,
+			},
+		},
+		"metadata": map[string]any{"type": "object"},
+	}
+}
+
+// filterPaths returns the document fields the list and get filters of source point at, sorted so
+// the generated mappings don't depend on map iteration order.
+func filterPaths(source SourceType) []string {
+	seen := map[string]bool{}
+	var paths []string
+	for _, filters := range []map[string]string{source.ListFilters, source.GetFilters} {
+		for _, path := range filters {
+			if !seen[path] {
+				seen[path] = true
+				paths = append(paths, path)
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// filterFieldMapping returns the mapping of the filter field at path, resolved in the description
+// schema: strings are keywords, integers longs, numbers doubles and booleans booleans, arrays are
+// mapped as their elements and objects stay objects. Fields the schema can't resolve are keywords,
+// like the filter values, so no filter field is left to dynamic mapping.
+//
+// Arrays of objects are mapped as object rather than nested since the filters are plain term
+// queries on the inner fields, which don't match nested documents.
+func filterFieldMapping(path string, schema map[string]any, defs map[string]map[string]any) map[string]any {
+	parts := strings.Split(path, ".")
+	if parts[0] != "description" {
+		// the fields of the document itself, e.g. arn or metadata.SourceID, are all strings
+		return map[string]any{"type": "keyword"}
+	}
+	if schema == nil {
+		return map[string]any{"type": "keyword"}
+	}
+
+	current := schema
+	for _, part := range parts[1:] {
+		current = elementSchema(current, defs)
+		properties, _ := current["properties"].(map[string]any)
+		next, ok := properties[part].(map[string]any)
+		if !ok {
+			// map keys, e.g. the keys of a tag map
+			if next, ok = current["additionalProperties"].(map[string]any); !ok {
+				return map[string]any{"type": "keyword"}
+			}
+		}
+		current = next
+	}
+
+	current = elementSchema(current, defs)
+	switch {
+	case schemaHasType(current, "string"):
+		return map[string]any{"type": "keyword"}
+	case schemaHasType(current, "integer"):
+		return map[string]any{"type": "long"}
+	case schemaHasType(current, "number"):
+		return map[string]any{"type": "double"}
+	case schemaHasType(current, "boolean"):
+		return map[string]any{"type": "boolean"}
+	case schemaHasType(current, "object"):
+		return map[string]any{"type": "object"}
+	default:
+		return map[string]any{"type": "keyword"}
+	}
+}
+
+// elementSchema follows the references and array items of schema down to the schema of the
+// values a term query on the field matches.
+func elementSchema(schema map[string]any, defs map[string]map[string]any) map[string]any {
+	for depth := 0; depth <= maxMappingDepth; depth++ {
+		if ref, ok := schema["$ref"].(string); ok {
+			schema = defs[strings.TrimPrefix(ref, "#/$defs/")]
+			continue
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			schema = items
+			continue
+		}
+		break
+	}
+	return schema
+}
+
+// schemaHasType reports whether the json schema type of schema, a name or a list of names, is t.
+func schemaHasType(schema map[string]any, t string) bool {
+	switch v := schema["type"].(type) {
+	case string:
+		return v == t
+	case []string:
+		return slices.Contains(v, t)
+	case []any:
+		return slices.Contains(v, any(t))
+	}
+	return false
+}
+
+// setFilterMapping maps the dotted path with field unless it's already mapped, e.g. as a tag or
+// policy document field. Paths are set parent first, so when a filter field also has filters on
+// its inner fields, the parent is an object holding them.
+func setFilterMapping(properties map[string]any, path string, field map[string]any) {
+	parts := strings.Split(path, ".")
+	current := properties
+	for i, part := range parts {
+		existing, _ := current[part].(map[string]any)
+		if i == len(parts)-1 {
+			if existing == nil {
+				current[part] = field
+			}
+			return
+		}
+		if existing == nil {
+			existing = map[string]any{"properties": map[string]any{}}
+			current[part] = existing
+		}
+		if t, ok := existing["type"].(string); ok && t != "object" && t != "nested" {
+			// tags and policy documents are not mapped field by field
+			return
+		}
+		if existing["enabled"] == false {
+			return
+		}
+		next, ok := existing["properties"].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			existing["properties"] = next
+		}
+		current = next
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const filterTestSchema = `{
+  "type": "object",
+  "properties": {
+    "Instance": {"$ref": "#/$defs/Instance"},
+    "Tags": {"type": ["object", "null"], "additionalProperties": {"type": "string"}}
+  },
+  "$defs": {
+    "Instance": {
+      "type": "object",
+      "properties": {
+        "InstanceId": {"type": "string"},
+        "LaunchTime": {"type": "string", "format": "date-time"},
+        "CoreCount": {"type": "integer"},
+        "CpuCredits": {"type": ["number", "null"]},
+        "EbsOptimized": {"type": "boolean"},
+        "SecurityGroupIds": {"type": ["array", "null"], "items": {"type": "string"}},
+        "BlockDeviceMappings": {"type": ["array", "null"], "items": {"$ref": "#/$defs/Mapping"}},
+        "Placement": {"$ref": "#/$defs/Placement"}
+      }
+    },
+    "Mapping": {"type": "object", "properties": {"DeviceName": {"type": "string"}}},
+    "Placement": {"type": "object", "properties": {"Zone": {"type": "string"}}}
+  }
+}`
+
+func TestFilterFieldMapping(t *testing.T) {
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(filterTestSchema), &schema); err != nil {
+		t.Fatal(err)
+	}
+	defs := map[string]map[string]any{}
+	for name, def := range schema["$defs"].(map[string]any) {
+		defs[name] = def.(map[string]any)
+	}
+
+	keyword := map[string]any{"type": "keyword"}
+	object := map[string]any{"type": "object"}
+	long := map[string]any{"type": "long"}
+	double := map[string]any{"type": "double"}
+	boolean := map[string]any{"type": "boolean"}
+	tests := map[string]map[string]any{
+		"arn":                                                 keyword,
+		"metadata.SourceID":                                   keyword,
+		"description.Instance.InstanceId":                     keyword,
+		"description.Instance.LaunchTime":                     keyword,
+		"description.Instance.SecurityGroupIds":               keyword,
+		"description.Instance.BlockDeviceMappings":            object,
+		"description.Instance.BlockDeviceMappings.DeviceName": keyword,
+		"description.Instance.Placement":                      object,
+		"description.Tags.env":                                keyword,
+		"description.Instance.CoreCount":                      long,
+		"description.Instance.CpuCredits":                     double,
+		"description.Instance.EbsOptimized":                   boolean,
+		"description.Instance.Unknown":                        keyword,
+		"description.Instance.InstanceId.Inner":               keyword,
+	}
+	for path, want := range tests {
+		if got := filterFieldMapping(path, schema, defs); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestSetFilterMapping(t *testing.T) {
+	// the parent is set first whatever the order of the filter maps, its inner fields are added to it.
+	paths := filterPaths(SourceType{
+		ListFilters: map[string]string{"a": "description.Placement.Zone", "b": "description.Placement"},
+		GetFilters:  map[string]string{"a": "description.Placement.Zone"},
+	})
+	if want := []string{"description.Placement", "description.Placement.Zone"}; !reflect.DeepEqual(paths, want) {
+		t.Fatalf("got paths %v, want %v", paths, want)
+	}
+
+	properties := map[string]any{"description": map[string]any{"properties": map[string]any{
+		"Tags": map[string]any{"type": "flattened"},
+	}}}
+	setFilterMapping(properties, paths[0], map[string]any{"type": "object"})
+	setFilterMapping(properties, paths[1], map[string]any{"type": "keyword"})
+	setFilterMapping(properties, "description.Tags.env", map[string]any{"type": "keyword"})
+
+	want := map[string]any{"description": map[string]any{"properties": map[string]any{
+		"Tags": map[string]any{"type": "flattened"},
+		"Placement": map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"Zone": map[string]any{"type": "keyword"}},
+		},
+	}}}
+	if !reflect.DeepEqual(properties, want) {
+		t.Errorf("got %v, want %v", properties, want)
+	}
+}
@@ -0,0 +1,465 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	resourceTypesFile   = "../resource_types.go"
+	tableIndexMapFile   = "../../pkg/steampipe/table_index_map.go"
+	pluginFile          = "../../steampipe-plugin-aws/aws/plugin.go"
+	supportedTablesFile = "../../supported_tables"
+	unsupportedTables   = "../../unsupported_tables"
+
+	discoveryDisabled = "DISABLED"
+)
+
+// documentFields are the top level fields of a resource document that filters may point at.
+var documentFields = map[string]bool{
+	"es_id": true, "es_index": true, "id": true, "arn": true, "name": true, "source_type": true,
+	"resource_type": true, "resource_group": true, "location": true, "source_id": true,
+	"resource_job_id": true, "source_job_id": true, "schedule_job_id": true, "created_at": true,
+}
+
+// validationReport collects the inconsistencies found, grouped by the resource type or table
+// they were found for.
+type validationReport map[string][]string
+
+func (r validationReport) add(subject, format string, args ...any) {
+	r[subject] = append(r[subject], fmt.Sprintf(format, args...))
+}
+
+func (r validationReport) print() {
+	subjects := make([]string, 0, len(r))
+	for s := range r {
+		subjects = append(subjects, s)
+	}
+	sort.Strings(subjects)
+
+	count := 0
+	for _, s := range subjects {
+		fmt.Fprintln(os.Stderr, s+":")
+		for _, issue := range r[s] {
+			fmt.Fprintln(os.Stderr, "  - "+issue)
+			count++
+		}
+	}
+	fmt.Fprintf(os.Stderr, "%d inconsistencies found in %d resource types and tables\n", count, len(subjects))
+}
+
+// validate cross checks aws-resource-types.json against the generated resource types, the table
+// index maps, the description models and their filters, and the steampipe plugin table map.
+// It exits with a report of every inconsistency found.
+func validate(resourceTypes []ResourceType, sources []SourceType, modelNode *ast.File) {
+	report := validationReport{}
+
+	registry := parseMapLiteral(resourceTypesFile, "resourceTypes")
+	awsMap := parseMapLiteral(tableIndexMapFile, "awsMap")
+	descriptionMap := parseMapLiteral(tableIndexMapFile, "AWSDescriptionMap")
+	reverseMap := parseMapLiteral(tableIndexMapFile, "AWSReverseMap")
+	tableMap := parseTableMap(pluginFile)
+	indexes := modelIndexes(modelNode)
+
+	rtByName := map[string]ResourceType{}
+	for _, rt := range resourceTypes {
+		if _, ok := rtByName[rt.ResourceName]; ok {
+			report.add(rt.ResourceName, "declared more than once in aws-resource-types.json")
+		}
+		rtByName[rt.ResourceName] = rt
+
+		if rt.Discovery == discoveryDisabled {
+			if _, ok := registry[rt.ResourceName]; ok {
+				report.add(rt.ResourceName, "disabled in aws-resource-types.json but registered in resource_types.go")
+			}
+		} else {
+			registered, ok := registry[rt.ResourceName]
+			if !ok {
+				report.add(rt.ResourceName, "missing from resource_types.go")
+			} else {
+				fields := compositeFields(registered)
+				if got := fields["ListDescriber"]; got != strings.TrimSpace(rt.ListDescriber) {
+					report.add(rt.ResourceName, "ListDescriber is %s in resource_types.go, %s in aws-resource-types.json", got, rt.ListDescriber)
+				}
+				getDescriber := strings.TrimSpace(rt.GetDescriber)
+				if getDescriber == "" {
+					getDescriber = "nil"
+				}
+				if got := fields["GetDescriber"]; got != getDescriber {
+					report.add(rt.ResourceName, "GetDescriber is %s in resource_types.go, %s in aws-resource-types.json", got, getDescriber)
+				}
+			}
+		}
+
+		if got, ok := awsMap[rt.ResourceName]; !ok {
+			report.add(rt.ResourceName, "missing from awsMap")
+		} else if table := stringLiteral(got); table != rt.SteampipeTable {
+			report.add(rt.ResourceName, "awsMap table is %s, aws-resource-types.json table is %s", table, rt.SteampipeTable)
+		}
+
+		if got, ok := descriptionMap[rt.ResourceName]; !ok {
+			report.add(rt.ResourceName, "missing from AWSDescriptionMap")
+		} else if model := compositeTypeName(got); model != rt.Model {
+			report.add(rt.ResourceName, "AWSDescriptionMap model is %s, aws-resource-types.json model is %s", model, rt.Model)
+		}
+
+		if got, ok := reverseMap[rt.SteampipeTable]; !ok {
+			report.add(rt.ResourceName, "table %s missing from AWSReverseMap", rt.SteampipeTable)
+		} else if name := stringLiteral(got); name != rt.ResourceName {
+			report.add(rt.ResourceName, "AWSReverseMap maps table %s to %s", rt.SteampipeTable, name)
+		}
+
+		if index, ok := indexes[rt.Model]; !ok {
+			report.add(rt.ResourceName, "model %sDescription not found or has no //index: comment in model.go", rt.Model)
+		} else if expected := resourceTypeIndex(rt.ResourceName); index != expected {
+			report.add(rt.ResourceName, "//index:%s of %sDescription doesn't match the index %s documents are written to", index, rt.Model, expected)
+		}
+
+		if _, ok := tableMap[rt.SteampipeTable]; !ok {
+			report.add(rt.ResourceName, "table %s missing from the plugin TableMap", rt.SteampipeTable)
+		}
+	}
+
+	for name := range registry {
+		if _, ok := rtByName[name]; !ok {
+			report.add(name, "registered in resource_types.go but missing from aws-resource-types.json")
+		}
+	}
+	for name := range awsMap {
+		if _, ok := rtByName[name]; !ok {
+			report.add(name, "in awsMap but missing from aws-resource-types.json")
+		}
+	}
+
+	supported := readLines(supportedTablesFile)
+	unsupported := readLines(unsupportedTables)
+	for table := range supported {
+		if unsupported[table] {
+			report.add(table, "listed in both supported_tables and unsupported_tables")
+		}
+		if _, ok := tableMap[table]; !ok {
+			report.add(table, "listed in supported_tables but missing from the plugin TableMap")
+		}
+		if _, ok := reverseMap[table]; !ok {
+			report.add(table, "listed in supported_tables but not mapped to a resource type")
+		}
+	}
+
+	scope := loadModelScope()
+	metadata := scope.Lookup("Metadata").Type()
+	for _, source := range sources {
+		obj, ok := scope.Lookup(source.Name + "Description").(*types.TypeName)
+		if !ok {
+			continue
+		}
+		for _, kind := range []struct {
+			name    string
+			filters map[string]string
+		}{{"getfilter", source.GetFilters}, {"listfilter", source.ListFilters}} {
+			for column, path := range kind.filters {
+				if err := resolveDocumentPath(obj.Type(), metadata, path); err != nil {
+					report.add(source.Name, "%s %s=%s: %v", kind.name, column, path, err)
+				}
+			}
+		}
+	}
+
+	if *baselineFile != "" {
+		if *updateBaseline {
+			report.writeBaseline(*baselineFile)
+			return
+		}
+		report = report.againstBaseline(*baselineFile)
+	}
+	if len(report) > 0 {
+		report.print()
+		os.Exit(1)
+	}
+}
+
+// baselineLine is the line of an inconsistency in the baseline, trimmed like readLines does.
+func baselineLine(subject, issue string) string {
+	return strings.TrimSpace(subject + ": " + issue)
+}
+
+// againstBaseline returns the inconsistencies of r missing from the baseline file, and one for
+// every baseline entry no longer found, so the baseline only shrinks as inconsistencies are fixed.
+func (r validationReport) againstBaseline(file string) validationReport {
+	known := readLines(file)
+	found := map[string]bool{}
+	remaining := validationReport{}
+	for subject, issues := range r {
+		for _, issue := range issues {
+			line := baselineLine(subject, issue)
+			found[line] = true
+			if !known[line] {
+				remaining.add(subject, "%s", issue)
+			}
+		}
+	}
+	for line := range known {
+		if !found[line] {
+			subject, _, _ := strings.Cut(line, ": ")
+			remaining.add(subject, "fixed, remove %q from %s", line, file)
+		}
+	}
+	return remaining
+}
+
+// writeBaseline writes the inconsistencies of r to file, sorted, one per line.
+func (r validationReport) writeBaseline(file string) {
+	var lines []string
+	for subject, issues := range r {
+		for _, issue := range issues {
+			lines = append(lines, baselineLine(subject, issue)+"\n")
+		}
+	}
+	sort.Strings(lines)
+	if err := os.WriteFile(file, []byte(strings.Join(lines, "")), 0644); err != nil {
+		panic(err)
+	}
+}
+
+// resolveDocumentPath checks the dotted filter path points at a field of the resource document.
+func resolveDocumentPath(description, metadata types.Type, path string) error {
+	parts := strings.Split(path, ".")
+	switch parts[0] {
+	case "description":
+		return resolveFieldPath(description, parts)
+	case "metadata":
+		return resolveFieldPath(metadata, parts)
+	default:
+		if len(parts) == 1 && documentFields[parts[0]] {
+			return nil
+		}
+		return fmt.Errorf("%s is not a field of the resource document", parts[0])
+	}
+}
+
+// resolveFieldPath resolves parts, except the first one which names t, against the json encoding of t.
+func resolveFieldPath(t types.Type, parts []string) error {
+	for i := 1; i < len(parts); i++ {
+		part := parts[i]
+		t = elemType(t)
+		switch u := t.Underlying().(type) {
+		case *types.Map:
+			// any key is valid, continue with the value type
+			t = u.Elem()
+			continue
+		case *types.Interface:
+			return nil
+		case *types.Struct:
+			field, ok := jsonField(u, part)
+			if !ok {
+				return fmt.Errorf("%s has no field %s", strings.Join(parts[:i], "."), part)
+			}
+			t = field
+		default:
+			return fmt.Errorf("%s is a %s, not an object", strings.Join(parts[:i], "."), t.String())
+		}
+	}
+	return nil
+}
+
+// elemType strips pointers, slices and arrays since the index flattens arrays of objects.
+func elemType(t types.Type) types.Type {
+	for {
+		switch u := t.(type) {
+		case *types.Pointer:
+			t = u.Elem()
+		case *types.Slice:
+			t = u.Elem()
+		case *types.Array:
+			t = u.Elem()
+		default:
+			if u, ok := t.Underlying().(*types.Slice); ok {
+				t = u.Elem()
+				continue
+			}
+			return t
+		}
+	}
+}
+
+// jsonField looks up the field that encoding/json writes under name, including promoted fields.
+func jsonField(s *types.Struct, name string) (types.Type, bool) {
+	for i := 0; i < s.NumFields(); i++ {
+		field := s.Field(i)
+		tag := reflect.StructTag(s.Tag(i)).Get("json")
+		if tag == "-" {
+			continue
+		}
+		jsonName, _, _ := strings.Cut(tag, ",")
+		if field.Embedded() && jsonName == "" {
+			if embedded, ok := elemType(field.Type()).Underlying().(*types.Struct); ok {
+				if t, ok := jsonField(embedded, name); ok {
+					return t, true
+				}
+			}
+			continue
+		}
+		if jsonName == "" {
+			jsonName = field.Name()
+		}
+		if field.Exported() && jsonName == name {
+			return field.Type(), true
+		}
+	}
+	return nil, false
+}
+
+// modelIndexes returns the //index: comment of every description model, keyed by model name.
+func modelIndexes(node *ast.File) map[string]string {
+	indexes := map[string]string{}
+	for _, decl := range node.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE || gen.Doc == nil {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			name := spec.(*ast.TypeSpec).Name.String()
+			if !strings.HasSuffix(name, "Description") {
+				continue
+			}
+			for _, c := range gen.Doc.List {
+				if strings.HasPrefix(c.Text, "//index:") {
+					indexes[strings.TrimSuffix(name, "Description")] = strings.TrimSpace(strings.TrimPrefix(c.Text, "//index:"))
+				}
+			}
+		}
+	}
+	return indexes
+}
+
+var indexStopWordsRe = regexp.MustCompile(`\W+`)
+
+func resourceTypeIndex(resourceName string) string {
+	return strings.ToLower(indexStopWordsRe.ReplaceAllString(resourceName, "_"))
+}
+
+// parseMapLiteral returns the elements of the map literal assigned to the package level variable
+// name in file, keyed by their string keys.
+func parseMapLiteral(file, name string) map[string]ast.Expr {
+	node, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+	if err != nil {
+		panic(err)
+	}
+
+	elements := map[string]ast.Expr{}
+	ast.Inspect(node, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || len(spec.Names) != 1 || spec.Names[0].Name != name || len(spec.Values) != 1 {
+			return true
+		}
+		if lit, ok := spec.Values[0].(*ast.CompositeLit); ok {
+			addMapElements(lit, elements)
+		}
+		return false
+	})
+	return elements
+}
+
+// parseTableMap returns the tables registered in the TableMap of the plugin definition.
+func parseTableMap(file string) map[string]ast.Expr {
+	node, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+	if err != nil {
+		panic(err)
+	}
+
+	elements := map[string]ast.Expr{}
+	ast.Inspect(node, func(n ast.Node) bool {
+		kv, ok := n.(*ast.KeyValueExpr)
+		if !ok {
+			return true
+		}
+		if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "TableMap" {
+			if lit, ok := kv.Value.(*ast.CompositeLit); ok {
+				addMapElements(lit, elements)
+			}
+			return false
+		}
+		return true
+	})
+	return elements
+}
+
+func addMapElements(lit *ast.CompositeLit, elements map[string]ast.Expr) {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key := stringLiteral(kv.Key); key != "" {
+			elements[key] = kv.Value
+		}
+	}
+}
+
+func compositeFields(expr ast.Expr) map[string]string {
+	fields := map[string]string{}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return fields
+	}
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok {
+				fields[key.Name] = types.ExprString(kv.Value)
+			}
+		}
+	}
+	return fields
+}
+
+func compositeTypeName(expr ast.Expr) string {
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return ""
+	}
+	if sel, ok := lit.Type.(*ast.SelectorExpr); ok {
+		return sel.Sel.Name
+	}
+	return types.ExprString(lit.Type)
+}
+
+func stringLiteral(expr ast.Expr) string {
+	bl, ok := expr.(*ast.BasicLit)
+	if !ok || bl.Kind != token.STRING {
+		return ""
+	}
+	s, err := strconv.Unquote(bl.Value)
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+func readLines(file string) map[string]bool {
+	f, err := os.Open(file)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	lines := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines[line] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		panic(err)
+	}
+	return lines
+}
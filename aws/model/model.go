@@ -1,4 +1,6 @@
-//go:generate go run ./gen/main.go --file $GOFILE --output ../../pkg/opengovernance-es-sdk/aws_resources_clients.go --type aws
+//go:generate go run ./gen --file $GOFILE --output ../../pkg/opengovernance-es-sdk/aws_resources_clients.go --type aws --index-templates ../../pkg/opengovernance-es-sdk/index_templates
+//go:generate go run ./gen --type aws --schema-dir ./schemas --schema-versions schema_versions.go
+//go:generate go run ./gen --file $GOFILE --type aws --validate --validation-baseline validation_baseline.txt
 
 package model
 
@@ -179,6 +181,12 @@ type Metadata struct {
 	Region       string
 	Partition    string
 	ResourceType string
+	// TagsSource is where the resource tags came from, either the describer or the tagging api.
+	TagsSource string `json:",omitempty"`
+	// Fingerprint is the hash of the canonical description, see describer.Fingerprint.
+	Fingerprint string `json:",omitempty"`
+	// SchemaVersion is the version of the description json schema, see DescriptionSchemaVersions.
+	SchemaVersion string `json:",omitempty"`
 }
 
 //  ===================  Access Analyzer ==================
@@ -459,6 +467,8 @@ type BackupSelectionDescription struct {
 	BackupSelection backup.BackupSelectionsListMember
 	ListOfTags      []backup.Condition
 	Resources       []string
+	NotResources    []string
+	Conditions      *backup.Conditions
 }
 
 //index:aws_backup_vault
@@ -516,6 +526,31 @@ type BackupRegionSettingDescription struct {
 	ResourceTypeOptInPreference      map[string]bool
 }
 
+type BackupCoverageRule struct {
+	BackupPlanId          string
+	BackupPlanName        string
+	RuleName              string
+	TargetBackupVaultName string
+	ScheduleExpression    string
+	SelectionId           string
+	SelectionName         string
+}
+
+//index:aws_backup_resourcecoverage
+//getfilter:resource_arn=description.ResourceArn
+//listfilter:resource_type=description.ResourceType
+//listfilter:is_protected=description.IsProtected
+type BackupResourceCoverageDescription struct {
+	ResourceArn           string
+	ResourceType          string
+	Tags                  map[string]string
+	TagsUnknown           bool
+	IsProtected           bool
+	CoveringRules         []BackupCoverageRule
+	LastRecoveryPointArn  *string
+	LastRecoveryPointTime *time.Time
+}
+
 //  ===================   CloudFront   ===================
 
 //index:aws_cloudfront_distribution
@@ -999,6 +1034,32 @@ type EC2NetworkInterfaceDescription struct {
 	NetworkInterface ec2.NetworkInterface
 }
 
+type EC2NetworkExposurePortRange struct {
+	Protocol    string
+	FromPort    int32
+	ToPort      int32
+	SourceCidrs []string
+}
+
+//index:aws_ec2_networkexposure
+//getfilter:resource_id=description.ResourceId
+//listfilter:resource_type=description.ResourceType
+//listfilter:vpc_id=description.VpcId
+//listfilter:instance_id=description.InstanceId
+type EC2NetworkExposureDescription struct {
+	ResourceId          string
+	ResourceType        string
+	InstanceId          string
+	VpcId               string
+	SubnetIds           []string
+	PublicIps           []string
+	SecurityGroupIds    []string
+	NetworkAclIds       []string
+	InternetGatewayId   string
+	IsInternetReachable bool
+	ExposedPorts        []EC2NetworkExposurePortRange
+}
+
 //index:aws_ec2_regionalsettings
 type EC2RegionalSettingsDescription struct {
 	EbsEncryptionByDefault         *bool
@@ -1716,6 +1777,28 @@ type IAMOpenIdConnectProviderDescription struct {
 	URL            string
 }
 
+type IAMPrivilegeEscalationPath struct {
+	Name    string
+	Actions []string
+}
+
+//index:aws_iam_effectivepermission
+//getfilter:principal_arn=description.PrincipalArn
+//listfilter:principal_type=description.PrincipalType
+//listfilter:principal_name=description.PrincipalName
+type IAMEffectivePermissionDescription struct {
+	PrincipalArn             string
+	PrincipalName            string
+	PrincipalType            string
+	AttachedPolicyArns       []string
+	InlinePolicyNames        []string
+	GroupNames               []string
+	PermissionsBoundaryArn   string
+	AllowedActions           []string
+	IsAdminEquivalent        bool
+	PrivilegeEscalationPaths []IAMPrivilegeEscalationPath
+}
+
 //  ===================  RDS  ===================
 
 //index:aws_rds_dbcluster
@@ -1775,7 +1858,7 @@ type RDSDBClusterSnapshotDescription struct {
 	Attributes        *rds.DBClusterSnapshotAttributesResult
 }
 
-//index:aws_rds_eventsubscription
+//index:aws_rds_dbeventsubscription
 //getfilter:cust_subscription_id=description.EventSubscription.CustSubscriptionId
 type RDSDBEventSubscriptionDescription struct {
 	EventSubscription rds.EventSubscription
@@ -1797,7 +1880,7 @@ type RDSDBSnapshotDescription struct {
 }
 
 //index:aws_rds_globalcluster
-//getfilter:global_cluster_identifier=description.DBGlobalCluster.GlobalClusterIdentifier
+//getfilter:global_cluster_identifier=description.GlobalCluster.GlobalClusterIdentifier
 type RDSGlobalClusterDescription struct {
 	GlobalCluster rds.GlobalCluster
 	Tags          []rds.Tag
@@ -1815,16 +1898,21 @@ type RDSReservedDBInstanceDescription struct {
 	ReservedDBInstance rds.ReservedDBInstance
 }
 
-//index:aws_rds_dbcluster
-//getfilter:db_cluster_identifier=description.DBCluster.DBClusterIdentifier
+//index:aws_rds_dbinstanceautomatedbackup
+//getfilter:arn=description.InstanceAutomatedBackup.DBInstanceAutomatedBackupsArn
+//listfilter:db_instance_identifier=description.InstanceAutomatedBackup.DBInstanceIdentifier
+//listfilter:dbi_resource_id=description.InstanceAutomatedBackup.DbiResourceId
+//listfilter:status=description.InstanceAutomatedBackup.Status
 type RDSDBInstanceAutomatedBackupDescription struct {
 	InstanceAutomatedBackup rds.DBInstanceAutomatedBackup
 }
 
+//index:aws_rds_dbengineversion
 type RDSDBEngineVersionDescription struct {
 	EngineVersion rds.DBEngineVersion
 }
 
+//index:aws_rds_dbrecommendation
 type RDSDBRecommendationDescription struct {
 	DBRecommendation rds.DBRecommendation
 }
@@ -2899,27 +2987,28 @@ type SSOAdminInstanceDescription struct {
 	Instance ssoadmin.InstanceMetadata
 }
 
-//index:aws_ssoadmin_account_assignment
+//index:aws_ssoadmin_accountassignment
 type SSOAdminAccountAssignmentDescription struct {
 	Instance          ssoadmin.InstanceMetadata
 	AccountAssignment ssoadmin.AccountAssignment
 }
 
-//index:aws_ssoadmin_permission_set
+//index:aws_ssoadmin_permissionset
+//listfilter:instance_arn=description.InstanceArn
 type SSOAdminPermissionSetDescription struct {
 	InstanceArn   string
 	PermissionSet ssoadmin.PermissionSet
 	Tags          interface{}
 }
 
-//index:aws_ssoadmin_managed_policy_attachment
+//index:aws_ssoadmin_attachedmanagedpolicy
 type SSOAdminPolicyAttachmentDescription struct {
 	InstanceArn           string
 	PermissionSetArn      string
 	AttachedManagedPolicy ssoadmin.AttachedManagedPolicy
 }
 
-//index:aws_ssoadmin_usereffectivea
+//index:aws_ssoadmin_usereffectiveaccess
 type UserEffectiveAccessDescription struct {
 	Instance          ssoadmin.InstanceMetadata
 	AccountAssignment ssoadmin.AccountAssignment
@@ -3386,6 +3475,38 @@ type OrganizationsPolicyTargetDescription struct {
 	TargetId      string
 }
 
+type OrganizationsParent struct {
+	Id   string
+	Name string
+	Type string
+}
+
+type OrganizationsInheritedPolicy struct {
+	PolicySummary organizations.PolicySummary
+	PolicyContent string
+	TargetId      string
+	TargetType    string
+	Inherited     bool
+}
+
+//index:aws_organizations_accounteffectivepolicy
+//getfilter:account_id=description.AccountId
+//listfilter:root_id=description.RootId
+//listfilter:parent_id=description.ParentId
+type OrganizationsAccountEffectivePolicyDescription struct {
+	AccountId              string
+	AccountName            string
+	RootId                 string
+	ParentId               string
+	OUPath                 []OrganizationsParent
+	Path                   string
+	ServiceControlPolicies []OrganizationsInheritedPolicy
+	TagPolicies            []OrganizationsInheritedPolicy
+	BackupPolicies         []OrganizationsInheritedPolicy
+	EffectiveTagPolicy     string
+	EffectiveBackupPolicy  string
+}
+
 // ===================  Pinpoint ===================
 
 //index:aws_pinpoint_app
@@ -3557,13 +3678,13 @@ type AppConfigApplicationDescription struct {
 // ===================  Audit Manager ===================
 
 //index:aws_auditmanager_assessment
-//getfilter:assessment_id=description.Assessment.Metadata.Id
+//getfilter:id=description.Assessment.Metadata.Id
 type AuditManagerAssessmentDescription struct {
 	Assessment auditmanager.Assessment
 }
 
 //index:aws_auditmanager_control
-//getfilter:control_id=description.Control.Id
+//getfilter:id=description.Control.Id
 type AuditManagerControlDescription struct {
 	Control auditmanager.Control
 }
@@ -3582,7 +3703,7 @@ type AuditManagerEvidenceDescription struct {
 //index:aws_auditmanager_evidencefolder
 //getfilter:id=description.EvidenceFolder.Id
 //getfilter:assessment_id=description.AssessmentID
-//getfilter:control_set_id=description.ControlSetID
+//getfilter:control_set_id=description.EvidenceFolder.ControlSetId
 type AuditManagerEvidenceFolderDescription struct {
 	EvidenceFolder auditmanager.AssessmentEvidenceFolder
 	AssessmentID   string
@@ -3772,10 +3893,9 @@ type IdentityStoreUserDescription struct {
 	PrimaryEmail *string
 }
 
-//index:aws_identitystore_group
-//getfilter:id=description.Group.GroupId
-//getfilter:identity_store_id=description.Group.IdentityStoreId
-//listfilter:identity_store_id=description.Group.IdentityStoreId
+//index:aws_identitystore_groupmembership
+//listfilter:identity_store_id=description.IdentityStoreId
+//listfilter:group_id=description.GroupId
 type IdentityStoreGroupMembershipDescription struct {
 	MembershipId    *string
 	IdentityStoreId *string
@@ -0,0 +1,454 @@
+// Code is generated by go generate. DO NOT EDIT.
+package model
+
+// DescriptionSchemaVersions is the version of the json schema of each resource type description.
+// The schemas are generated into aws/model/schemas.
+var DescriptionSchemaVersions = map[string]int{
+	"AWS::ACMPCA::CertificateAuthority":                  1,
+	"AWS::AMP::Workspace":                                1,
+	"AWS::AccessAnalyzer::Analyzer":                      1,
+	"AWS::AccessAnalyzer::Finding":                       1,
+	"AWS::Account::Account":                              1,
+	"AWS::Account::AlternateContact":                     1,
+	"AWS::Account::Contact":                              1,
+	"AWS::Amplify::App":                                  1,
+	"AWS::ApiGateway::ApiKey":                            1,
+	"AWS::ApiGateway::Authorizer":                        1,
+	"AWS::ApiGateway::DomainName":                        1,
+	"AWS::ApiGateway::RestApi":                           1,
+	"AWS::ApiGateway::Stage":                             1,
+	"AWS::ApiGateway::UsagePlan":                         1,
+	"AWS::ApiGatewayV2::Api":                             1,
+	"AWS::ApiGatewayV2::DomainName":                      1,
+	"AWS::ApiGatewayV2::Integration":                     1,
+	"AWS::ApiGatewayV2::Route":                           1,
+	"AWS::ApiGatewayV2::Stage":                           1,
+	"AWS::AppConfig::Application":                        1,
+	"AWS::AppStream::Application":                        1,
+	"AWS::AppStream::Fleet":                              1,
+	"AWS::AppStream::Image":                              1,
+	"AWS::AppStream::Stack":                              1,
+	"AWS::ApplicationAutoScaling::Policy":                1,
+	"AWS::ApplicationAutoScaling::Target":                1,
+	"AWS::Athena::QueryExecution":                        1,
+	"AWS::Athena::WorkGroup":                             1,
+	"AWS::AuditManager::Assessment":                      1,
+	"AWS::AuditManager::Control":                         1,
+	"AWS::AuditManager::Evidence":                        1,
+	"AWS::AuditManager::EvidenceFolder":                  1,
+	"AWS::AuditManager::Framework":                       1,
+	"AWS::AutoScaling::AutoScalingGroup":                 1,
+	"AWS::AutoScaling::LaunchConfiguration":              1,
+	"AWS::Backup::Framework":                             1,
+	"AWS::Backup::LegalHold":                             1,
+	"AWS::Backup::Plan":                                  1,
+	"AWS::Backup::ProtectedResource":                     1,
+	"AWS::Backup::RecoveryPoint":                         1,
+	"AWS::Backup::RegionSetting":                         1,
+	"AWS::Backup::ReportPlan":                            1,
+	"AWS::Backup::ResourceCoverage":                      1,
+	"AWS::Backup::Selection":                             2,
+	"AWS::Backup::Vault":                                 1,
+	"AWS::Batch::ComputeEnvironment":                     1,
+	"AWS::Batch::Job":                                    1,
+	"AWS::Batch::JobQueue":                               1,
+	"AWS::CertificateManager::Certificate":               1,
+	"AWS::CloudFormation::Stack":                         1,
+	"AWS::CloudFormation::StackResource":                 1,
+	"AWS::CloudFormation::StackSet":                      1,
+	"AWS::CloudFront::CachePolicy":                       1,
+	"AWS::CloudFront::Distribution":                      1,
+	"AWS::CloudFront::Function":                          1,
+	"AWS::CloudFront::OriginAccessControl":               1,
+	"AWS::CloudFront::OriginAccessIdentity":              1,
+	"AWS::CloudFront::OriginRequestPolicy":               1,
+	"AWS::CloudFront::ResponseHeadersPolicy":             1,
+	"AWS::CloudFront::StreamingDistribution":             1,
+	"AWS::CloudSearch::Domain":                           1,
+	"AWS::CloudTrail::Channel":                           1,
+	"AWS::CloudTrail::EventDataStore":                    1,
+	"AWS::CloudTrail::Import":                            1,
+	"AWS::CloudTrail::Query":                             1,
+	"AWS::CloudTrail::Trail":                             1,
+	"AWS::CloudTrail::TrailEvent":                        1,
+	"AWS::CloudWatch::Alarm":                             1,
+	"AWS::CloudWatch::LogEvent":                          1,
+	"AWS::CloudWatch::LogResourcePolicy":                 1,
+	"AWS::CloudWatch::LogStream":                         1,
+	"AWS::CloudWatch::LogSubscriptionFilter":             1,
+	"AWS::CloudWatch::Metric":                            1,
+	"AWS::CodeArtifact::Domain":                          1,
+	"AWS::CodeArtifact::Repository":                      1,
+	"AWS::CodeBuild::Build":                              1,
+	"AWS::CodeBuild::Project":                            1,
+	"AWS::CodeBuild::SourceCredential":                   1,
+	"AWS::CodeCommit::Repository":                        1,
+	"AWS::CodeDeploy::Application":                       1,
+	"AWS::CodeDeploy::DeploymentConfig":                  1,
+	"AWS::CodeDeploy::DeploymentGroup":                   1,
+	"AWS::CodePipeline::Pipeline":                        1,
+	"AWS::CodeStar::Project":                             1,
+	"AWS::Config::AggregationAuthorization":              1,
+	"AWS::Config::ConfigurationRecorder":                 1,
+	"AWS::Config::ConformancePack":                       1,
+	"AWS::Config::RetentionConfiguration":                1,
+	"AWS::Config::Rule":                                  1,
+	"AWS::CostExplorer::ByAccountDaily":                  1,
+	"AWS::CostExplorer::ByAccountMonthly":                1,
+	"AWS::CostExplorer::ByRecordTypeDaily":               1,
+	"AWS::CostExplorer::ByRecordTypeMonthly":             1,
+	"AWS::CostExplorer::ByServiceDaily":                  1,
+	"AWS::CostExplorer::ByServiceMonthly":                1,
+	"AWS::CostExplorer::ByUsageTypeDaily":                1,
+	"AWS::CostExplorer::ByUsageTypeMonthly":              1,
+	"AWS::CostExplorer::ForcastDaily":                    1,
+	"AWS::CostExplorer::ForcastMonthly":                  1,
+	"AWS::DAX::Cluster":                                  1,
+	"AWS::DAX::Parameter":                                1,
+	"AWS::DAX::ParameterGroup":                           1,
+	"AWS::DAX::SubnetGroup":                              1,
+	"AWS::DLM::LifecyclePolicy":                          1,
+	"AWS::DMS::Endpoint":                                 1,
+	"AWS::DMS::ReplicationInstance":                      1,
+	"AWS::DMS::ReplicationTask":                          1,
+	"AWS::DirectConnect::Connection":                     1,
+	"AWS::DirectConnect::Gateway":                        1,
+	"AWS::DirectoryService::Certificate":                 1,
+	"AWS::DirectoryService::Directory":                   1,
+	"AWS::DirectoryService::LogSubscription":             1,
+	"AWS::DocDB::Cluster":                                1,
+	"AWS::DocDB::ClusterInstance":                        1,
+	"AWS::DocDB::ClusterSnapshot":                        1,
+	"AWS::DynamoDb::BackUp":                              1,
+	"AWS::DynamoDb::GlobalSecondaryIndex":                1,
+	"AWS::DynamoDb::GlobalTable":                         1,
+	"AWS::DynamoDb::LocalSecondaryIndex":                 1,
+	"AWS::DynamoDb::Table":                               1,
+	"AWS::DynamoDb::TableExport":                         1,
+	"AWS::DynamoDbStreams::Stream":                       1,
+	"AWS::EC2::AvailabilityZone":                         1,
+	"AWS::EC2::CapacityReservation":                      1,
+	"AWS::EC2::CapacityReservationFleet":                 1,
+	"AWS::EC2::ClientVpnEndpoint":                        1,
+	"AWS::EC2::CustomerGateway":                          1,
+	"AWS::EC2::DHCPOptions":                              1,
+	"AWS::EC2::EIP":                                      1,
+	"AWS::EC2::EgressOnlyInternetGateway":                1,
+	"AWS::EC2::ElasticIP":                                1,
+	"AWS::EC2::Fleet":                                    1,
+	"AWS::EC2::FlowLog":                                  1,
+	"AWS::EC2::Host":                                     1,
+	"AWS::EC2::Image":                                    1,
+	"AWS::EC2::Instance":                                 1,
+	"AWS::EC2::InstanceAvailability":                     1,
+	"AWS::EC2::InstanceMetricCpuUtilizationHourly":       1,
+	"AWS::EC2::InstanceType":                             1,
+	"AWS::EC2::InternetGateway":                          1,
+	"AWS::EC2::Ipam":                                     1,
+	"AWS::EC2::IpamPool":                                 1,
+	"AWS::EC2::KeyPair":                                  1,
+	"AWS::EC2::LaunchTemplate":                           1,
+	"AWS::EC2::LaunchTemplateVersion":                    1,
+	"AWS::EC2::LocalGateway":                             1,
+	"AWS::EC2::ManagedPrefixList":                        1,
+	"AWS::EC2::ManagedPrefixListEntry":                   1,
+	"AWS::EC2::NatGateway":                               1,
+	"AWS::EC2::NetworkAcl":                               1,
+	"AWS::EC2::NetworkExposure":                          1,
+	"AWS::EC2::NetworkInterface":                         1,
+	"AWS::EC2::PlacementGroup":                           1,
+	"AWS::EC2::Region":                                   1,
+	"AWS::EC2::RegionalSettings":                         1,
+	"AWS::EC2::ReservedInstances":                        1,
+	"AWS::EC2::RouteTable":                               1,
+	"AWS::EC2::SecurityGroup":                            1,
+	"AWS::EC2::SecurityGroupRule":                        1,
+	"AWS::EC2::Subnet":                                   1,
+	"AWS::EC2::TransitGateway":                           1,
+	"AWS::EC2::TransitGatewayAttachment":                 1,
+	"AWS::EC2::TransitGatewayRoute":                      1,
+	"AWS::EC2::TransitGatewayRouteTable":                 1,
+	"AWS::EC2::VPC":                                      1,
+	"AWS::EC2::VPCEndpoint":                              1,
+	"AWS::EC2::VPCEndpointService":                       1,
+	"AWS::EC2::VPCPeeringConnection":                     1,
+	"AWS::EC2::VPNConnection":                            1,
+	"AWS::EC2::VPNGateway":                               1,
+	"AWS::EC2::VerifiedAccessEndpoint":                   1,
+	"AWS::EC2::VerifiedAccessGroup":                      1,
+	"AWS::EC2::VerifiedAccessInstance":                   1,
+	"AWS::EC2::VerifiedAccessTrustProvider":              1,
+	"AWS::EC2::Volume":                                   1,
+	"AWS::EC2::VolumeSnapshot":                           1,
+	"AWS::ECR::Image":                                    1,
+	"AWS::ECR::PublicRegistry":                           1,
+	"AWS::ECR::PublicRepository":                         1,
+	"AWS::ECR::Registry":                                 1,
+	"AWS::ECR::RegistryScanningConfiguration":            1,
+	"AWS::ECR::Repository":                               1,
+	"AWS::ECS::Cluster":                                  1,
+	"AWS::ECS::ContainerInstance":                        1,
+	"AWS::ECS::Service":                                  1,
+	"AWS::ECS::Task":                                     1,
+	"AWS::ECS::TaskDefinition":                           1,
+	"AWS::ECS::TaskSet":                                  1,
+	"AWS::EFS::AccessPoint":                              1,
+	"AWS::EFS::FileSystem":                               1,
+	"AWS::EFS::MountTarget":                              1,
+	"AWS::EKS::Addon":                                    1,
+	"AWS::EKS::AddonVersion":                             1,
+	"AWS::EKS::Cluster":                                  1,
+	"AWS::EKS::FargateProfile":                           1,
+	"AWS::EKS::Nodegroup":                                1,
+	"AWS::EMR::BlockPublicAccessConfiguration":           1,
+	"AWS::EMR::Cluster":                                  1,
+	"AWS::EMR::Instance":                                 1,
+	"AWS::EMR::InstanceFleet":                            1,
+	"AWS::EMR::InstanceGroup":                            1,
+	"AWS::ElastiCache::Cluster":                          1,
+	"AWS::ElastiCache::ParameterGroup":                   1,
+	"AWS::ElastiCache::ReplicationGroup":                 1,
+	"AWS::ElastiCache::ReservedCacheNode":                1,
+	"AWS::ElastiCache::SubnetGroup":                      1,
+	"AWS::ElasticBeanstalk::Application":                 1,
+	"AWS::ElasticBeanstalk::ApplicationVersion":          1,
+	"AWS::ElasticBeanstalk::Environment":                 1,
+	"AWS::ElasticLoadBalancing::LoadBalancer":            1,
+	"AWS::ElasticLoadBalancingV2::Listener":              1,
+	"AWS::ElasticLoadBalancingV2::ListenerRule":          1,
+	"AWS::ElasticLoadBalancingV2::LoadBalancer":          1,
+	"AWS::ElasticLoadBalancingV2::SslPolicy":             1,
+	"AWS::ElasticLoadBalancingV2::TargetGroup":           1,
+	"AWS::ElasticSearch::Domain":                         1,
+	"AWS::EventBridge::EventBus":                         1,
+	"AWS::EventBridge::EventRule":                        1,
+	"AWS::FSX::FileSystem":                               1,
+	"AWS::FSX::Snapshot":                                 1,
+	"AWS::FSX::StorageVirtualMachine":                    1,
+	"AWS::FSX::Task":                                     1,
+	"AWS::FSX::Volume":                                   1,
+	"AWS::Firehose::DeliveryStream":                      1,
+	"AWS::Glacier::Vault":                                1,
+	"AWS::GlobalAccelerator::Accelerator":                1,
+	"AWS::GlobalAccelerator::EndpointGroup":              1,
+	"AWS::GlobalAccelerator::Listener":                   1,
+	"AWS::Glue::CatalogDatabase":                         1,
+	"AWS::Glue::CatalogTable":                            1,
+	"AWS::Glue::Connection":                              1,
+	"AWS::Glue::Crawler":                                 1,
+	"AWS::Glue::DataCatalogEncryptionSettings":           1,
+	"AWS::Glue::DataQualityRuleset":                      1,
+	"AWS::Glue::DevEndpoint":                             1,
+	"AWS::Glue::Job":                                     1,
+	"AWS::Glue::SecurityConfiguration":                   1,
+	"AWS::Grafana::Workspace":                            1,
+	"AWS::GuardDuty::Detector":                           1,
+	"AWS::GuardDuty::Filter":                             1,
+	"AWS::GuardDuty::Finding":                            1,
+	"AWS::GuardDuty::IPSet":                              1,
+	"AWS::GuardDuty::Member":                             1,
+	"AWS::GuardDuty::PublishingDestination":              1,
+	"AWS::GuardDuty::ThreatIntelSet":                     1,
+	"AWS::Health::AffectedEntity":                        1,
+	"AWS::Health::Event":                                 1,
+	"AWS::IAM::AccessAdvisor":                            1,
+	"AWS::IAM::AccessKey":                                1,
+	"AWS::IAM::AccountPasswordPolicy":                    1,
+	"AWS::IAM::AccountSummary":                           1,
+	"AWS::IAM::CredentialReport":                         1,
+	"AWS::IAM::EffectivePermission":                      1,
+	"AWS::IAM::Group":                                    1,
+	"AWS::IAM::OpenIdConnectProvider":                    1,
+	"AWS::IAM::Policy":                                   1,
+	"AWS::IAM::PolicyAttachment":                         1,
+	"AWS::IAM::Role":                                     1,
+	"AWS::IAM::SSHPublicKey":                             1,
+	"AWS::IAM::SamlProvider":                             1,
+	"AWS::IAM::ServerCertificate":                        1,
+	"AWS::IAM::ServiceSpecificCredential":                1,
+	"AWS::IAM::User":                                     1,
+	"AWS::IAM::VirtualMFADevice":                         1,
+	"AWS::IdentityStore::Group":                          1,
+	"AWS::IdentityStore::GroupMembership":                1,
+	"AWS::IdentityStore::User":                           1,
+	"AWS::ImageBuilder::Image":                           1,
+	"AWS::Inspector2::Coverage":                          1,
+	"AWS::Inspector2::CoverageStatistics":                1,
+	"AWS::Inspector2::Finding":                           1,
+	"AWS::Inspector2::Member":                            1,
+	"AWS::Inspector::AssessmentRun":                      1,
+	"AWS::Inspector::AssessmentTarget":                   1,
+	"AWS::Inspector::AssessmentTemplate":                 1,
+	"AWS::Inspector::Exclusion":                          1,
+	"AWS::Inspector::Finding":                            1,
+	"AWS::KMS::Alias":                                    1,
+	"AWS::KMS::Key":                                      1,
+	"AWS::KMS::KeyRotation":                              1,
+	"AWS::Kafka::Cluster":                                1,
+	"AWS::Keyspaces::Keyspace":                           1,
+	"AWS::Keyspaces::Table":                              1,
+	"AWS::Kinesis::Consumer":                             1,
+	"AWS::Kinesis::Stream":                               1,
+	"AWS::KinesisAnalyticsV2::Application":               1,
+	"AWS::KinesisVideo::Stream":                          1,
+	"AWS::Lambda::Alias":                                 1,
+	"AWS::Lambda::Function":                              1,
+	"AWS::Lambda::FunctionVersion":                       1,
+	"AWS::Lambda::LambdaLayer":                           1,
+	"AWS::Lambda::LayerVersion":                          1,
+	"AWS::Lightsail::Instance":                           1,
+	"AWS::Logs::LogGroup":                                1,
+	"AWS::Logs::MetricFilter":                            1,
+	"AWS::MQ::Broker":                                    1,
+	"AWS::MWAA::Environment":                             1,
+	"AWS::Macie2::ClassificationJob":                     1,
+	"AWS::MediaStore::Container":                         1,
+	"AWS::MemoryDb::Cluster":                             1,
+	"AWS::Mgn::Application":                              1,
+	"AWS::Neptune::DBCluster":                            1,
+	"AWS::Neptune::DBClusterSnapshot":                    1,
+	"AWS::Neptune::Database":                             1,
+	"AWS::NetworkFirewall::Firewall":                     1,
+	"AWS::NetworkFirewall::FirewallPolicy":               1,
+	"AWS::NetworkFirewall::RuleGroup":                    1,
+	"AWS::Oam::Link":                                     1,
+	"AWS::Oam::Sink":                                     1,
+	"AWS::OpenSearch::Domain":                            1,
+	"AWS::OpenSearchServerless::Collection":              1,
+	"AWS::OpsWorksCM::Server":                            1,
+	"AWS::Organizations::Account":                        1,
+	"AWS::Organizations::AccountEffectivePolicy":         1,
+	"AWS::Organizations::Organization":                   1,
+	"AWS::Organizations::OrganizationalUnit":             1,
+	"AWS::Organizations::Policy":                         1,
+	"AWS::Organizations::PolicyTarget":                   1,
+	"AWS::Organizations::Root":                           1,
+	"AWS::Pinpoint::App":                                 1,
+	"AWS::Pipes::Pipe":                                   1,
+	"AWS::RDS::DBCluster":                                1,
+	"AWS::RDS::DBClusterParameterGroup":                  1,
+	"AWS::RDS::DBClusterSnapshot":                        1,
+	"AWS::RDS::DBEngineVersion":                          1,
+	"AWS::RDS::DBEventSubscription":                      1,
+	"AWS::RDS::DBInstance":                               1,
+	"AWS::RDS::DBInstanceAutomatedBackup":                1,
+	"AWS::RDS::DBParameterGroup":                         1,
+	"AWS::RDS::DBProxy":                                  1,
+	"AWS::RDS::DBRecommendation":                         1,
+	"AWS::RDS::DBSnapshot":                               1,
+	"AWS::RDS::DBSubnetGroup":                            1,
+	"AWS::RDS::GlobalCluster":                            1,
+	"AWS::RDS::OptionGroup":                              1,
+	"AWS::RDS::ReservedDBInstance":                       1,
+	"AWS::Ram::PrincipalAssociation":                     1,
+	"AWS::Ram::ResourceAssociation":                      1,
+	"AWS::Redshift::Cluster":                             1,
+	"AWS::Redshift::ClusterParameterGroup":               1,
+	"AWS::Redshift::EventSubscription":                   1,
+	"AWS::Redshift::Snapshot":                            1,
+	"AWS::Redshift::SubnetGroup":                         1,
+	"AWS::RedshiftServerless::Namespace":                 1,
+	"AWS::RedshiftServerless::Snapshot":                  1,
+	"AWS::RedshiftServerless::Workgroup":                 1,
+	"AWS::ResourceExplorer2::Index":                      1,
+	"AWS::ResourceExplorer2::SupportedResourceType":      1,
+	"AWS::ResourceGroups::Groups":                        1,
+	"AWS::Route53::HealthCheck":                          1,
+	"AWS::Route53::HostedZone":                           1,
+	"AWS::Route53::QueryLog":                             1,
+	"AWS::Route53::Record":                               1,
+	"AWS::Route53::TrafficPolicy":                        1,
+	"AWS::Route53::TrafficPolicyInstance":                1,
+	"AWS::Route53Domains::Domain":                        1,
+	"AWS::Route53Resolver::QueryLogConfig":               1,
+	"AWS::Route53Resolver::ResolverEndpoint":             1,
+	"AWS::Route53Resolver::ResolverRule":                 1,
+	"AWS::S3::AccessPoint":                               1,
+	"AWS::S3::AccountSetting":                            1,
+	"AWS::S3::Bucket":                                    1,
+	"AWS::S3::BucketIntelligentTieringConfiguration":     1,
+	"AWS::S3::MultiRegionAccessPoint":                    1,
+	"AWS::S3::Object":                                    1,
+	"AWS::SES::ConfigurationSet":                         1,
+	"AWS::SES::Identity":                                 1,
+	"AWS::SESv2::EmailIdentities":                        1,
+	"AWS::SNS::Subscription":                             1,
+	"AWS::SNS::Topic":                                    1,
+	"AWS::SQS::Queue":                                    1,
+	"AWS::SSM::Association":                              1,
+	"AWS::SSM::Document":                                 1,
+	"AWS::SSM::DocumentPermission":                       1,
+	"AWS::SSM::Inventory":                                1,
+	"AWS::SSM::InventoryEntry":                           1,
+	"AWS::SSM::MaintenanceWindow":                        1,
+	"AWS::SSM::ManagedInstance":                          1,
+	"AWS::SSM::ManagedInstanceCompliance":                1,
+	"AWS::SSM::ManagedInstancePatchState":                1,
+	"AWS::SSM::Parameter":                                1,
+	"AWS::SSM::PatchBaseline":                            1,
+	"AWS::SSOAdmin::AccountAssignment":                   1,
+	"AWS::SSOAdmin::AttachedManagedPolicy":               1,
+	"AWS::SSOAdmin::Instance":                            1,
+	"AWS::SSOAdmin::PermissionSet":                       1,
+	"AWS::SSOAdmin::UserEffectiveAccess":                 1,
+	"AWS::SageMaker::App":                                1,
+	"AWS::SageMaker::Domain":                             1,
+	"AWS::SageMaker::EndpointConfiguration":              1,
+	"AWS::SageMaker::Model":                              1,
+	"AWS::SageMaker::NotebookInstance":                   1,
+	"AWS::SageMaker::TrainingJob":                        1,
+	"AWS::SecretsManager::Secret":                        1,
+	"AWS::SecurityHub::ActionTarget":                     1,
+	"AWS::SecurityHub::Finding":                          1,
+	"AWS::SecurityHub::FindingAggregator":                1,
+	"AWS::SecurityHub::Hub":                              1,
+	"AWS::SecurityHub::Insight":                          1,
+	"AWS::SecurityHub::Member":                           1,
+	"AWS::SecurityHub::Product":                          1,
+	"AWS::SecurityHub::StandardsControl":                 1,
+	"AWS::SecurityHub::StandardsSubscription":            1,
+	"AWS::SecurityLake::DataLake":                        1,
+	"AWS::SecurityLake::Subscriber":                      1,
+	"AWS::ServiceCatalog::Portfolio":                     1,
+	"AWS::ServiceCatalog::Product":                       1,
+	"AWS::ServiceDiscovery::Instance":                    1,
+	"AWS::ServiceDiscovery::Namespace":                   1,
+	"AWS::ServiceDiscovery::Service":                     1,
+	"AWS::ServiceQuotas::Service":                        1,
+	"AWS::ServiceQuotas::ServiceQuotaChangeRequest":      1,
+	"AWS::SeverlessApplicationRepository::Application":   1,
+	"AWS::Shield::ProtectionGroup":                       1,
+	"AWS::SimSpaceWeaver::Simulation":                    1,
+	"AWS::StepFunctions::StateMachine":                   1,
+	"AWS::StepFunctions::StateMachineExecution":          1,
+	"AWS::StepFunctions::StateMachineExecutionHistories": 1,
+	"AWS::StorageGateway::StorageGateway":                1,
+	"AWS::Timestream::Database":                          1,
+	"AWS::WAF::RateBasedRule":                            1,
+	"AWS::WAF::Rule":                                     1,
+	"AWS::WAF::RuleGroup":                                1,
+	"AWS::WAF::WebACL":                                   1,
+	"AWS::WAFRegional::Rule":                             1,
+	"AWS::WAFRegional::RuleGroup":                        1,
+	"AWS::WAFRegional::WebACL":                           1,
+	"AWS::WAFv2::IPSet":                                  1,
+	"AWS::WAFv2::RegexPatternSet":                        1,
+	"AWS::WAFv2::RuleGroup":                              1,
+	"AWS::WAFv2::WebACL":                                 1,
+	"AWS::WellArchitected::Answer":                       1,
+	"AWS::WellArchitected::CheckDetail":                  1,
+	"AWS::WellArchitected::CheckSummary":                 1,
+	"AWS::WellArchitected::ConsolidatedReport":           1,
+	"AWS::WellArchitected::Lens":                         1,
+	"AWS::WellArchitected::LensReview":                   1,
+	"AWS::WellArchitected::LensReviewImprovement":        1,
+	"AWS::WellArchitected::LensReviewReport":             1,
+	"AWS::WellArchitected::LensShare":                    1,
+	"AWS::WellArchitected::Milestone":                    1,
+	"AWS::WellArchitected::Notification":                 1,
+	"AWS::WellArchitected::ShareInvitation":              1,
+	"AWS::WellArchitected::Workload":                     1,
+	"AWS::WellArchitected::WorkloadShare":                1,
+	"AWS::Workspaces::Bundle":                            1,
+	"AWS::Workspaces::Workspace":                         1,
+}
@@ -0,0 +1,2146 @@
+// Code is generated by go generate. DO NOT EDIT.
+
+package aws
+
+var describerInfos = map[string]DescriberInfo{
+	"AWS::Redshift::Snapshot": {
+		ListDescriber: "RedshiftSnapshot",
+		GetDescriber:  "GetRedshiftSnapshot",
+		Actions:       []string{"redshift:DescribeClusterSnapshots"},
+	},
+	"AWS::IAM::AccountSummary": {
+		ListDescriber: "IAMAccountSummary",
+		GetDescriber:  "",
+		Actions:       []string{"iam:GetAccountSummary", "sts:GetCallerIdentity"},
+	},
+	"AWS::Glacier::Vault": {
+		ListDescriber: "GlacierVault",
+		GetDescriber:  "GetGlacierVault",
+		Actions:       []string{"glacier:DescribeVault", "glacier:GetVaultAccessPolicy", "glacier:GetVaultLock", "glacier:GetVaultNotifications", "glacier:ListTagsForVault", "glacier:ListVaults"},
+	},
+	"AWS::Organizations::Organization": {
+		ListDescriber: "OrganizationsOrganization",
+		GetDescriber:  "",
+		Actions:       []string{"organizations:DescribeOrganization"},
+	},
+	"AWS::Organizations::Policy": {
+		ListDescriber: "OrganizationsPolicy",
+		GetDescriber:  "",
+		Actions:       []string{"organizations:DescribePolicy", "organizations:ListPolicies"},
+	},
+	"AWS::Organizations::PolicyTarget": {
+		ListDescriber: "OrganizationsPolicyTarget",
+		GetDescriber:  "",
+		Actions:       []string{"organizations:DescribePolicy", "organizations:ListAccounts", "organizations:ListOrganizationalUnitsForParent", "organizations:ListPoliciesForTarget", "organizations:ListRoots", "organizations:ListTagsForResource"},
+	},
+	"AWS::Organizations::OrganizationalUnit": {
+		ListDescriber: "OrganizationsOrganizationalUnit",
+		GetDescriber:  "",
+		Actions:       []string{"organizations:ListOrganizationalUnitsForParent", "organizations:ListRoots", "organizations:ListTagsForResource"},
+	},
+	"AWS::Organizations::Root": {
+		ListDescriber: "OrganizationsRoot",
+		GetDescriber:  "",
+		Actions:       []string{"organizations:ListRoots"},
+	},
+	"AWS::CloudSearch::Domain": {
+		ListDescriber: "CloudSearchDomain",
+		GetDescriber:  "",
+		Actions:       []string{"cloudsearch:DescribeDomains", "cloudsearch:ListDomainNames"},
+	},
+	"AWS::DynamoDb::GlobalSecondaryIndex": {
+		ListDescriber: "DynamoDbGlobalSecondaryIndex",
+		GetDescriber:  "GetDynamoDbGlobalSecondaryIndex",
+		Actions:       []string{"dynamodb:DescribeTable", "dynamodb:ListTables"},
+	},
+	"AWS::EC2::RouteTable": {
+		ListDescriber: "EC2RouteTable",
+		GetDescriber:  "GetEC2RouteTable",
+		Actions:       []string{"ec2:DescribeRouteTables"},
+	},
+	"AWS::SecurityHub::Hub": {
+		ListDescriber: "SecurityHubHub",
+		GetDescriber:  "",
+		Actions:       []string{"securityhub:DescribeHub", "securityhub:GetAdministratorAccount", "securityhub:ListTagsForResource"},
+	},
+	"AWS::StorageGateway::StorageGateway": {
+		ListDescriber: "StorageGatewayStorageGateway",
+		GetDescriber:  "",
+		Actions:       []string{"storagegateway:ListGateways", "storagegateway:ListTagsForResource"},
+	},
+	"AWS::Inspector::AssessmentTemplate": {
+		ListDescriber: "InspectorAssessmentTemplate",
+		GetDescriber:  "GetInspectorAssessmentTemplate",
+		Actions:       []string{"inspector:DescribeAssessmentTemplates", "inspector:ListAssessmentTemplates", "inspector:ListEventSubscriptions", "inspector:ListTagsForResource"},
+	},
+	"AWS::ElasticLoadBalancingV2::ListenerRule": {
+		ListDescriber: "ElasticLoadBalancingV2ListenerRule",
+		GetDescriber:  "",
+		Actions:       []string{"elasticloadbalancing:DescribeListeners", "elasticloadbalancing:DescribeLoadBalancerAttributes", "elasticloadbalancing:DescribeLoadBalancers", "elasticloadbalancing:DescribeRules", "elasticloadbalancing:DescribeTags"},
+	},
+	"AWS::IAM::Role": {
+		ListDescriber: "IAMRole",
+		GetDescriber:  "",
+		Actions:       []string{"iam:GetRole", "iam:GetRolePolicy", "iam:ListAttachedRolePolicies", "iam:ListInstanceProfilesForRole", "iam:ListRolePolicies", "iam:ListRoles"},
+	},
+	"AWS::Backup::ProtectedResource": {
+		ListDescriber: "BackupProtectedResource",
+		GetDescriber:  "",
+		Actions:       []string{"backup:ListProtectedResources"},
+	},
+	"AWS::CodeCommit::Repository": {
+		ListDescriber: "CodeCommitRepository",
+		GetDescriber:  "",
+		Actions:       []string{"codecommit:BatchGetRepositories", "codecommit:ListRepositories", "codecommit:ListTagsForResource"},
+	},
+	"AWS::EC2::VPCEndpoint": {
+		ListDescriber: "EC2VPCEndpoint",
+		GetDescriber:  "GetEC2VPCEndpoint",
+		Actions:       []string{"ec2:DescribeVpcEndpoints"},
+	},
+	"AWS::EventBridge::EventRule": {
+		ListDescriber: "EventBridgeRule",
+		GetDescriber:  "",
+		Actions:       []string{"events:DescribeRule", "events:ListRules", "events:ListTagsForResource", "events:ListTargetsByRule"},
+	},
+	"AWS::CloudFront::OriginAccessControl": {
+		ListDescriber: "CloudFrontOriginAccessControl",
+		GetDescriber:  "",
+		Actions:       []string{"cloudfront:ListOriginAccessControls", "cloudfront:ListTagsForResource"},
+	},
+	"AWS::CodeBuild::Project": {
+		ListDescriber: "CodeBuildProject",
+		GetDescriber:  "GetCodeBuildProject",
+		Actions:       []string{"codebuild:BatchGetProjects", "codebuild:ListProjects"},
+	},
+	"AWS::CodeBuild::Build": {
+		ListDescriber: "CodeBuildBuild",
+		GetDescriber:  "GetCodeBuildBuild",
+		Actions:       []string{"codebuild:BatchGetBuilds", "codebuild:ListBuilds"},
+	},
+	"AWS::ElastiCache::ParameterGroup": {
+		ListDescriber: "ElastiCacheParameterGroup",
+		GetDescriber:  "",
+		Actions:       []string{"elasticache:DescribeCacheParameterGroups"},
+	},
+	"AWS::MemoryDb::Cluster": {
+		ListDescriber: "MemoryDbCluster",
+		GetDescriber:  "",
+		Actions:       []string{"memorydb:DescribeClusters", "memorydb:ListTags"},
+	},
+	"AWS::Glue::Crawler": {
+		ListDescriber: "GlueCrawler",
+		GetDescriber:  "GetGlueCrawler",
+		Actions:       []string{"glue:GetCrawler", "glue:GetCrawlers"},
+	},
+	"AWS::DirectConnect::Gateway": {
+		ListDescriber: "DirectConnectGateway",
+		GetDescriber:  "",
+		Actions:       []string{"directconnect:DescribeDirectConnectGateways", "directconnect:DescribeTags"},
+	},
+	"AWS::DynamoDb::BackUp": {
+		ListDescriber: "DynamoDbBackUp",
+		GetDescriber:  "",
+		Actions:       []string{"dynamodb:ListBackups"},
+	},
+	"AWS::EC2::EIP": {
+		ListDescriber: "EC2EIP",
+		GetDescriber:  "GetEC2EIP",
+		Actions:       []string{"ec2:DescribeAddresses"},
+	},
+	"AWS::EC2::InternetGateway": {
+		ListDescriber: "EC2InternetGateway",
+		GetDescriber:  "GetEC2InternetGateway",
+		Actions:       []string{"ec2:DescribeInternetGateways"},
+	},
+	"AWS::GuardDuty::PublishingDestination": {
+		ListDescriber: "GuardDutyPublishingDestination",
+		GetDescriber:  "",
+		Actions:       []string{"guardduty:DescribePublishingDestination", "guardduty:ListDetectors", "guardduty:ListPublishingDestinations"},
+	},
+	"AWS::KinesisAnalyticsV2::Application": {
+		ListDescriber: "KinesisAnalyticsV2Application",
+		GetDescriber:  "",
+		Actions:       []string{"kinesisanalytics:DescribeApplication", "kinesisanalytics:ListApplications", "kinesisanalytics:ListTagsForResource"},
+	},
+	"AWS::EMR::Instance": {
+		ListDescriber: "EMRInstance",
+		GetDescriber:  "",
+		Actions:       []string{"elasticmapreduce:ListClusters", "elasticmapreduce:ListInstances"},
+	},
+	"AWS::EMR::BlockPublicAccessConfiguration": {
+		ListDescriber: "EMRBlockPublicAccessConfiguration",
+		GetDescriber:  "",
+		Actions:       []string{"elasticmapreduce:GetBlockPublicAccessConfiguration"},
+	},
+	"AWS::ApiGateway::RestApi": {
+		ListDescriber: "ApiGatewayRestAPI",
+		GetDescriber:  "GetApiGatewayRestAPI",
+		Actions:       []string{"apigateway:GET"},
+	},
+	"AWS::ApiGatewayV2::Integration": {
+		ListDescriber: "ApiGatewayV2Integration",
+		GetDescriber:  "GetApiGatewayV2Integration",
+		Actions:       []string{"apigateway:GET"},
+	},
+	"AWS::AutoScaling::AutoScalingGroup": {
+		ListDescriber: "AutoScalingAutoScalingGroup",
+		GetDescriber:  "GetAutoScalingAutoScalingGroup",
+		Actions:       []string{"autoscaling:DescribeAutoScalingGroups", "autoscaling:DescribePolicies"},
+	},
+	"AWS::DynamoDb::TableExport": {
+		ListDescriber: "DynamoDbTableExport",
+		GetDescriber:  "",
+		Actions:       []string{"dynamodb:DescribeExport", "dynamodb:ListExports"},
+	},
+	"AWS::EC2::KeyPair": {
+		ListDescriber: "EC2KeyPair",
+		GetDescriber:  "GetEC2KeyPair",
+		Actions:       []string{"ec2:DescribeKeyPairs"},
+	},
+	"AWS::EFS::FileSystem": {
+		ListDescriber: "EFSFileSystem",
+		GetDescriber:  "",
+		Actions:       []string{"elasticfilesystem:DescribeFileSystemPolicy", "elasticfilesystem:DescribeFileSystems"},
+	},
+	"AWS::Kafka::Cluster": {
+		ListDescriber: "KafkaCluster",
+		GetDescriber:  "",
+		Actions:       []string{"kafka:DescribeClusterOperation", "kafka:DescribeConfiguration", "kafka:ListClustersV2"},
+	},
+	"AWS::SecretsManager::Secret": {
+		ListDescriber: "SecretsManagerSecret",
+		GetDescriber:  "",
+		Actions:       []string{"secretsmanager:DescribeSecret", "secretsmanager:GetResourcePolicy", "secretsmanager:ListSecrets"},
+	},
+	"AWS::Backup::LegalHold": {
+		ListDescriber: "BackupLegalHold",
+		GetDescriber:  "",
+		Actions:       []string{"backup:GetLegalHold", "backup:ListLegalHolds"},
+	},
+	"AWS::CloudFront::Function": {
+		ListDescriber: "CloudFrontFunction",
+		GetDescriber:  "",
+		Actions:       []string{"cloudfront:DescribeFunction", "cloudfront:ListFunctions"},
+	},
+	"AWS::GlobalAccelerator::EndpointGroup": {
+		ListDescriber: "GlobalAcceleratorEndpointGroup",
+		GetDescriber:  "",
+		Actions:       []string{"globalaccelerator:ListAccelerators", "globalaccelerator:ListEndpointGroups", "globalaccelerator:ListListeners"},
+	},
+	"AWS::DAX::ParameterGroup": {
+		ListDescriber: "DAXParameterGroup",
+		GetDescriber:  "",
+		Actions:       []string{"dax:DescribeParameterGroups"},
+	},
+	"AWS::SQS::Queue": {
+		ListDescriber: "SQSQueue",
+		GetDescriber:  "",
+		Actions:       []string{"sqs:GetQueueAttributes", "sqs:ListQueueTags", "sqs:ListQueues"},
+	},
+	"AWS::Config::Rule": {
+		ListDescriber: "ConfigRule",
+		GetDescriber:  "",
+		Actions:       []string{"config:DescribeComplianceByConfigRule", "config:DescribeConfigRules", "config:ListTagsForResource"},
+	},
+	"AWS::GuardDuty::Member": {
+		ListDescriber: "GuardDutyMember",
+		GetDescriber:  "",
+		Actions:       []string{"guardduty:ListDetectors", "guardduty:ListMembers"},
+	},
+	"AWS::Inspector::Exclusion": {
+		ListDescriber: "InspectorExclusion",
+		GetDescriber:  "",
+		Actions:       []string{"inspector:DescribeExclusions", "inspector:ListAssessmentRuns", "inspector:ListExclusions"},
+	},
+	"AWS::DirectoryService::Directory": {
+		ListDescriber: "DirectoryServiceDirectory",
+		GetDescriber:  "",
+		Actions:       []string{"ds:DescribeDirectories", "ds:DescribeEventTopics", "ds:DescribeSharedDirectories", "ds:GetSnapshotLimits", "ds:ListTagsForResource"},
+	},
+	"AWS::DirectoryService::Certificate": {
+		ListDescriber: "DirectoryServiceCertificate",
+		GetDescriber:  "",
+		Actions:       []string{"ds:DescribeCertificate", "ds:DescribeDirectories", "ds:ListCertificates"},
+	},
+	"AWS::DirectoryService::LogSubscription": {
+		ListDescriber: "DirectoryServiceLogSubscription",
+		GetDescriber:  "",
+		Actions:       []string{"ds:DescribeDirectories", "ds:ListLogSubscriptions"},
+	},
+	"AWS::EFS::AccessPoint": {
+		ListDescriber: "EFSAccessPoint",
+		GetDescriber:  "",
+		Actions:       []string{"elasticfilesystem:DescribeAccessPoints"},
+	},
+	"AWS::IAM::PolicyAttachment": {
+		ListDescriber: "IAMPolicyAttachment",
+		GetDescriber:  "",
+		Actions:       []string{"iam:ListEntitiesForPolicy", "iam:ListPolicies"},
+	},
+	"AWS::IAM::CredentialReport": {
+		ListDescriber: "IAMCredentialReport",
+		GetDescriber:  "",
+		Actions:       []string{"iam:GenerateCredentialReport", "iam:GetCredentialReport"},
+	},
+	"AWS::RDS::GlobalCluster": {
+		ListDescriber: "RDSGlobalCluster",
+		GetDescriber:  "",
+		Actions:       []string{"rds:DescribeGlobalClusters", "rds:ListTagsForResource"},
+	},
+	"AWS::GuardDuty::Detector": {
+		ListDescriber: "GuardDutyDetector",
+		GetDescriber:  "",
+		Actions:       []string{"guardduty:GetDetector", "guardduty:ListDetectors"},
+	},
+	"AWS::SNS::Topic": {
+		ListDescriber: "SNSTopic",
+		GetDescriber:  "",
+		Actions:       []string{"sns:GetTopicAttributes", "sns:ListTagsForResource", "sns:ListTopics"},
+	},
+	"AWS::AppConfig::Application": {
+		ListDescriber: "AppConfigApplication",
+		GetDescriber:  "",
+		Actions:       []string{"appconfig:ListApplications", "appconfig:ListTagsForResource"},
+	},
+	"AWS::Batch::Job": {
+		ListDescriber: "BatchJob",
+		GetDescriber:  "",
+		Actions:       []string{"batch:DescribeJobQueues", "batch:ListJobs"},
+	},
+	"AWS::Batch::JobQueue": {
+		ListDescriber: "BatchJobQueue",
+		GetDescriber:  "",
+		Actions:       []string{"batch:DescribeJobQueues"},
+	},
+	"AWS::ECS::Service": {
+		ListDescriber: "ECSService",
+		GetDescriber:  "GetECSService",
+		Actions:       []string{"ecs:DescribeServices", "ecs:ListClusters", "ecs:ListServices", "ecs:ListTagsForResource"},
+	},
+	"AWS::FSX::Task": {
+		ListDescriber: "FSXTask",
+		GetDescriber:  "",
+		Actions:       []string{"fsx:DescribeDataRepositoryTasks"},
+	},
+	"AWS::IAM::VirtualMFADevice": {
+		ListDescriber: "IAMVirtualMFADevice",
+		GetDescriber:  "",
+		Actions:       []string{"iam:ListMFADeviceTags", "iam:ListVirtualMFADevices"},
+	},
+	"AWS::WAFv2::WebACL": {
+		ListDescriber: "WAFv2WebACL",
+		GetDescriber:  "",
+		Actions:       []string{"cloudfront:ListDistributionsByWebACLId", "wafv2:GetLoggingConfiguration", "wafv2:GetWebACL", "wafv2:ListResourcesForWebACL", "wafv2:ListTagsForResource", "wafv2:ListWebACLs"},
+	},
+	"AWS::ApplicationAutoScaling::Target": {
+		ListDescriber: "ApplicationAutoScalingTarget",
+		GetDescriber:  "",
+		Actions:       []string{"application-autoscaling:DescribeScalableTargets"},
+	},
+	"AWS::ApplicationAutoScaling::Policy": {
+		ListDescriber: "ApplicationAutoScalingPolicy",
+		GetDescriber:  "",
+		Actions:       []string{"application-autoscaling:DescribeScalingPolicies"},
+	},
+	"AWS::Backup::Vault": {
+		ListDescriber: "BackupVault",
+		GetDescriber:  "",
+		Actions:       []string{"backup:GetBackupVaultAccessPolicy", "backup:GetBackupVaultNotifications", "backup:ListBackupVaults", "backup:ListTags"},
+	},
+	"AWS::ElastiCache::Cluster": {
+		ListDescriber: "ElastiCacheCluster",
+		GetDescriber:  "GetElastiCacheCluster",
+		Actions:       []string{"elasticache:DescribeCacheClusters", "elasticache:ListTagsForResource"},
+	},
+	"AWS::Logs::LogGroup": {
+		ListDescriber: "CloudWatchLogsLogGroup",
+		GetDescriber:  "",
+		Actions:       []string{"logs:DescribeLogGroups", "logs:GetDataProtectionPolicy", "logs:ListTagsForResource"},
+	},
+	"AWS::S3::Bucket": {
+		ListDescriber: "S3Bucket",
+		GetDescriber:  "",
+		Actions:       []string{"s3:GetBucketAcl", "s3:GetBucketLocation", "s3:GetBucketLogging", "s3:GetBucketNotification", "s3:GetBucketObjectLockConfiguration", "s3:GetBucketOwnershipControls", "s3:GetBucketPolicy", "s3:GetBucketPolicyStatus", "s3:GetBucketPublicAccessBlock", "s3:GetBucketTagging", "s3:GetBucketVersioning", "s3:GetBucketWebsite", "s3:GetEncryptionConfiguration", "s3:GetLifecycleConfiguration", "s3:GetReplicationConfiguration", "s3:ListAllMyBuckets"},
+	},
+	"AWS::S3::BucketIntelligentTieringConfiguration": {
+		ListDescriber: "S3BucketIntelligentTieringConfiguration",
+		GetDescriber:  "",
+		Actions:       []string{"s3:GetBucketLocation", "s3:GetIntelligentTieringConfiguration", "s3:ListAllMyBuckets"},
+	},
+	"AWS::S3::MultiRegionAccessPoint": {
+		ListDescriber: "S3MultiRegionAccessPoint",
+		GetDescriber:  "",
+		Actions:       []string{"s3:ListMultiRegionAccessPoints", "sts:GetCallerIdentity"},
+	},
+	"AWS::CertificateManager::Certificate": {
+		ListDescriber: "CertificateManagerCertificate",
+		GetDescriber:  "",
+		Actions:       []string{"acm:DescribeCertificate", "acm:GetCertificate", "acm:ListCertificates", "acm:ListTagsForCertificate"},
+	},
+	"AWS::ApiGatewayV2::Api": {
+		ListDescriber: "ApiGatewayV2API",
+		GetDescriber:  "GetApiGatewayV2API",
+		Actions:       []string{"apigateway:GET"},
+	},
+	"AWS::EC2::Volume": {
+		ListDescriber: "EC2Volume",
+		GetDescriber:  "GetEC2Volume",
+		Actions:       []string{"ec2:DescribeVolumeAttribute", "ec2:DescribeVolumes"},
+	},
+	"AWS::ApiGateway::ApiKey": {
+		ListDescriber: "ApiGatewayApiKey",
+		GetDescriber:  "",
+		Actions:       []string{"apigateway:GET"},
+	},
+	"AWS::Glue::Connection": {
+		ListDescriber: "GlueConnection",
+		GetDescriber:  "",
+		Actions:       []string{"glue:GetConnections"},
+	},
+	"AWS::ECS::Task": {
+		ListDescriber: "ECSTask",
+		GetDescriber:  "",
+		Actions:       []string{"ecs:DescribeTasks", "ecs:GetTaskProtection", "ecs:ListClusters", "ecs:ListServices", "ecs:ListTasks"},
+	},
+	"AWS::SSM::ManagedInstance": {
+		ListDescriber: "SSMManagedInstance",
+		GetDescriber:  "",
+		Actions:       []string{"ssm:DescribeInstanceInformation"},
+	},
+	"AWS::SSM::Inventory": {
+		ListDescriber: "SSMInventory",
+		GetDescriber:  "",
+		Actions:       []string{"ssm:GetInventory", "ssm:GetInventorySchema"},
+	},
+	"AWS::SSM::InventoryEntry": {
+		ListDescriber: "SSMInventoryEntry",
+		GetDescriber:  "",
+		Actions:       []string{"ssm:GetInventory", "ssm:ListInventoryEntries"},
+	},
+	"AWS::SSM::MaintenanceWindow": {
+		ListDescriber: "SSMMaintenanceWindow",
+		GetDescriber:  "",
+		Actions:       []string{"ssm:DescribeMaintenanceWindowTargets", "ssm:DescribeMaintenanceWindowTasks", "ssm:DescribeMaintenanceWindows", "ssm:GetMaintenanceWindow", "ssm:ListTagsForResource"},
+	},
+	"AWS::SSM::PatchBaseline": {
+		ListDescriber: "SSMPatchBaseline",
+		GetDescriber:  "",
+		Actions:       []string{"ssm:DescribePatchBaselines", "ssm:GetPatchBaseline", "ssm:ListTagsForResource"},
+	},
+	"AWS::SSM::Parameter": {
+		ListDescriber: "SSMParameter",
+		GetDescriber:  "",
+		Actions:       []string{"ssm:DescribeParameters", "ssm:GetParameter", "ssm:ListTagsForResource"},
+	},
+	"AWS::Lambda::Function": {
+		ListDescriber: "LambdaFunction",
+		GetDescriber:  "GetLambdaFunction",
+		Actions:       []string{"lambda:GetFunction", "lambda:GetPolicy", "lambda:ListFunctionUrlConfigs", "lambda:ListFunctions"},
+	},
+	"AWS::RDS::DBSnapshot": {
+		ListDescriber: "RDSDBSnapshot",
+		GetDescriber:  "",
+		Actions:       []string{"rds:DescribeDBSnapshotAttributes", "rds:DescribeDBSnapshots"},
+	},
+	"AWS::CodeDeploy::Application": {
+		ListDescriber: "CodeDeployApplication",
+		GetDescriber:  "",
+		Actions:       []string{"codedeploy:GetApplication", "codedeploy:ListApplications", "codedeploy:ListTagsForResource"},
+	},
+	"AWS::CodeDeploy::DeploymentConfig": {
+		ListDescriber: "CodeDeployDeploymentConfig",
+		GetDescriber:  "",
+		Actions:       []string{"codedeploy:GetDeploymentConfig", "codedeploy:ListDeploymentConfigs"},
+	},
+	"AWS::EMR::Cluster": {
+		ListDescriber: "EMRCluster",
+		GetDescriber:  "",
+		Actions:       []string{"elasticmapreduce:DescribeCluster", "elasticmapreduce:ListClusters"},
+	},
+	"AWS::IAM::AccessKey": {
+		ListDescriber: "IAMAccessKey",
+		GetDescriber:  "",
+		Actions:       []string{"iam:GetAccessKeyLastUsed", "iam:ListAccessKeys", "iam:ListUsers"},
+	},
+	"AWS::IAM::SSHPublicKey": {
+		ListDescriber: "IAMSSHPublicKey",
+		GetDescriber:  "",
+		Actions:       []string{"iam:ListSSHPublicKeys", "iam:ListUsers"},
+	},
+	"AWS::Glue::CatalogTable": {
+		ListDescriber: "GlueCatalogTable",
+		GetDescriber:  "",
+		Actions:       []string{"glue:GetDatabases", "glue:GetTables", "lakeformation:GetResourceLFTags"},
+	},
+	"AWS::CloudTrail::Channel": {
+		ListDescriber: "CloudTrailChannel",
+		GetDescriber:  "",
+		Actions:       []string{"cloudtrail:GetChannel", "cloudtrail:ListChannels"},
+	},
+	"AWS::EC2::NetworkAcl": {
+		ListDescriber: "EC2NetworkAcl",
+		GetDescriber:  "GetEC2NetworkAcl",
+		Actions:       []string{"ec2:DescribeNetworkAcls"},
+	},
+	"AWS::ECS::ContainerInstance": {
+		ListDescriber: "ECSContainerInstance",
+		GetDescriber:  "",
+		Actions:       []string{"ecs:DescribeClusters", "ecs:DescribeContainerInstances", "ecs:ListClusters", "ecs:ListContainerInstances"},
+	},
+	"AWS::RedshiftServerless::Snapshot": {
+		ListDescriber: "RedshiftServerlessSnapshot",
+		GetDescriber:  "",
+		Actions:       []string{"redshift-serverless:ListSnapshots", "redshift-serverless:ListTagsForResource"},
+	},
+	"AWS::Workspaces::Bundle": {
+		ListDescriber: "WorkspacesBundle",
+		GetDescriber:  "",
+		Actions:       []string{"workspaces:DescribeTags", "workspaces:DescribeWorkspaceBundles"},
+	},
+	"AWS::CloudTrail::Trail": {
+		ListDescriber: "CloudTrailTrail",
+		GetDescriber:  "",
+		Actions:       []string{"cloudtrail:DescribeTrails", "cloudtrail:GetEventSelectors", "cloudtrail:GetTrailStatus", "cloudtrail:ListTags", "cloudtrail:ListTrails"},
+	},
+	"AWS::DAX::Parameter": {
+		ListDescriber: "DAXParameter",
+		GetDescriber:  "",
+		Actions:       []string{"dax:DescribeParameterGroups", "dax:DescribeParameters"},
+	},
+	"AWS::ECR::Image": {
+		ListDescriber: "ECRImage",
+		GetDescriber:  "",
+		Actions:       []string{"ecr:DescribeImages", "ecr:DescribeRepositories"},
+	},
+	"AWS::IAM::ServerCertificate": {
+		ListDescriber: "IAMServerCertificate",
+		GetDescriber:  "",
+		Actions:       []string{"iam:GetServerCertificate", "iam:ListServerCertificates"},
+	},
+	"AWS::Keyspaces::Keyspace": {
+		ListDescriber: "KeyspacesKeyspace",
+		GetDescriber:  "",
+		Actions:       []string{"cassandra:Select"},
+	},
+	"AWS::S3::AccessPoint": {
+		ListDescriber: "S3AccessPoint",
+		GetDescriber:  "",
+		Actions:       []string{"s3:GetAccessPoint", "s3:GetAccessPointPolicy", "s3:GetAccessPointPolicyStatus", "s3:ListAccessPoints", "sts:GetCallerIdentity"},
+	},
+	"AWS::SageMaker::EndpointConfiguration": {
+		ListDescriber: "SageMakerEndpointConfiguration",
+		GetDescriber:  "",
+		Actions:       []string{"sagemaker:DescribeEndpointConfig", "sagemaker:ListEndpointConfigs", "sagemaker:ListTags"},
+	},
+	"AWS::ElastiCache::ReservedCacheNode": {
+		ListDescriber: "ElastiCacheReservedCacheNode",
+		GetDescriber:  "",
+		Actions:       []string{"elasticache:DescribeReservedCacheNodes"},
+	},
+	"AWS::EMR::InstanceFleet": {
+		ListDescriber: "EMRInstanceFleet",
+		GetDescriber:  "",
+		Actions:       []string{"elasticmapreduce:ListClusters", "elasticmapreduce:ListInstanceFleets"},
+	},
+	"AWS::Account::Account": {
+		ListDescriber: "IAMAccount",
+		GetDescriber:  "",
+		Actions:       []string{"iam:ListAccountAliases", "organizations:DescribeOrganization", "organizations:ListAccounts", "sts:GetCallerIdentity"},
+	},
+	"AWS::EC2::VPCPeeringConnection": {
+		ListDescriber: "EC2VPCPeeringConnection",
+		GetDescriber:  "GetEC2VPCPeeringConnection",
+		Actions:       []string{"ec2:DescribeVpcPeeringConnections"},
+	},
+	"AWS::EKS::FargateProfile": {
+		ListDescriber: "EKSFargateProfile",
+		GetDescriber:  "",
+		Actions:       []string{"eks:DescribeFargateProfile", "eks:ListClusters", "eks:ListFargateProfiles"},
+	},
+	"AWS::IAM::AccountPasswordPolicy": {
+		ListDescriber: "IAMAccountPasswordPolicy",
+		GetDescriber:  "",
+		Actions:       []string{"iam:GetAccountPasswordPolicy", "sts:GetCallerIdentity"},
+	},
+	"AWS::CodePipeline::Pipeline": {
+		ListDescriber: "CodePipelinePipeline",
+		GetDescriber:  "",
+		Actions:       []string{"codepipeline:GetPipeline", "codepipeline:ListPipelines", "codepipeline:ListTagsForResource"},
+	},
+	"AWS::DAX::Cluster": {
+		ListDescriber: "DAXCluster",
+		GetDescriber:  "",
+		Actions:       []string{"dax:DescribeClusters", "dax:ListTags"},
+	},
+	"AWS::DLM::LifecyclePolicy": {
+		ListDescriber: "DLMLifecyclePolicy",
+		GetDescriber:  "",
+		Actions:       []string{"dlm:GetLifecyclePolicies", "dlm:GetLifecyclePolicy"},
+	},
+	"AWS::OpsWorksCM::Server": {
+		ListDescriber: "OpsWorksCMServer",
+		GetDescriber:  "",
+		Actions:       []string{"opsworks-cm:DescribeServers", "opsworks-cm:ListTagsForResource"},
+	},
+	"AWS::AccessAnalyzer::Analyzer": {
+		ListDescriber: "AccessAnalyzerAnalyzer",
+		GetDescriber:  "GetAccessAnalyzerAnalyzer",
+		Actions:       []string{"access-analyzer:GetAnalyzer", "access-analyzer:ListAnalyzers", "access-analyzer:ListFindings"},
+	},
+	"AWS::AccessAnalyzer::Finding": {
+		ListDescriber: "AccessAnalyzerAnalyzerFinding",
+		GetDescriber:  "",
+		Actions:       []string{"access-analyzer:ListAnalyzers", "access-analyzer:ListFindings"},
+	},
+	"AWS::ElastiCache::SubnetGroup": {
+		ListDescriber: "ElastiCacheSubnetGroup",
+		GetDescriber:  "",
+		Actions:       []string{"elasticache:DescribeCacheSubnetGroups"},
+	},
+	"AWS::FSX::Volume": {
+		ListDescriber: "FSXVolume",
+		GetDescriber:  "",
+		Actions:       []string{"fsx:DescribeVolumes"},
+	},
+	"AWS::Amplify::App": {
+		ListDescriber: "AmplifyApp",
+		GetDescriber:  "",
+		Actions:       []string{"amplify:ListApps"},
+	},
+	"AWS::CloudTrail::Query": {
+		ListDescriber: "CloudTrailQuery",
+		GetDescriber:  "",
+		Actions:       []string{"cloudtrail:DescribeQuery", "cloudtrail:ListEventDataStores", "cloudtrail:ListQueries"},
+	},
+	"AWS::ECR::PublicRegistry": {
+		ListDescriber: "ECRPublicRegistry",
+		GetDescriber:  "",
+		Actions:       []string{"ecr-public:DescribeRegistries", "ecr-public:ListTagsForResource"},
+	},
+	"AWS::EC2::NetworkInterface": {
+		ListDescriber: "EC2NetworkInterface",
+		GetDescriber:  "GetEC2NetworkInterface",
+		Actions:       []string{"ec2:DescribeNetworkInterfaces"},
+	},
+	"AWS::EC2::VPNConnection": {
+		ListDescriber: "EC2VPNConnection",
+		GetDescriber:  "GetEC2VPNConnection",
+		Actions:       []string{"ec2:DescribeVpnConnections"},
+	},
+	"AWS::FSX::StorageVirtualMachine": {
+		ListDescriber: "FSXStorageVirtualMachine",
+		GetDescriber:  "",
+		Actions:       []string{"fsx:DescribeStorageVirtualMachines"},
+	},
+	"AWS::ApiGateway::Authorizer": {
+		ListDescriber: "ApiGatewayAuthorizer",
+		GetDescriber:  "",
+		Actions:       []string{"apigateway:GET"},
+	},
+	"AWS::AppStream::Stack": {
+		ListDescriber: "AppStreamStack",
+		GetDescriber:  "",
+		Actions:       []string{"appstream:DescribeStacks", "appstream:ListTagsForResource"},
+	},
+	"AWS::Athena::WorkGroup": {
+		ListDescriber: "AthenaWrokgroup",
+		GetDescriber:  "",
+		Actions:       []string{"athena:GetWorkGroup", "athena:ListWorkGroups"},
+	},
+	"AWS::Athena::QueryExecution": {
+		ListDescriber: "AthenaQueryExecution",
+		GetDescriber:  "",
+		Actions:       []string{"athena:GetQueryExecution", "athena:ListQueryExecutions"},
+	},
+	"AWS::AppStream::Image": {
+		ListDescriber: "AppStreamImage",
+		GetDescriber:  "GetAppStreamImage",
+		Actions:       []string{"appstream:DescribeImages", "appstream:ListTagsForResource"},
+	},
+	"AWS::CloudWatch::Alarm": {
+		ListDescriber: "CloudWatchAlarm",
+		GetDescriber:  "GetCloudWatchAlarm",
+		Actions:       []string{"cloudwatch:DescribeAlarms", "cloudwatch:ListTagsForResource"},
+	},
+	"AWS::CloudWatch::LogSubscriptionFilter": {
+		ListDescriber: "CloudWatchLogsSubscriptionFilter",
+		GetDescriber:  "",
+		Actions:       []string{"logs:DescribeLogGroups", "logs:DescribeSubscriptionFilters"},
+	},
+	"AWS::RDS::DBCluster": {
+		ListDescriber: "RDSDBCluster",
+		GetDescriber:  "GetRDSDBCluster",
+		Actions:       []string{"rds:DescribeDBClusters", "rds:DescribePendingMaintenanceActions"},
+	},
+	"AWS::RDS::DBClusterSnapshot": {
+		ListDescriber: "RDSDBClusterSnapshot",
+		GetDescriber:  "",
+		Actions:       []string{"rds:DescribeDBClusterSnapshotAttributes", "rds:DescribeDBClusterSnapshots"},
+	},
+	"AWS::Backup::Framework": {
+		ListDescriber: "BackupFramework",
+		GetDescriber:  "",
+		Actions:       []string{"backup:DescribeFramework", "backup:ListFrameworks", "backup:ListTags"},
+	},
+	"AWS::CodeBuild::SourceCredential": {
+		ListDescriber: "CodeBuildSourceCredential",
+		GetDescriber:  "",
+		Actions:       []string{"codebuild:ListSourceCredentials"},
+	},
+	"AWS::IAM::ServiceSpecificCredential": {
+		ListDescriber: "IAMServiceSpecificCredential",
+		GetDescriber:  "",
+		Actions:       []string{"iam:ListServiceSpecificCredentials", "iam:ListUsers"},
+	},
+	"AWS::EC2::CapacityReservationFleet": {
+		ListDescriber: "EC2CapacityReservationFleet",
+		GetDescriber:  "GetEC2CapacityReservationFleet",
+		Actions:       []string{"ec2:DescribeCapacityReservationFleets"},
+	},
+	"AWS::NetworkFirewall::Firewall": {
+		ListDescriber: "NetworkFirewallFirewall",
+		GetDescriber:  "",
+		Actions:       []string{"network-firewall:DescribeFirewall", "network-firewall:DescribeLoggingConfiguration", "network-firewall:ListFirewalls"},
+	},
+	"AWS::Workspaces::Workspace": {
+		ListDescriber: "WorkspacesWorkspace",
+		GetDescriber:  "",
+		Actions:       []string{"workspaces:DescribeTags", "workspaces:DescribeWorkspaces"},
+	},
+	"AWS::ElasticSearch::Domain": {
+		ListDescriber: "ESDomain",
+		GetDescriber:  "",
+		Actions:       []string{"es:DescribeElasticsearchDomains", "es:ListDomainNames", "es:ListTags"},
+	},
+	"AWS::RDS::DBInstance": {
+		ListDescriber: "RDSDBInstance",
+		GetDescriber:  "GetRDSDBInstance",
+		Actions:       []string{"rds:DescribeCertificates", "rds:DescribeDBInstances", "rds:DescribePendingMaintenanceActions"},
+	},
+	"AWS::RDS::DBInstanceAutomatedBackup": {
+		ListDescriber: "RDSDBInstanceAutomatedBackup",
+		GetDescriber:  "GetRDSDBInstanceAutomatedBackup",
+		Actions:       []string{"rds:DescribeDBInstanceAutomatedBackups"},
+	},
+	"AWS::EFS::MountTarget": {
+		ListDescriber: "EFSMountTarget",
+		GetDescriber:  "",
+		Actions:       []string{"elasticfilesystem:DescribeFileSystemPolicy", "elasticfilesystem:DescribeFileSystems", "elasticfilesystem:DescribeMountTargetSecurityGroups", "elasticfilesystem:DescribeMountTargets"},
+	},
+	"AWS::GlobalAccelerator::Listener": {
+		ListDescriber: "GlobalAcceleratorListener",
+		GetDescriber:  "",
+		Actions:       []string{"globalaccelerator:ListAccelerators", "globalaccelerator:ListListeners"},
+	},
+	"AWS::EKS::Addon": {
+		ListDescriber: "EKSAddon",
+		GetDescriber:  "",
+		Actions:       []string{"eks:DescribeAddon", "eks:ListAddons", "eks:ListClusters"},
+	},
+	"AWS::IAM::Policy": {
+		ListDescriber: "IAMPolicy",
+		GetDescriber:  "",
+		Actions:       []string{"iam:GetPolicyVersion", "iam:ListPolicies"},
+	},
+	"AWS::Redshift::Cluster": {
+		ListDescriber: "RedshiftCluster",
+		GetDescriber:  "",
+		Actions:       []string{"redshift:DescribeClusters", "redshift:DescribeLoggingStatus", "redshift:DescribeScheduledActions"},
+	},
+	"AWS::WAFRegional::Rule": {
+		ListDescriber: "WAFRegionalRule",
+		GetDescriber:  "",
+		Actions:       []string{"waf-regional:GetRule", "waf-regional:ListRules", "waf-regional:ListTagsForResource"},
+	},
+	"AWS::WAFRegional::RuleGroup": {
+		ListDescriber: "WAFRegionalRuleGroup",
+		GetDescriber:  "",
+		Actions:       []string{"waf-regional:GetRuleGroup", "waf-regional:ListActivatedRulesInRuleGroup", "waf-regional:ListRuleGroups", "waf-regional:ListTagsForResource"},
+	},
+	"AWS::Glue::DataCatalogEncryptionSettings": {
+		ListDescriber: "GlueDataCatalogEncryptionSettings",
+		GetDescriber:  "",
+		Actions:       []string{"glue:GetDataCatalogEncryptionSettings"},
+	},
+	"AWS::EC2::FlowLog": {
+		ListDescriber: "EC2FlowLog",
+		GetDescriber:  "GetEC2FlowLog",
+		Actions:       []string{"ec2:DescribeFlowLogs"},
+	},
+	"AWS::EC2::IpamPool": {
+		ListDescriber: "EC2IpamPool",
+		GetDescriber:  "GetEC2IpamPool",
+		Actions:       []string{"ec2:DescribeIpamPools"},
+	},
+	"AWS::IAM::SamlProvider": {
+		ListDescriber: "IAMSamlProvider",
+		GetDescriber:  "",
+		Actions:       []string{"iam:GetSAMLProvider", "iam:ListSAMLProviders"},
+	},
+	"AWS::Route53::HostedZone": {
+		ListDescriber: "Route53HostedZone",
+		GetDescriber:  "",
+		Actions:       []string{"route53:GetDNSSEC", "route53:GetHostedZoneLimit", "route53:ListHostedZones", "route53:ListQueryLoggingConfigs", "route53:ListTagsForResource"},
+	},
+	"AWS::Route53::QueryLog": {
+		ListDescriber: "Route53QueryLog",
+		GetDescriber:  "",
+		Actions:       []string{"route53:ListQueryLoggingConfigs"},
+	},
+	"AWS::EC2::PlacementGroup": {
+		ListDescriber: "EC2PlacementGroup",
+		GetDescriber:  "GetEC2PlacementGroup",
+		Actions:       []string{"ec2:DescribePlacementGroups"},
+	},
+	"AWS::FSX::Snapshot": {
+		ListDescriber: "FSXSnapshot",
+		GetDescriber:  "",
+		Actions:       []string{"fsx:DescribeSnapshots"},
+	},
+	"AWS::KMS::Key": {
+		ListDescriber: "KMSKey",
+		GetDescriber:  "GetKMSKey",
+		Actions:       []string{"kms:DescribeKey", "kms:GetKeyPolicy", "kms:GetKeyRotationStatus", "kms:ListAliases", "kms:ListKeys", "kms:ListResourceTags"},
+	},
+	"AWS::KMS::KeyRotation": {
+		ListDescriber: "KMSKeyRotation",
+		GetDescriber:  "",
+		Actions:       []string{"kms:ListKeyRotations", "kms:ListKeys"},
+	},
+	"AWS::EC2::Ipam": {
+		ListDescriber: "EC2Ipam",
+		GetDescriber:  "GetEC2Ipam",
+		Actions:       []string{"ec2:DescribeIpams"},
+	},
+	"AWS::ElasticBeanstalk::Environment": {
+		ListDescriber: "ElasticBeanstalkEnvironment",
+		GetDescriber:  "",
+		Actions:       []string{"elasticbeanstalk:DescribeConfigurationSettings", "elasticbeanstalk:DescribeEnvironmentManagedActions", "elasticbeanstalk:DescribeEnvironments", "elasticbeanstalk:ListTagsForResource"},
+	},
+	"AWS::ElasticBeanstalk::ApplicationVersion": {
+		ListDescriber: "ElasticBeanstalkApplicationVersion",
+		GetDescriber:  "",
+		Actions:       []string{"elasticbeanstalk:DescribeApplicationVersions", "elasticbeanstalk:DescribeApplications", "elasticbeanstalk:ListTagsForResource"},
+	},
+	"AWS::Lambda::FunctionVersion": {
+		ListDescriber: "LambdaFunctionVersion",
+		GetDescriber:  "",
+		Actions:       []string{"lambda:GetPolicy", "lambda:ListFunctions"},
+	},
+	"AWS::Glue::DevEndpoint": {
+		ListDescriber: "GlueDevEndpoint",
+		GetDescriber:  "",
+		Actions:       []string{"glue:GetDevEndpoints"},
+	},
+	"AWS::Backup::RecoveryPoint": {
+		ListDescriber: "BackupRecoveryPoint",
+		GetDescriber:  "",
+		Actions:       []string{"backup:DescribeRecoveryPoint", "backup:ListBackupVaults", "backup:ListRecoveryPointsByBackupVault", "backup:ListTags"},
+	},
+	"AWS::Backup::ReportPlan": {
+		ListDescriber: "BackupReportPlan",
+		GetDescriber:  "GetBackupReportPlan",
+		Actions:       []string{"backup:DescribeReportPlan", "backup:ListReportPlans", "backup:ListTags"},
+	},
+	"AWS::Backup::RegionSetting": {
+		ListDescriber: "BackupRegionSetting",
+		GetDescriber:  "",
+		Actions:       []string{"backup:DescribeRegionSettings"},
+	},
+	"AWS::DynamoDbStreams::Stream": {
+		ListDescriber: "DynamoDbStream",
+		GetDescriber:  "",
+		Actions:       []string{"dynamodb:ListStreams"},
+	},
+	"AWS::EC2::EgressOnlyInternetGateway": {
+		ListDescriber: "EC2EgressOnlyInternetGateway",
+		GetDescriber:  "GetEC2EgressOnlyInternetGateway",
+		Actions:       []string{"ec2:DescribeEgressOnlyInternetGateways"},
+	},
+	"AWS::CloudFront::Distribution": {
+		ListDescriber: "CloudFrontDistribution",
+		GetDescriber:  "",
+		Actions:       []string{"cloudfront:GetDistribution", "cloudfront:ListDistributions", "cloudfront:ListTagsForResource"},
+	},
+	"AWS::CloudFront::StreamingDistribution": {
+		ListDescriber: "CloudFrontStreamingDistribution",
+		GetDescriber:  "",
+		Actions:       []string{"cloudfront:GetStreamingDistribution", "cloudfront:ListStreamingDistributions", "cloudfront:ListTagsForResource"},
+	},
+	"AWS::Glue::Job": {
+		ListDescriber: "GlueJob",
+		GetDescriber:  "GetGlueJob",
+		Actions:       []string{"glue:GetJob", "glue:GetJobBookmark", "glue:GetJobs"},
+	},
+	"AWS::AppStream::Fleet": {
+		ListDescriber: "AppStreamFleet",
+		GetDescriber:  "",
+		Actions:       []string{"appstream:DescribeFleets", "appstream:ListTagsForResource"},
+	},
+	"AWS::SES::ConfigurationSet": {
+		ListDescriber: "SESConfigurationSet",
+		GetDescriber:  "",
+		Actions:       []string{"ses:DescribeConfigurationSet", "ses:ListConfigurationSets"},
+	},
+	"AWS::IAM::User": {
+		ListDescriber: "IAMUser",
+		GetDescriber:  "",
+		Actions:       []string{"iam:GetLoginProfile", "iam:GetUserPolicy", "iam:ListAttachedUserPolicies", "iam:ListGroupsForUser", "iam:ListMFADevices", "iam:ListUserPolicies", "iam:ListUsers"},
+	},
+	"AWS::CloudFront::OriginRequestPolicy": {
+		ListDescriber: "CloudFrontOriginRequestPolicy",
+		GetDescriber:  "",
+		Actions:       []string{"cloudfront:GetOriginRequestPolicy", "cloudfront:ListOriginRequestPolicies"},
+	},
+	"AWS::EC2::SecurityGroup": {
+		ListDescriber: "EC2SecurityGroup",
+		GetDescriber:  "GetEC2SecurityGroup",
+		Actions:       []string{"ec2:DescribeSecurityGroups"},
+	},
+	"AWS::GuardDuty::IPSet": {
+		ListDescriber: "GuardDutyIPSet",
+		GetDescriber:  "",
+		Actions:       []string{"guardduty:GetIPSet", "guardduty:ListDetectors", "guardduty:ListIPSets"},
+	},
+	"AWS::EKS::Cluster": {
+		ListDescriber: "EKSCluster",
+		GetDescriber:  "",
+		Actions:       []string{"eks:DescribeCluster", "eks:ListClusters"},
+	},
+	"AWS::Grafana::Workspace": {
+		ListDescriber: "GrafanaWorkspace",
+		GetDescriber:  "",
+		Actions:       []string{"grafana:ListWorkspaces"},
+	},
+	"AWS::Glue::CatalogDatabase": {
+		ListDescriber: "GlueCatalogDatabase",
+		GetDescriber:  "",
+		Actions:       []string{"glue:GetDatabases"},
+	},
+	"AWS::Health::Event": {
+		ListDescriber: "HealthEvent",
+		GetDescriber:  "",
+		Actions:       []string{"health:DescribeEvents"},
+	},
+	"AWS::Health::AffectedEntity": {
+		ListDescriber: "HealthAffectedEntity",
+		GetDescriber:  "",
+		Actions:       []string{"health:DescribeAffectedEntities", "health:DescribeEvents"},
+	},
+	"AWS::CloudFormation::StackSet": {
+		ListDescriber: "CloudFormationStackSet",
+		GetDescriber:  "",
+		Actions:       []string{"cloudformation:DescribeStackSet", "cloudformation:ListStackSets"},
+	},
+	"AWS::EC2::AvailabilityZone": {
+		ListDescriber: "EC2AvailabilityZone",
+		GetDescriber:  "GetEC2AvailabilityZone",
+		Actions:       []string{"ec2:DescribeAvailabilityZones", "ec2:DescribeRegions"},
+	},
+	"AWS::EC2::TransitGateway": {
+		ListDescriber: "EC2TransitGateway",
+		GetDescriber:  "GetEC2TransitGateway",
+		Actions:       []string{"ec2:DescribeTransitGateways"},
+	},
+	"AWS::ApiGateway::UsagePlan": {
+		ListDescriber: "ApiGatewayUsagePlan",
+		GetDescriber:  "",
+		Actions:       []string{"apigateway:GET"},
+	},
+	"AWS::Inspector::Finding": {
+		ListDescriber: "InspectorFinding",
+		GetDescriber:  "",
+		Actions:       []string{"inspector:DescribeFindings", "inspector:ListFindings"},
+	},
+	"AWS::EC2::Fleet": {
+		ListDescriber: "EC2Fleet",
+		GetDescriber:  "GetEC2Fleet",
+		Actions:       []string{"ec2:DescribeFleets"},
+	},
+	"AWS::ElasticBeanstalk::Application": {
+		ListDescriber: "ElasticBeanstalkApplication",
+		GetDescriber:  "",
+		Actions:       []string{"elasticbeanstalk:DescribeApplications", "elasticbeanstalk:ListTagsForResource"},
+	},
+	"AWS::ElasticLoadBalancingV2::LoadBalancer": {
+		ListDescriber: "ElasticLoadBalancingV2LoadBalancer",
+		GetDescriber:  "GetElasticLoadBalancingV2LoadBalancer",
+		Actions:       []string{"elasticloadbalancing:DescribeLoadBalancerAttributes", "elasticloadbalancing:DescribeLoadBalancers", "elasticloadbalancing:DescribeTags"},
+	},
+	"AWS::OpenSearch::Domain": {
+		ListDescriber: "OpenSearchDomain",
+		GetDescriber:  "",
+		Actions:       []string{"es:DescribeDomains", "es:ListDomainNames", "es:ListTags"},
+	},
+	"AWS::RDS::DBEventSubscription": {
+		ListDescriber: "RDSDBEventSubscription",
+		GetDescriber:  "",
+		Actions:       []string{"rds:DescribeEventSubscriptions"},
+	},
+	"AWS::RDS::DBEngineVersion": {
+		ListDescriber: "RDSDBEngineVersion",
+		GetDescriber:  "",
+		Actions:       []string{"rds:DescribeDBEngineVersions"},
+	},
+	"AWS::EC2::RegionalSettings": {
+		ListDescriber: "EC2RegionalSettings",
+		GetDescriber:  "",
+		Actions:       []string{"ec2:GetEbsDefaultKmsKeyId", "ec2:GetEbsEncryptionByDefault", "ec2:GetSnapshotBlockPublicAccessState"},
+	},
+	"AWS::EC2::SecurityGroupRule": {
+		ListDescriber: "EC2SecurityGroupRule",
+		GetDescriber:  "",
+		Actions:       []string{"ec2:DescribeSecurityGroups"},
+	},
+	"AWS::EC2::TransitGatewayAttachment": {
+		ListDescriber: "EC2TransitGatewayAttachment",
+		GetDescriber:  "GetEC2TransitGatewayAttachment",
+		Actions:       []string{"ec2:DescribeTransitGatewayAttachments"},
+	},
+	"AWS::SES::Identity": {
+		ListDescriber: "SESIdentity",
+		GetDescriber:  "",
+		Actions:       []string{"ses:GetIdentityDkimAttributes", "ses:GetIdentityMailFromDomainAttributes", "ses:GetIdentityNotificationAttributes", "ses:GetIdentityVerificationAttributes", "ses:ListIdentities"},
+	},
+	"AWS::SESv2::EmailIdentities": {
+		ListDescriber: "SESv2EmailIdentities",
+		GetDescriber:  "",
+		Actions:       []string{"ses:ListEmailIdentities", "ses:ListTagsForResource"},
+	},
+	"AWS::WAF::Rule": {
+		ListDescriber: "WAFRule",
+		GetDescriber:  "",
+		Actions:       []string{"waf:GetRule", "waf:ListRules", "waf:ListTagsForResource"},
+	},
+	"AWS::WAF::RuleGroup": {
+		ListDescriber: "WAFRuleGroup",
+		GetDescriber:  "",
+		Actions:       []string{"waf:GetRuleGroup", "waf:ListActivatedRulesInRuleGroup", "waf:ListRuleGroups", "waf:ListTagsForResource"},
+	},
+	"AWS::WAF::RateBasedRule": {
+		ListDescriber: "WAFRateBasedRule",
+		GetDescriber:  "",
+		Actions:       []string{"waf:GetRateBasedRule", "waf:ListRateBasedRules", "waf:ListTagsForResource"},
+	},
+	"AWS::WAF::WebACL": {
+		ListDescriber: "WAFWebACL",
+		GetDescriber:  "",
+		Actions:       []string{"waf:GetLoggingConfiguration", "waf:GetWebACL", "waf:ListTagsForResource", "waf:ListWebACLs"},
+	},
+	"AWS::WAFRegional::WebACL": {
+		ListDescriber: "WAFRegionalWebACL",
+		GetDescriber:  "",
+		Actions:       []string{"waf-regional:GetLoggingConfiguration", "waf-regional:GetWebACL", "waf-regional:ListResourcesForWebACL", "waf-regional:ListTagsForResource", "waf-regional:ListWebACLs"},
+	},
+	"AWS::WellArchitected::Workload": {
+		ListDescriber: "WellArchitectedWorkload",
+		GetDescriber:  "",
+		Actions:       []string{"wellarchitected:GetWorkload", "wellarchitected:ListWorkloads"},
+	},
+	"AWS::WellArchitected::Answer": {
+		ListDescriber: "WellArchitectedAnswer",
+		GetDescriber:  "",
+		Actions:       []string{"wellarchitected:GetAnswer", "wellarchitected:ListAnswers", "wellarchitected:ListWorkloads"},
+	},
+	"AWS::WellArchitected::CheckDetail": {
+		ListDescriber: "WellArchitectedCheckDetail",
+		GetDescriber:  "",
+		Actions:       []string{"wellarchitected:GetAnswer", "wellarchitected:ListAnswers", "wellarchitected:ListCheckDetails", "wellarchitected:ListWorkloads"},
+	},
+	"AWS::WellArchitected::CheckSummary": {
+		ListDescriber: "WellArchitectedCheckSummary",
+		GetDescriber:  "",
+		Actions:       []string{"wellarchitected:GetAnswer", "wellarchitected:ListAnswers", "wellarchitected:ListCheckSummaries", "wellarchitected:ListWorkloads"},
+	},
+	"AWS::WellArchitected::ConsolidatedReport": {
+		ListDescriber: "WellArchitectedConsolidatedReport",
+		GetDescriber:  "",
+		Actions:       []string{"wellarchitected:GetConsolidatedReport"},
+	},
+	"AWS::WellArchitected::Lens": {
+		ListDescriber: "WellArchitectedLens",
+		GetDescriber:  "",
+		Actions:       []string{"wellarchitected:GetLens", "wellarchitected:ListLenses"},
+	},
+	"AWS::WellArchitected::LensReview": {
+		ListDescriber: "WellArchitectedLensReview",
+		GetDescriber:  "",
+		Actions:       []string{"wellarchitected:GetLensReview", "wellarchitected:ListLensReviews", "wellarchitected:ListWorkloads"},
+	},
+	"AWS::WellArchitected::LensReviewImprovement": {
+		ListDescriber: "WellArchitectedLensReviewImprovement",
+		GetDescriber:  "",
+		Actions:       []string{"wellarchitected:ListLensReviewImprovements", "wellarchitected:ListWorkloads"},
+	},
+	"AWS::WellArchitected::LensReviewReport": {
+		ListDescriber: "WellArchitectedLensReviewReport",
+		GetDescriber:  "",
+		Actions:       []string{"wellarchitected:GetLensReviewReport", "wellarchitected:ListWorkloads"},
+	},
+	"AWS::WellArchitected::LensShare": {
+		ListDescriber: "WellArchitectedLensShare",
+		GetDescriber:  "",
+		Actions:       []string{"wellarchitected:GetLens", "wellarchitected:ListLensShares", "wellarchitected:ListLenses"},
+	},
+	"AWS::WellArchitected::Milestone": {
+		ListDescriber: "WellArchitectedMilestone",
+		GetDescriber:  "",
+		Actions:       []string{"wellarchitected:GetMilestone", "wellarchitected:ListMilestones", "wellarchitected:ListWorkloads"},
+	},
+	"AWS::WellArchitected::Notification": {
+		ListDescriber: "WellArchitectedNotification",
+		GetDescriber:  "",
+		Actions:       []string{"wellarchitected:ListNotifications"},
+	},
+	"AWS::WellArchitected::ShareInvitation": {
+		ListDescriber: "WellArchitectedShareInvitation",
+		GetDescriber:  "",
+		Actions:       []string{"wellarchitected:ListShareInvitations"},
+	},
+	"AWS::WellArchitected::WorkloadShare": {
+		ListDescriber: "WellArchitectedWorkloadShare",
+		GetDescriber:  "",
+		Actions:       []string{"wellarchitected:ListWorkloadShares", "wellarchitected:ListWorkloads"},
+	},
+	"AWS::AutoScaling::LaunchConfiguration": {
+		ListDescriber: "AutoScalingLaunchConfiguration",
+		GetDescriber:  "GetAutoScalingLaunchConfiguration",
+		Actions:       []string{"autoscaling:DescribeLaunchConfigurations"},
+	},
+	"AWS::CloudTrail::EventDataStore": {
+		ListDescriber: "CloudTrailEventDataStore",
+		GetDescriber:  "",
+		Actions:       []string{"cloudtrail:GetEventDataStore", "cloudtrail:ListEventDataStores"},
+	},
+	"AWS::CodeDeploy::DeploymentGroup": {
+		ListDescriber: "CodeDeployDeploymentGroup",
+		GetDescriber:  "",
+		Actions:       []string{"codedeploy:GetDeploymentGroup", "codedeploy:ListApplications", "codedeploy:ListDeploymentGroups", "codedeploy:ListTagsForResource"},
+	},
+	"AWS::ImageBuilder::Image": {
+		ListDescriber: "ImageBuilderImage",
+		GetDescriber:  "",
+		Actions:       []string{"imagebuilder:GetImage", "imagebuilder:ListImageBuildVersions", "imagebuilder:ListImages"},
+	},
+	"AWS::Redshift::ClusterParameterGroup": {
+		ListDescriber: "RedshiftClusterParameterGroup",
+		GetDescriber:  "",
+		Actions:       []string{"redshift:DescribeClusterParameterGroups", "redshift:DescribeClusterParameters"},
+	},
+	"AWS::Account::AlternateContact": {
+		ListDescriber: "AccountAlternateContact",
+		GetDescriber:  "",
+		Actions:       []string{"account:GetAlternateContact"},
+	},
+	"AWS::Inspector::AssessmentTarget": {
+		ListDescriber: "InspectorAssessmentTarget",
+		GetDescriber:  "",
+		Actions:       []string{"inspector:DescribeAssessmentTargets", "inspector:ListAssessmentTargets"},
+	},
+	"AWS::CloudFront::ResponseHeadersPolicy": {
+		ListDescriber: "CloudFrontResponseHeadersPolicy",
+		GetDescriber:  "",
+		Actions:       []string{"cloudfront:GetResponseHeadersPolicy", "cloudfront:ListResponseHeadersPolicies"},
+	},
+	"AWS::EC2::Instance": {
+		ListDescriber: "EC2Instance",
+		GetDescriber:  "GetEC2Instance",
+		Actions:       []string{"ec2:DescribeInstanceAttribute", "ec2:DescribeInstanceStatus", "ec2:DescribeInstances", "ec2:GetLaunchTemplateData"},
+	},
+	"AWS::EC2::InstanceMetricCpuUtilizationHourly": {
+		ListDescriber: "Ec2InstanceMetricCpuUtilizationHourly",
+		GetDescriber:  "",
+		Actions:       []string{"cloudwatch:GetMetricStatistics", "ec2:DescribeInstances"},
+	},
+	"AWS::EC2::ReservedInstances": {
+		ListDescriber: "EC2ReservedInstances",
+		GetDescriber:  "GetEC2ReservedInstances",
+		Actions:       []string{"ec2:DescribeReservedInstances", "ec2:DescribeReservedInstancesModifications"},
+	},
+	"AWS::ECR::Repository": {
+		ListDescriber: "ECRRepository",
+		GetDescriber:  "",
+		Actions:       []string{"ecr:BatchGetRepositoryScanningConfiguration", "ecr:DescribeImages", "ecr:DescribeRepositories", "ecr:GetLifecyclePolicy", "ecr:GetRepositoryPolicy", "ecr:ListTagsForResource"},
+	},
+	"AWS::ECR::Registry": {
+		ListDescriber: "ECRRegistry",
+		GetDescriber:  "",
+		Actions:       []string{"ecr:DescribeRegistry"},
+	},
+	"AWS::ECR::RegistryScanningConfiguration": {
+		ListDescriber: "ECRRegistryScanningConfiguration",
+		GetDescriber:  "",
+		Actions:       []string{"ecr:GetRegistryScanningConfiguration"},
+	},
+	"AWS::ElasticLoadBalancingV2::Listener": {
+		ListDescriber: "ElasticLoadBalancingV2Listener",
+		GetDescriber:  "GetElasticLoadBalancingV2Listener",
+		Actions:       []string{"elasticloadbalancing:DescribeListeners", "elasticloadbalancing:DescribeLoadBalancerAttributes", "elasticloadbalancing:DescribeLoadBalancers", "elasticloadbalancing:DescribeTags"},
+	},
+	"AWS::IAM::Group": {
+		ListDescriber: "IAMGroup",
+		GetDescriber:  "",
+		Actions:       []string{"iam:GetGroup", "iam:GetGroupPolicy", "iam:ListAttachedGroupPolicies", "iam:ListGroupPolicies", "iam:ListGroups"},
+	},
+	"AWS::IAM::OpenIdConnectProvider": {
+		ListDescriber: "IAMOpenIdConnectProvider",
+		GetDescriber:  "GetIAMOpenIdConnectProvider",
+		Actions:       []string{"iam:GetOpenIDConnectProvider", "iam:ListOpenIDConnectProviders"},
+	},
+	"AWS::Backup::Plan": {
+		ListDescriber: "BackupPlan",
+		GetDescriber:  "",
+		Actions:       []string{"backup:GetBackupPlan", "backup:ListBackupPlans"},
+	},
+	"AWS::Config::ConformancePack": {
+		ListDescriber: "ConfigConformancePack",
+		GetDescriber:  "",
+		Actions:       []string{"config:DescribeConformancePacks"},
+	},
+	"AWS::Config::RetentionConfiguration": {
+		ListDescriber: "ConfigRetentionConfiguration",
+		GetDescriber:  "",
+		Actions:       []string{"config:DescribeRetentionConfigurations"},
+	},
+	"AWS::Account::Contact": {
+		ListDescriber: "AccountContact",
+		GetDescriber:  "",
+		Actions:       []string{"account:GetContactInformation"},
+	},
+	"AWS::Glue::DataQualityRuleset": {
+		ListDescriber: "GlueDataQualityRuleset",
+		GetDescriber:  "",
+		Actions:       []string{"glue:GetDataQualityRuleset", "glue:ListDataQualityRulesets"},
+	},
+	"AWS::EventBridge::EventBus": {
+		ListDescriber: "EventBridgeBus",
+		GetDescriber:  "",
+		Actions:       []string{"events:ListEventBuses", "events:ListTagsForResource"},
+	},
+	"AWS::ApiGateway::Stage": {
+		ListDescriber: "ApiGatewayStage",
+		GetDescriber:  "GetApiGatewayStage",
+		Actions:       []string{"apigateway:GET"},
+	},
+	"AWS::ApiGatewayV2::Stage": {
+		ListDescriber: "ApiGatewayV2Stage",
+		GetDescriber:  "",
+		Actions:       []string{"apigateway:GET"},
+	},
+	"AWS::DynamoDb::LocalSecondaryIndex": {
+		ListDescriber: "DynamoDbLocalSecondaryIndex",
+		GetDescriber:  "",
+		Actions:       []string{"dynamodb:DescribeTable", "dynamodb:ListTables"},
+	},
+	"AWS::ResourceGroups::Groups": {
+		ListDescriber: "ResourceGroups",
+		GetDescriber:  "",
+		Actions:       []string{"resource-groups:GetTags", "resource-groups:ListGroupResources", "resource-groups:ListGroups"},
+	},
+	"AWS::Timestream::Database": {
+		ListDescriber: "TimestreamDatabase",
+		GetDescriber:  "",
+		Actions:       []string{"timestream:ListDatabases", "timestream:ListTagsForResource"},
+	},
+	"AWS::OpenSearchServerless::Collection": {
+		ListDescriber: "OpenSearchServerlessCollection",
+		GetDescriber:  "",
+		Actions:       []string{"aoss:BatchGetCollection", "aoss:ListCollections", "aoss:ListTagsForResource"},
+	},
+	"AWS::EC2::ElasticIP": {
+		ListDescriber: "EC2ElasticIP",
+		GetDescriber:  "",
+		Actions:       []string{"ec2:DescribeAddresses"},
+	},
+	"AWS::EC2::LocalGateway": {
+		ListDescriber: "EC2LocalGateway",
+		GetDescriber:  "",
+		Actions:       []string{"ec2:DescribeLocalGateways"},
+	},
+	"AWS::EC2::Image": {
+		ListDescriber: "EC2AMI",
+		GetDescriber:  "GetEC2AMI",
+		Actions:       []string{"ec2:DescribeImageAttribute", "ec2:DescribeImages"},
+	},
+	"AWS::EC2::Subnet": {
+		ListDescriber: "EC2Subnet",
+		GetDescriber:  "GetEC2Subnet",
+		Actions:       []string{"ec2:DescribeSubnets"},
+	},
+	"AWS::ECS::TaskSet": {
+		ListDescriber: "ECSTaskSet",
+		GetDescriber:  "",
+		Actions:       []string{"ecs:DescribeServices", "ecs:ListClusters", "ecs:ListServices"},
+	},
+	"AWS::Kinesis::Stream": {
+		ListDescriber: "KinesisStream",
+		GetDescriber:  "",
+		Actions:       []string{"kinesis:DescribeStream", "kinesis:DescribeStreamSummary", "kinesis:ListStreams", "kinesis:ListTagsForStream"},
+	},
+	"AWS::Kinesis::Consumer": {
+		ListDescriber: "KinesisConsumer",
+		GetDescriber:  "",
+		Actions:       []string{"kinesis:DescribeStream", "kinesis:ListStreamConsumers", "kinesis:ListStreams"},
+	},
+	"AWS::DocDB::Cluster": {
+		ListDescriber: "DocDBCluster",
+		GetDescriber:  "GetDocDBCluster",
+		Actions:       []string{"rds:DescribeDBClusters", "rds:ListTagsForResource"},
+	},
+	"AWS::DocDB::ClusterSnapshot": {
+		ListDescriber: "DocDBClusterSnapshot",
+		GetDescriber:  "",
+		Actions:       []string{"rds:DescribeDBClusterSnapshotAttributes", "rds:DescribeDBClusterSnapshots", "rds:DescribeDBClusters", "rds:ListTagsForResource"},
+	},
+	"AWS::DocDB::ClusterInstance": {
+		ListDescriber: "DocDBClusterInstance",
+		GetDescriber:  "GetDocDBClusterInstance",
+		Actions:       []string{"rds:DescribeDBInstances", "rds:ListTagsForResource"},
+	},
+	"AWS::ElastiCache::ReplicationGroup": {
+		ListDescriber: "ElastiCacheReplicationGroup",
+		GetDescriber:  "",
+		Actions:       []string{"elasticache:DescribeReplicationGroups"},
+	},
+	"AWS::GlobalAccelerator::Accelerator": {
+		ListDescriber: "GlobalAcceleratorAccelerator",
+		GetDescriber:  "",
+		Actions:       []string{"globalaccelerator:DescribeAcceleratorAttributes", "globalaccelerator:ListAccelerators", "globalaccelerator:ListTagsForResource"},
+	},
+	"AWS::EMR::InstanceGroup": {
+		ListDescriber: "EMRInstanceGroup",
+		GetDescriber:  "",
+		Actions:       []string{"elasticmapreduce:ListClusters", "elasticmapreduce:ListInstanceGroups"},
+	},
+	"AWS::EC2::ManagedPrefixList": {
+		ListDescriber: "EC2ManagedPrefixList",
+		GetDescriber:  "GetEC2ManagedPrefixList",
+		Actions:       []string{"ec2:DescribeManagedPrefixLists"},
+	},
+	"AWS::EC2::ClientVpnEndpoint": {
+		ListDescriber: "EC2ClientVpnEndpoint",
+		GetDescriber:  "",
+		Actions:       []string{"ec2:DescribeClientVpnEndpoints"},
+	},
+	"AWS::MWAA::Environment": {
+		ListDescriber: "MWAAEnvironment",
+		GetDescriber:  "",
+		Actions:       []string{"airflow:GetEnvironment", "airflow:ListEnvironments"},
+	},
+	"AWS::CloudWatch::LogResourcePolicy": {
+		ListDescriber: "CloudWatchLogsResourcePolicy",
+		GetDescriber:  "",
+		Actions:       []string{"logs:DescribeResourcePolicies"},
+	},
+	"AWS::CodeArtifact::Domain": {
+		ListDescriber: "CodeArtifactDomain",
+		GetDescriber:  "",
+		Actions:       []string{"codeartifact:DescribeDomain", "codeartifact:GetDomainPermissionsPolicy", "codeartifact:ListDomains", "codeartifact:ListTagsForResource"},
+	},
+	"AWS::CodeStar::Project": {
+		ListDescriber: "CodeStarProject",
+		GetDescriber:  "",
+		Actions:       []string{"codestar:DescribeProject", "codestar:ListProjects", "codestar:ListTagsForProject"},
+	},
+	"AWS::Neptune::Database": {
+		ListDescriber: "NeptuneDatabase",
+		GetDescriber:  "",
+		Actions:       []string{"rds:DescribeDBInstances", "rds:ListTagsForResource"},
+	},
+	"AWS::Neptune::DBCluster": {
+		ListDescriber: "NeptuneDatabaseCluster",
+		GetDescriber:  "",
+		Actions:       []string{"rds:DescribeDBClusters", "rds:ListTagsForResource"},
+	},
+	"AWS::Neptune::DBClusterSnapshot": {
+		ListDescriber: "NeptuneDatabaseClusterSnapshot",
+		GetDescriber:  "",
+		Actions:       []string{"rds:DescribeDBClusterSnapshotAttributes", "rds:DescribeDBClusterSnapshots", "rds:DescribeDBClusters"},
+	},
+	"AWS::NetworkFirewall::FirewallPolicy": {
+		ListDescriber: "NetworkFirewallPolicy",
+		GetDescriber:  "",
+		Actions:       []string{"network-firewall:DescribeFirewallPolicy", "network-firewall:ListFirewallPolicies"},
+	},
+	"AWS::NetworkFirewall::RuleGroup": {
+		ListDescriber: "NetworkFirewallRuleGroup",
+		GetDescriber:  "",
+		Actions:       []string{"network-firewall:DescribeRuleGroup", "network-firewall:ListRuleGroups"},
+	},
+	"AWS::Oam::Link": {
+		ListDescriber: "OAMLink",
+		GetDescriber:  "",
+		Actions:       []string{"oam:GetLink", "oam:ListLinks"},
+	},
+	"AWS::Oam::Sink": {
+		ListDescriber: "OAMSink",
+		GetDescriber:  "",
+		Actions:       []string{"oam:ListSinks", "oam:ListTagsForResource"},
+	},
+	"AWS::Organizations::Account": {
+		ListDescriber: "OrganizationsAccount",
+		GetDescriber:  "",
+		Actions:       []string{"organizations:DescribeOrganization", "organizations:ListAccounts", "organizations:ListParents", "organizations:ListTagsForResource", "sts:GetCallerIdentity"},
+	},
+	"AWS::Pinpoint::App": {
+		ListDescriber: "PinpointApp",
+		GetDescriber:  "",
+		Actions:       []string{"mobiletargeting:GetApplicationSettings", "mobiletargeting:GetApps"},
+	},
+	"AWS::Pipes::Pipe": {
+		ListDescriber: "PipesPipe",
+		GetDescriber:  "",
+		Actions:       []string{"pipes:DescribePipe", "pipes:ListPipes"},
+	},
+	"AWS::RDS::DBClusterParameterGroup": {
+		ListDescriber: "RDSDBClusterParameterGroup",
+		GetDescriber:  "",
+		Actions:       []string{"rds:DescribeDBClusterParameterGroups", "rds:DescribeDBClusterParameters", "rds:ListTagsForResource"},
+	},
+	"AWS::RDS::OptionGroup": {
+		ListDescriber: "RDSOptionGroup",
+		GetDescriber:  "",
+		Actions:       []string{"rds:DescribeOptionGroups", "rds:ListTagsForResource"},
+	},
+	"AWS::RDS::DBParameterGroup": {
+		ListDescriber: "RDSDBParameterGroup",
+		GetDescriber:  "",
+		Actions:       []string{"rds:DescribeDBParameterGroups", "rds:DescribeDBParameters", "rds:ListTagsForResource"},
+	},
+	"AWS::RDS::DBProxy": {
+		ListDescriber: "RDSDBProxy",
+		GetDescriber:  "",
+		Actions:       []string{"rds:DescribeDBProxies", "rds:ListTagsForResource"},
+	},
+	"AWS::RDS::DBSubnetGroup": {
+		ListDescriber: "RDSDBSubnetGroup",
+		GetDescriber:  "",
+		Actions:       []string{"rds:DescribeDBSubnetGroups", "rds:ListTagsForResource"},
+	},
+	"AWS::RDS::DBRecommendation": {
+		ListDescriber: "RDSDBRecommendation",
+		GetDescriber:  "",
+		Actions:       []string{"rds:DescribeDBRecommendations"},
+	},
+	"AWS::Redshift::EventSubscription": {
+		ListDescriber: "RedshiftEventSubscription",
+		GetDescriber:  "",
+		Actions:       []string{"redshift:DescribeEventSubscriptions"},
+	},
+	"AWS::RedshiftServerless::Workgroup": {
+		ListDescriber: "RedshiftServerlessWorkgroup",
+		GetDescriber:  "",
+		Actions:       []string{"redshift-serverless:ListTagsForResource", "redshift-serverless:ListWorkgroups"},
+	},
+	"AWS::ResourceExplorer2::Index": {
+		ListDescriber: "ResourceExplorerIndex",
+		GetDescriber:  "",
+		Actions:       []string{"resource-explorer-2:ListIndexes"},
+	},
+	"AWS::Route53::HealthCheck": {
+		ListDescriber: "Route53HealthCheck",
+		GetDescriber:  "",
+		Actions:       []string{"route53:GetHealthCheckStatus", "route53:ListHealthChecks", "route53:ListTagsForResource"},
+	},
+	"AWS::Route53Resolver::ResolverRule": {
+		ListDescriber: "Route53ResolverResolverRule",
+		GetDescriber:  "",
+		Actions:       []string{"route53resolver:ListResolverRuleAssociations", "route53resolver:ListResolverRules", "route53resolver:ListTagsForResource"},
+	},
+	"AWS::Route53Resolver::QueryLogConfig": {
+		ListDescriber: "Route53ResolverQueryLogConfig",
+		GetDescriber:  "GetRoute53ResolverQueryLogConfig",
+		Actions:       []string{"route53resolver:GetResolverQueryLogConfig", "route53resolver:ListResolverQueryLogConfigs"},
+	},
+	"AWS::SageMaker::App": {
+		ListDescriber: "SageMakerApp",
+		GetDescriber:  "",
+		Actions:       []string{"sagemaker:DescribeApp", "sagemaker:ListApps", "sagemaker:ListDomains"},
+	},
+	"AWS::SageMaker::Domain": {
+		ListDescriber: "SageMakerDomain",
+		GetDescriber:  "",
+		Actions:       []string{"sagemaker:DescribeDomain", "sagemaker:ListDomains", "sagemaker:ListTags"},
+	},
+	"AWS::StepFunctions::StateMachine": {
+		ListDescriber: "StepFunctionsStateMachine",
+		GetDescriber:  "",
+		Actions:       []string{"states:DescribeStateMachine", "states:ListStateMachines", "states:ListTagsForResource"},
+	},
+	"AWS::StepFunctions::StateMachineExecution": {
+		ListDescriber: "StepFunctionsStateMachineExecution",
+		GetDescriber:  "",
+		Actions:       []string{"states:DescribeExecution", "states:ListExecutions", "states:ListStateMachines"},
+	},
+	"AWS::StepFunctions::StateMachineExecutionHistories": {
+		ListDescriber: "StepFunctionsStateMachineExecutionHistories",
+		GetDescriber:  "",
+		Actions:       []string{"states:GetExecutionHistory", "states:ListExecutions", "states:ListStateMachines"},
+	},
+	"AWS::SimSpaceWeaver::Simulation": {
+		ListDescriber: "SimSpaceWeaverSimulation",
+		GetDescriber:  "",
+		Actions:       []string{"simspaceweaver:DescribeSimulation", "simspaceweaver:ListSimulations", "simspaceweaver:ListTagsForResource"},
+	},
+	"AWS::SSM::Association": {
+		ListDescriber: "SSMAssociation",
+		GetDescriber:  "",
+		Actions:       []string{"ssm:DescribeAssociation", "ssm:ListAssociations"},
+	},
+	"AWS::SSM::Document": {
+		ListDescriber: "SSMDocument",
+		GetDescriber:  "",
+		Actions:       []string{"ssm:DescribeDocument", "ssm:DescribeDocumentPermission", "ssm:ListDocuments"},
+	},
+	"AWS::SSM::DocumentPermission": {
+		ListDescriber: "SSMDocumentPermission",
+		GetDescriber:  "",
+		Actions:       []string{"ssm:DescribeDocument", "ssm:DescribeDocumentPermission", "ssm:ListDocuments"},
+	},
+	"AWS::EC2::CustomerGateway": {
+		ListDescriber: "EC2CustomerGateway",
+		GetDescriber:  "GetEC2CustomerGateway",
+		Actions:       []string{"ec2:DescribeCustomerGateways"},
+	},
+	"AWS::EC2::VerifiedAccessInstance": {
+		ListDescriber: "EC2VerifiedAccessInstance",
+		GetDescriber:  "GetEC2VerifiedAccessInstance",
+		Actions:       []string{"ec2:DescribeVerifiedAccessInstances"},
+	},
+	"AWS::EC2::VerifiedAccessEndpoint": {
+		ListDescriber: "EC2VerifiedAccessEndpoint",
+		GetDescriber:  "GetEC2VerifiedAccessEndpoint",
+		Actions:       []string{"ec2:DescribeVerifiedAccessEndpoints"},
+	},
+	"AWS::EC2::VerifiedAccessGroup": {
+		ListDescriber: "EC2VerifiedAccessGroup",
+		GetDescriber:  "GetEC2VerifiedAccessGroup",
+		Actions:       []string{"ec2:DescribeVerifiedAccessGroups"},
+	},
+	"AWS::EC2::VerifiedAccessTrustProvider": {
+		ListDescriber: "EC2VerifiedAccessTrustProvider",
+		GetDescriber:  "GetEC2VerifiedAccessTrustProvider",
+		Actions:       []string{"ec2:DescribeVerifiedAccessTrustProviders"},
+	},
+	"AWS::EC2::VPNGateway": {
+		ListDescriber: "EC2VPNGateway",
+		GetDescriber:  "GetEC2VPNGateway",
+		Actions:       []string{"ec2:DescribeVpnGateways"},
+	},
+	"AWS::WAFv2::IPSet": {
+		ListDescriber: "WAFv2IPSet",
+		GetDescriber:  "",
+		Actions:       []string{"wafv2:GetIPSet", "wafv2:ListIPSets", "wafv2:ListTagsForResource"},
+	},
+	"AWS::WAFv2::RegexPatternSet": {
+		ListDescriber: "WAFv2RegexPatternSet",
+		GetDescriber:  "",
+		Actions:       []string{"wafv2:GetRegexPatternSet", "wafv2:ListRegexPatternSets", "wafv2:ListTagsForResource"},
+	},
+	"AWS::WAFv2::RuleGroup": {
+		ListDescriber: "WAFv2RuleGroup",
+		GetDescriber:  "",
+		Actions:       []string{"wafv2:GetRuleGroup", "wafv2:ListRuleGroups", "wafv2:ListTagsForResource"},
+	},
+	"AWS::EC2::TransitGatewayRoute": {
+		ListDescriber: "EC2TransitGatewayRoute",
+		GetDescriber:  "GetEC2TransitGatewayRoute",
+		Actions:       []string{"ec2:DescribeTransitGatewayRouteTables", "ec2:SearchTransitGatewayRoutes"},
+	},
+	"AWS::GuardDuty::Filter": {
+		ListDescriber: "GuardDutyFilter",
+		GetDescriber:  "",
+		Actions:       []string{"guardduty:GetFilter", "guardduty:ListDetectors", "guardduty:ListFilters"},
+	},
+	"AWS::ECS::TaskDefinition": {
+		ListDescriber: "ECSTaskDefinition",
+		GetDescriber:  "GetECSTaskDefinition",
+		Actions:       []string{"ecs:DescribeTaskDefinition", "ecs:ListTaskDefinitions"},
+	},
+	"AWS::GuardDuty::ThreatIntelSet": {
+		ListDescriber: "GuardDutyThreatIntelSet",
+		GetDescriber:  "",
+		Actions:       []string{"guardduty:GetThreatIntelSet", "guardduty:ListDetectors", "guardduty:ListThreatIntelSets"},
+	},
+	"AWS::ApiGatewayV2::DomainName": {
+		ListDescriber: "ApiGatewayV2DomainName",
+		GetDescriber:  "GetApiGatewayV2DomainName",
+		Actions:       []string{"apigateway:GET"},
+	},
+	"AWS::ApiGateway::DomainName": {
+		ListDescriber: "ApiGatewayDomainName",
+		GetDescriber:  "GetApiGatewayDomainName",
+		Actions:       []string{"apigateway:GET"},
+	},
+	"AWS::ApiGatewayV2::Route": {
+		ListDescriber: "ApiGatewayV2Route",
+		GetDescriber:  "",
+		Actions:       []string{"apigateway:GET"},
+	},
+	"AWS::MQ::Broker": {
+		ListDescriber: "MQBroker",
+		GetDescriber:  "",
+		Actions:       []string{"mq:DescribeBroker", "mq:ListBrokers", "mq:ListTags"},
+	},
+	"AWS::ACMPCA::CertificateAuthority": {
+		ListDescriber: "ACMPCACertificateAuthority",
+		GetDescriber:  "",
+		Actions:       []string{"acm-pca:ListCertificateAuthorities", "acm-pca:ListTags"},
+	},
+	"AWS::CloudFormation::Stack": {
+		ListDescriber: "CloudFormationStack",
+		GetDescriber:  "GetCloudFormationStack",
+		Actions:       []string{"cloudformation:DescribeStackResources", "cloudformation:DescribeStacks", "cloudformation:GetTemplate"},
+	},
+	"AWS::CloudFormation::StackResource": {
+		ListDescriber: "CloudFormationStackResource",
+		GetDescriber:  "GetCloudFormationStackResource",
+		Actions:       []string{"cloudformation:DescribeStackResource", "cloudformation:DescribeStacks", "cloudformation:ListStackResources"},
+	},
+	"AWS::DirectConnect::Connection": {
+		ListDescriber: "DirectConnectConnection",
+		GetDescriber:  "",
+		Actions:       []string{"directconnect:DescribeConnections"},
+	},
+	"AWS::FSX::FileSystem": {
+		ListDescriber: "FSXFileSystem",
+		GetDescriber:  "",
+		Actions:       []string{"fsx:DescribeFileSystems"},
+	},
+	"AWS::Glue::SecurityConfiguration": {
+		ListDescriber: "GlueSecurityConfiguration",
+		GetDescriber:  "",
+		Actions:       []string{"glue:GetSecurityConfigurations"},
+	},
+	"AWS::Inspector::AssessmentRun": {
+		ListDescriber: "InspectorAssessmentRun",
+		GetDescriber:  "",
+		Actions:       []string{"inspector:DescribeAssessmentRuns", "inspector:ListAssessmentRuns"},
+	},
+	"AWS::Inspector2::Coverage": {
+		ListDescriber: "Inspector2Coverage",
+		GetDescriber:  "",
+		Actions:       []string{"inspector2:ListCoverage"},
+	},
+	"AWS::Inspector2::CoverageStatistics": {
+		ListDescriber: "Inspector2CoverageStatistic",
+		GetDescriber:  "",
+		Actions:       []string{"inspector2:ListCoverageStatistics"},
+	},
+	"AWS::Inspector2::Member": {
+		ListDescriber: "Inspector2CoverageMember",
+		GetDescriber:  "",
+		Actions:       []string{"inspector2:ListMembers"},
+	},
+	"AWS::Inspector2::Finding": {
+		ListDescriber: "Inspector2Finding",
+		GetDescriber:  "",
+		Actions:       []string{"inspector2:ListFindings"},
+	},
+	"AWS::Config::ConfigurationRecorder": {
+		ListDescriber: "ConfigConfigurationRecorder",
+		GetDescriber:  "",
+		Actions:       []string{"config:DescribeConfigurationRecorderStatus", "config:DescribeConfigurationRecorders"},
+	},
+	"AWS::EC2::NatGateway": {
+		ListDescriber: "EC2NatGateway",
+		GetDescriber:  "GetEC2NatGateway",
+		Actions:       []string{"ec2:DescribeNatGateways"},
+	},
+	"AWS::ECR::PublicRepository": {
+		ListDescriber: "ECRPublicRepository",
+		GetDescriber:  "",
+		Actions:       []string{"ecr-public:DescribeImages", "ecr-public:DescribeRepositories", "ecr-public:GetRepositoryPolicy", "ecr-public:ListTagsForResource"},
+	},
+	"AWS::ECS::Cluster": {
+		ListDescriber: "ECSCluster",
+		GetDescriber:  "GetECSCluster",
+		Actions:       []string{"ecs:DescribeClusters", "ecs:ListClusters"},
+	},
+	"AWS::ElasticLoadBalancingV2::TargetGroup": {
+		ListDescriber: "ElasticLoadBalancingV2TargetGroup",
+		GetDescriber:  "",
+		Actions:       []string{"elasticloadbalancing:DescribeTags", "elasticloadbalancing:DescribeTargetGroups", "elasticloadbalancing:DescribeTargetHealth"},
+	},
+	"AWS::CloudFront::CachePolicy": {
+		ListDescriber: "CloudFrontCachePolicy",
+		GetDescriber:  "",
+		Actions:       []string{"cloudfront:GetCachePolicy", "cloudfront:ListCachePolicies"},
+	},
+	"AWS::CodeArtifact::Repository": {
+		ListDescriber: "CodeArtifactRepository",
+		GetDescriber:  "",
+		Actions:       []string{"codeartifact:DescribeRepository", "codeartifact:GetRepositoryEndpoint", "codeartifact:GetRepositoryPermissionsPolicy", "codeartifact:ListRepositories", "codeartifact:ListTagsForResource"},
+	},
+	"AWS::AMP::Workspace": {
+		ListDescriber: "AMPWorkspace",
+		GetDescriber:  "",
+		Actions:       []string{"aps:ListWorkspaces"},
+	},
+	"AWS::EC2::CapacityReservation": {
+		ListDescriber: "EC2CapacityReservation",
+		GetDescriber:  "GetEC2CapacityReservation",
+		Actions:       []string{"ec2:DescribeCapacityReservations"},
+	},
+	"AWS::SageMaker::NotebookInstance": {
+		ListDescriber: "SageMakerNotebookInstance",
+		GetDescriber:  "",
+		Actions:       []string{"sagemaker:DescribeNotebookInstance", "sagemaker:ListNotebookInstances", "sagemaker:ListTags"},
+	},
+	"AWS::IAM::AccessAdvisor": {
+		ListDescriber: "IAMAccessAdvisor",
+		GetDescriber:  "",
+		Actions:       []string{"iam:GenerateServiceLastAccessedDetails", "iam:GetServiceLastAccessedDetails", "iam:ListGroupsForUser", "iam:ListPolicies", "iam:ListRoles", "iam:ListUsers"},
+	},
+	"AWS::EC2::VolumeSnapshot": {
+		ListDescriber: "EC2VolumeSnapshot",
+		GetDescriber:  "GetEC2VolumeSnapshot",
+		Actions:       []string{"ec2:DescribeSnapshotAttribute", "ec2:DescribeSnapshots"},
+	},
+	"AWS::EC2::Region": {
+		ListDescriber: "EC2Region",
+		GetDescriber:  "",
+		Actions:       []string{"ec2:DescribeRegions"},
+	},
+	"AWS::Keyspaces::Table": {
+		ListDescriber: "KeyspacesTable",
+		GetDescriber:  "",
+		Actions:       []string{"cassandra:Select"},
+	},
+	"AWS::Config::AggregationAuthorization": {
+		ListDescriber: "ConfigAggregateAuthorization",
+		GetDescriber:  "",
+		Actions:       []string{"config:DescribeAggregationAuthorizations", "config:ListTagsForResource"},
+	},
+	"AWS::DAX::SubnetGroup": {
+		ListDescriber: "DAXSubnetGroup",
+		GetDescriber:  "",
+		Actions:       []string{"dax:DescribeSubnetGroups"},
+	},
+	"AWS::DynamoDb::GlobalTable": {
+		ListDescriber: "DynamoDbGlobalTable",
+		GetDescriber:  "",
+		Actions:       []string{"dynamodb:DescribeGlobalTable", "dynamodb:ListGlobalTables"},
+	},
+	"AWS::ElasticLoadBalancing::LoadBalancer": {
+		ListDescriber: "ElasticLoadBalancingLoadBalancer",
+		GetDescriber:  "",
+		Actions:       []string{"elasticloadbalancing:DescribeLoadBalancerAttributes", "elasticloadbalancing:DescribeLoadBalancers", "elasticloadbalancing:DescribeTags"},
+	},
+	"AWS::AppStream::Application": {
+		ListDescriber: "AppStreamApplication",
+		GetDescriber:  "",
+		Actions:       []string{"appstream:DescribeApplications", "appstream:ListTagsForResource"},
+	},
+	"AWS::RedshiftServerless::Namespace": {
+		ListDescriber: "RedshiftServerlessNamespace",
+		GetDescriber:  "",
+		Actions:       []string{"redshift-serverless:ListNamespaces", "redshift-serverless:ListTagsForResource"},
+	},
+	"AWS::CloudFront::OriginAccessIdentity": {
+		ListDescriber: "CloudFrontOriginAccessIdentity",
+		GetDescriber:  "",
+		Actions:       []string{"cloudfront:GetCloudFrontOriginAccessIdentity", "cloudfront:ListCloudFrontOriginAccessIdentities"},
+	},
+	"AWS::EC2::Host": {
+		ListDescriber: "EC2Host",
+		GetDescriber:  "GetEC2Host",
+		Actions:       []string{"ec2:DescribeHosts"},
+	},
+	"AWS::EC2::VPC": {
+		ListDescriber: "EC2VPC",
+		GetDescriber:  "GetEC2VPC",
+		Actions:       []string{"ec2:DescribeVpcs"},
+	},
+	"AWS::EC2::TransitGatewayRouteTable": {
+		ListDescriber: "EC2TransitGatewayRouteTable",
+		GetDescriber:  "GetEC2TransitGatewayRouteTable",
+		Actions:       []string{"ec2:DescribeTransitGatewayRouteTables"},
+	},
+	"AWS::EKS::Nodegroup": {
+		ListDescriber: "EKSNodegroup",
+		GetDescriber:  "",
+		Actions:       []string{"eks:DescribeNodegroup", "eks:ListClusters", "eks:ListNodegroups"},
+	},
+	"AWS::Backup::Selection": {
+		ListDescriber: "BackupSelection",
+		GetDescriber:  "",
+		Actions:       []string{"backup:GetBackupPlan", "backup:GetBackupSelection", "backup:ListBackupPlans", "backup:ListBackupSelections"},
+	},
+	"AWS::CloudTrail::Import": {
+		ListDescriber: "CloudTrailImport",
+		GetDescriber:  "",
+		Actions:       []string{"cloudtrail:GetImport", "cloudtrail:ListImports"},
+	},
+	"AWS::CostExplorer::ByServiceDaily": {
+		ListDescriber: "CostByServiceLastDay",
+		GetDescriber:  "",
+		Actions:       []string{"ce:GetCostAndUsage"},
+	},
+	"AWS::ElasticLoadBalancingV2::SslPolicy": {
+		ListDescriber: "ElasticLoadBalancingV2SslPolicy",
+		GetDescriber:  "",
+		Actions:       []string{"elasticloadbalancing:DescribeSSLPolicies"},
+	},
+	"AWS::GuardDuty::Finding": {
+		ListDescriber: "GuardDutyFinding",
+		GetDescriber:  "",
+		Actions:       []string{"guardduty:GetFindings", "guardduty:ListDetectors", "guardduty:ListFindings"},
+	},
+	"AWS::EC2::DHCPOptions": {
+		ListDescriber: "EC2DHCPOptions",
+		GetDescriber:  "GetEC2DHCPOptions",
+		Actions:       []string{"ec2:DescribeDhcpOptions"},
+	},
+	"AWS::Batch::ComputeEnvironment": {
+		ListDescriber: "BatchComputeEnvironment",
+		GetDescriber:  "GetBatchComputeEnvironment",
+		Actions:       []string{"batch:DescribeComputeEnvironments"},
+	},
+	"AWS::DMS::ReplicationInstance": {
+		ListDescriber: "DMSReplicationInstance",
+		GetDescriber:  "",
+		Actions:       []string{"dms:DescribeReplicationInstances", "dms:ListTagsForResource"},
+	},
+	"AWS::DMS::Endpoint": {
+		ListDescriber: "DMSEndpoint",
+		GetDescriber:  "",
+		Actions:       []string{"dms:DescribeEndpoints", "dms:ListTagsForResource"},
+	},
+	"AWS::DMS::ReplicationTask": {
+		ListDescriber: "DMSReplicationTask",
+		GetDescriber:  "",
+		Actions:       []string{"dms:DescribeReplicationTasks", "dms:ListTagsForResource"},
+	},
+	"AWS::DynamoDb::Table": {
+		ListDescriber: "DynamoDbTable",
+		GetDescriber:  "",
+		Actions:       []string{"dynamodb:DescribeContinuousBackups", "dynamodb:DescribeKinesisStreamingDestination", "dynamodb:DescribeTable", "dynamodb:ListTables", "dynamodb:ListTagsOfResource"},
+	},
+	"AWS::Shield::ProtectionGroup": {
+		ListDescriber: "ShieldProtectionGroup",
+		GetDescriber:  "",
+		Actions:       []string{"shield:ListProtectionGroups", "shield:ListTagsForResource"},
+	},
+	"AWS::Firehose::DeliveryStream": {
+		ListDescriber: "FirehoseDeliveryStream",
+		GetDescriber:  "",
+		Actions:       []string{"firehose:DescribeDeliveryStream", "firehose:ListDeliveryStreams", "firehose:ListTagsForDeliveryStream"},
+	},
+	"AWS::KinesisVideo::Stream": {
+		ListDescriber: "KinesisVideoStream",
+		GetDescriber:  "",
+		Actions:       []string{"kinesisvideo:ListStreams", "kinesisvideo:ListTagsForStream"},
+	},
+	"AWS::KMS::Alias": {
+		ListDescriber: "KMSAlias",
+		GetDescriber:  "",
+		Actions:       []string{"kms:ListAliases"},
+	},
+	"AWS::Lambda::Alias": {
+		ListDescriber: "LambdaAlias",
+		GetDescriber:  "",
+		Actions:       []string{"lambda:GetFunction", "lambda:GetFunctionUrlConfig", "lambda:GetPolicy", "lambda:ListAliases", "lambda:ListFunctionUrlConfigs", "lambda:ListFunctions"},
+	},
+	"AWS::Lambda::LambdaLayer": {
+		ListDescriber: "LambdaLayer",
+		GetDescriber:  "",
+		Actions:       []string{"lambda:ListLayers"},
+	},
+	"AWS::Lambda::LayerVersion": {
+		ListDescriber: "LambdaLayerVersion",
+		GetDescriber:  "",
+		Actions:       []string{"lambda:GetLayerVersion", "lambda:GetLayerVersionPolicy", "lambda:ListLayerVersions", "lambda:ListLayers"},
+	},
+	"AWS::Lightsail::Instance": {
+		ListDescriber: "LightsailInstance",
+		GetDescriber:  "",
+		Actions:       []string{"lightsail:GetInstances"},
+	},
+	"AWS::Macie2::ClassificationJob": {
+		ListDescriber: "Macie2ClassificationJob",
+		GetDescriber:  "",
+		Actions:       []string{"macie2:DescribeClassificationJob", "macie2:ListClassificationJobs"},
+	},
+	"AWS::MediaStore::Container": {
+		ListDescriber: "MediaStoreContainer",
+		GetDescriber:  "",
+		Actions:       []string{"mediastore:GetContainerPolicy", "mediastore:ListContainers", "mediastore:ListTagsForResource"},
+	},
+	"AWS::Mgn::Application": {
+		ListDescriber: "MGNApplication",
+		GetDescriber:  "",
+		Actions:       []string{"mgn:ListApplications"},
+	},
+	"AWS::Route53Resolver::ResolverEndpoint": {
+		ListDescriber: "Route53ResolverResolverEndpoint",
+		GetDescriber:  "",
+		Actions:       []string{"route53resolver:ListResolverEndpointIpAddresses", "route53resolver:ListResolverEndpoints", "route53resolver:ListTagsForResource"},
+	},
+	"AWS::Route53Domains::Domain": {
+		ListDescriber: "Route53Domain",
+		GetDescriber:  "",
+		Actions:       []string{"route53domains:GetDomainDetail", "route53domains:ListDomains", "route53domains:ListTagsForDomain"},
+	},
+	"AWS::Route53::Record": {
+		ListDescriber: "Route53Record",
+		GetDescriber:  "",
+		Actions:       []string{"route53:ListHostedZones", "route53:ListResourceRecordSets"},
+	},
+	"AWS::Route53::TrafficPolicy": {
+		ListDescriber: "Route53TrafficPolicy",
+		GetDescriber:  "",
+		Actions:       []string{"route53:GetTrafficPolicy", "route53:ListTrafficPolicies"},
+	},
+	"AWS::Route53::TrafficPolicyInstance": {
+		ListDescriber: "Route53TrafficPolicyInstance",
+		GetDescriber:  "",
+		Actions:       []string{"route53:ListTrafficPolicyInstances"},
+	},
+	"AWS::SageMaker::Model": {
+		ListDescriber: "SageMakerModel",
+		GetDescriber:  "",
+		Actions:       []string{"sagemaker:DescribeModel", "sagemaker:ListModels", "sagemaker:ListTags"},
+	},
+	"AWS::SageMaker::TrainingJob": {
+		ListDescriber: "SageMakerTrainingJob",
+		GetDescriber:  "",
+		Actions:       []string{"sagemaker:DescribeTrainingJob", "sagemaker:ListTags", "sagemaker:ListTrainingJobs"},
+	},
+	"AWS::SecurityHub::ActionTarget": {
+		ListDescriber: "SecurityHubActionTarget",
+		GetDescriber:  "",
+		Actions:       []string{"securityhub:DescribeActionTargets"},
+	},
+	"AWS::SecurityHub::Finding": {
+		ListDescriber: "SecurityHubFinding",
+		GetDescriber:  "",
+		Actions:       []string{"securityhub:GetFindings"},
+	},
+	"AWS::SecurityHub::FindingAggregator": {
+		ListDescriber: "SecurityHubFindingAggregator",
+		GetDescriber:  "",
+		Actions:       []string{"securityhub:GetFindingAggregator", "securityhub:ListFindingAggregators"},
+	},
+	"AWS::SecurityHub::Insight": {
+		ListDescriber: "SecurityHubInsight",
+		GetDescriber:  "",
+		Actions:       []string{"securityhub:GetInsights"},
+	},
+	"AWS::SecurityHub::Member": {
+		ListDescriber: "SecurityHubMember",
+		GetDescriber:  "",
+		Actions:       []string{"securityhub:ListMembers"},
+	},
+	"AWS::SecurityHub::Product": {
+		ListDescriber: "SecurityHubProduct",
+		GetDescriber:  "",
+		Actions:       []string{"securityhub:DescribeProducts"},
+	},
+	"AWS::SecurityHub::StandardsControl": {
+		ListDescriber: "SecurityHubStandardsControl",
+		GetDescriber:  "",
+		Actions:       []string{"securityhub:DescribeStandardsControls", "securityhub:GetEnabledStandards"},
+	},
+	"AWS::SecurityHub::StandardsSubscription": {
+		ListDescriber: "SecurityHubStandardsSubscription",
+		GetDescriber:  "",
+		Actions:       []string{"securityhub:DescribeStandards", "securityhub:GetEnabledStandards"},
+	},
+	"AWS::SecurityLake::DataLake": {
+		ListDescriber: "SecurityLakeDataLake",
+		GetDescriber:  "",
+		Actions:       []string{"securitylake:ListDataLakes"},
+	},
+	"AWS::SecurityLake::Subscriber": {
+		ListDescriber: "SecurityLakeSubscriber",
+		GetDescriber:  "",
+		Actions:       []string{"securitylake:ListSubscribers"},
+	},
+	"AWS::Ram::PrincipalAssociation": {
+		ListDescriber: "RamPrincipalAssociation",
+		GetDescriber:  "",
+		Actions:       []string{"ram:GetResourceShareAssociations", "ram:ListResourceSharePermissions"},
+	},
+	"AWS::Ram::ResourceAssociation": {
+		ListDescriber: "RamResourceAssociation",
+		GetDescriber:  "",
+		Actions:       []string{"ram:GetResourceShareAssociations", "ram:ListResourceSharePermissions"},
+	},
+	"AWS::RDS::ReservedDBInstance": {
+		ListDescriber: "RDSReservedDBInstance",
+		GetDescriber:  "",
+		Actions:       []string{"rds:DescribeReservedDBInstances"},
+	},
+	"AWS::Redshift::SubnetGroup": {
+		ListDescriber: "RedshiftSubnetGroup",
+		GetDescriber:  "",
+		Actions:       []string{"redshift:DescribeClusterSubnetGroups"},
+	},
+	"AWS::SeverlessApplicationRepository::Application": {
+		ListDescriber: "ServerlessApplicationRepositoryApplication",
+		GetDescriber:  "",
+		Actions:       []string{"serverlessrepo:GetApplication", "serverlessrepo:GetApplicationPolicy", "serverlessrepo:ListApplications"},
+	},
+	"AWS::AuditManager::Framework": {
+		ListDescriber: "AuditManagerFramework",
+		GetDescriber:  "",
+		Actions:       []string{"auditmanager:GetAssessmentFramework", "auditmanager:ListAssessmentFrameworks"},
+	},
+	"AWS::AuditManager::EvidenceFolder": {
+		ListDescriber: "AuditManagerEvidenceFolder",
+		GetDescriber:  "",
+		Actions:       []string{"auditmanager:GetEvidenceFoldersByAssessment", "auditmanager:ListAssessments"},
+	},
+	"AWS::AuditManager::Evidence": {
+		ListDescriber: "AuditManagerEvidence",
+		GetDescriber:  "",
+		Actions:       []string{"auditmanager:GetEvidenceByEvidenceFolder", "auditmanager:GetEvidenceFoldersByAssessment", "auditmanager:ListAssessments"},
+	},
+	"AWS::AuditManager::Control": {
+		ListDescriber: "AuditManagerControl",
+		GetDescriber:  "GetAuditManagerControl",
+		Actions:       []string{"auditmanager:GetControl", "auditmanager:ListControls"},
+	},
+	"AWS::AuditManager::Assessment": {
+		ListDescriber: "AuditManagerAssessment",
+		GetDescriber:  "",
+		Actions:       []string{"auditmanager:GetAssessment", "auditmanager:ListAssessments"},
+	},
+	"AWS::Logs::MetricFilter": {
+		ListDescriber: "CloudWatchLogsMetricFilter",
+		GetDescriber:  "",
+		Actions:       []string{"logs:DescribeMetricFilters"},
+	},
+	"AWS::ServiceQuotas::ServiceQuotaChangeRequest": {
+		ListDescriber: "ServiceQuotasServiceQuotaChangeRequest",
+		GetDescriber:  "",
+		Actions:       []string{"servicequotas:ListRequestedServiceQuotaChangeHistory", "servicequotas:ListTagsForResource"},
+	},
+	"AWS::ServiceQuotas::Service": {
+		ListDescriber: "ServiceQuotasService",
+		GetDescriber:  "",
+		Actions:       []string{"servicequotas:ListServices"},
+	},
+	"AWS::EC2::VPCEndpointService": {
+		ListDescriber: "EC2VPCEndpointService",
+		GetDescriber:  "",
+		Actions:       []string{"ec2:DescribeVpcEndpointConnections", "ec2:DescribeVpcEndpointServicePermissions", "ec2:DescribeVpcEndpointServices"},
+	},
+	"AWS::EC2::LaunchTemplate": {
+		ListDescriber: "EC2LaunchTemplate",
+		GetDescriber:  "GetEC2LaunchTemplate",
+		Actions:       []string{"ec2:DescribeLaunchTemplates"},
+	},
+	"AWS::EC2::LaunchTemplateVersion": {
+		ListDescriber: "EC2LaunchTemplateVersion",
+		GetDescriber:  "GetEC2LaunchTemplateVersion",
+		Actions:       []string{"ec2:DescribeLaunchTemplateVersions", "ec2:DescribeLaunchTemplates"},
+	},
+	"AWS::SNS::Subscription": {
+		ListDescriber: "SNSSubscription",
+		GetDescriber:  "",
+		Actions:       []string{"sns:GetSubscriptionAttributes", "sns:ListSubscriptions"},
+	},
+	"AWS::S3::AccountSetting": {
+		ListDescriber: "S3AccountSetting",
+		GetDescriber:  "",
+		Actions:       []string{"s3:GetAccountPublicAccessBlock", "sts:GetCallerIdentity"},
+	},
+	"AWS::SSM::ManagedInstanceCompliance": {
+		ListDescriber: "SSMManagedInstanceCompliance",
+		GetDescriber:  "",
+		Actions:       []string{"ssm:DescribeInstanceInformation", "ssm:ListComplianceItems"},
+	},
+	"AWS::SSM::ManagedInstancePatchState": {
+		ListDescriber: "SSMManagedInstancePatchState",
+		GetDescriber:  "",
+		Actions:       []string{"ssm:DescribeInstanceInformation", "ssm:DescribeInstancePatchStates"},
+	},
+	"AWS::SSOAdmin::AccountAssignment": {
+		ListDescriber: "SSOAdminAccountAssignment",
+		GetDescriber:  "",
+		Actions:       []string{"sso:ListAccountAssignments", "sso:ListInstances", "sso:ListPermissionSets"},
+	},
+	"AWS::SSOAdmin::UserEffectiveAccess": {
+		ListDescriber: "UserEffectiveAccess",
+		GetDescriber:  "",
+		Actions:       []string{"identitystore:DescribeUser", "identitystore:ListGroupMemberships", "sso:ListAccountAssignments", "sso:ListInstances", "sso:ListPermissionSets"},
+	},
+	"AWS::SSOAdmin::Instance": {
+		ListDescriber: "SSOAdminInstance",
+		GetDescriber:  "",
+		Actions:       []string{"sso:ListInstances"},
+	},
+	"AWS::SSOAdmin::PermissionSet": {
+		ListDescriber: "SSOAdminPermissionSet",
+		GetDescriber:  "",
+		Actions:       []string{"sso:DescribePermissionSet", "sso:ListInstances", "sso:ListPermissionSets", "sso:ListTagsForResource"},
+	},
+	"AWS::SSOAdmin::AttachedManagedPolicy": {
+		ListDescriber: "SSOAdminManagedPolicyAttachment",
+		GetDescriber:  "",
+		Actions:       []string{"sso:ListInstances", "sso:ListManagedPoliciesInPermissionSet", "sso:ListPermissionSets"},
+	},
+	"AWS::ServiceDiscovery::Service": {
+		ListDescriber: "ServiceDiscoveryService",
+		GetDescriber:  "",
+		Actions:       []string{"servicediscovery:ListServices", "servicediscovery:ListTagsForResource"},
+	},
+	"AWS::ServiceDiscovery::Namespace": {
+		ListDescriber: "ServiceDiscoveryNamespace",
+		GetDescriber:  "",
+		Actions:       []string{"servicediscovery:ListNamespaces", "servicediscovery:ListTagsForResource"},
+	},
+	"AWS::ServiceDiscovery::Instance": {
+		ListDescriber: "ServiceDiscoveryInstance",
+		GetDescriber:  "",
+		Actions:       []string{"servicediscovery:ListInstances", "servicediscovery:ListServices"},
+	},
+	"AWS::ServiceCatalog::Portfolio": {
+		ListDescriber: "ServiceCatalogPortfolio",
+		GetDescriber:  "",
+		Actions:       []string{"servicecatalog:DescribePortfolio", "servicecatalog:ListPortfolios"},
+	},
+	"AWS::ServiceCatalog::Product": {
+		ListDescriber: "ServiceCatalogProduct",
+		GetDescriber:  "",
+		Actions:       []string{"servicecatalog:DescribeProductAsAdmin", "servicecatalog:ListLaunchPaths", "servicecatalog:SearchProducts"},
+	},
+	"AWS::IdentityStore::User": {
+		ListDescriber: "IdentityStoreUser",
+		GetDescriber:  "",
+		Actions:       []string{"identitystore:ListUsers", "sso:ListInstances"},
+	},
+	"AWS::IdentityStore::Group": {
+		ListDescriber: "IdentityStoreGroup",
+		GetDescriber:  "",
+		Actions:       []string{"identitystore:ListGroups", "sso:ListInstances"},
+	},
+	"AWS::IdentityStore::GroupMembership": {
+		ListDescriber: "IdentityStoreGroupMembership",
+		GetDescriber:  "",
+		Actions:       []string{"identitystore:ListGroupMemberships", "identitystore:ListGroups", "sso:ListInstances"},
+	},
+	"AWS::IAM::EffectivePermission": {
+		ListDescriber: "IAMEffectivePermission",
+		GetDescriber:  "",
+		Actions:       []string{"iam:GetGroupPolicy", "iam:GetRole", "iam:GetRolePolicy", "iam:GetUser", "iam:GetUserPolicy", "iam:ListAttachedGroupPolicies", "iam:ListAttachedRolePolicies", "iam:ListAttachedUserPolicies", "iam:ListGroupPolicies", "iam:ListGroups", "iam:ListGroupsForUser", "iam:ListRolePolicies", "iam:ListRoles", "iam:ListUserPolicies", "iam:ListUsers"},
+	},
+	"AWS::Organizations::AccountEffectivePolicy": {
+		ListDescriber: "OrganizationsAccountEffectivePolicy",
+		GetDescriber:  "",
+		Actions:       []string{"organizations:ListAccountsForParent", "organizations:ListOrganizationalUnitsForParent", "organizations:ListRoots"},
+	},
+	"AWS::EC2::NetworkExposure": {
+		ListDescriber: "EC2NetworkExposure",
+		GetDescriber:  "",
+		Actions:       []string{"ec2:DescribeNetworkAcls", "ec2:DescribeNetworkInterfaces", "ec2:DescribeRouteTables", "ec2:DescribeSecurityGroups", "elasticloadbalancing:DescribeListeners", "elasticloadbalancing:DescribeLoadBalancers"},
+	},
+	"AWS::Backup::ResourceCoverage": {
+		ListDescriber: "BackupResourceCoverage",
+		GetDescriber:  "",
+		Actions:       []string{"backup:GetBackupPlan", "backup:GetBackupSelection", "backup:ListBackupPlans", "backup:ListBackupSelections", "backup:ListBackupVaults", "backup:ListRecoveryPointsByBackupVault", "dynamodb:ListTables", "dynamodb:ListTagsOfResource", "ec2:DescribeVolumes", "elasticfilesystem:DescribeFileSystems", "rds:DescribeDBClusters", "rds:DescribeDBInstances", "s3:GetBucketLocation", "s3:GetBucketTagging", "s3:ListAllMyBuckets"},
+	},
+}
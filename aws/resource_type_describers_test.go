@@ -0,0 +1,21 @@
+package aws
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/opengovern/og-aws-describer/pkg/policy"
+)
+
+// TestDescriberActions checks the IAM actions generated for the describers against the action
+// catalog, a wrong service prefix or action name would never be granted.
+func TestDescriberActions(t *testing.T) {
+	catalog := policy.Actions()
+	for name, info := range describerInfos {
+		for _, action := range info.Actions {
+			if _, found := slices.BinarySearch(catalog, action); !found {
+				t.Errorf("%s: unknown IAM action %s", name, action)
+			}
+		}
+	}
+}
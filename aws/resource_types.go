@@ -5646,6 +5646,76 @@ var resourceTypes = map[string]ResourceType{
 		Summarize:            true,
 	},
 
+	"AWS::AuditManager::Framework": {
+		Connector:            source.CloudAWS,
+		ResourceName:         "AWS::AuditManager::Framework",
+		ResourceLabel:        "Audit Manager Framework",
+		Tags:                 map[string][]string{},
+		ServiceName:          "AuditManager",
+		ListDescriber:        ParallelDescribeRegional(describer.AuditManagerFramework),
+		GetDescriber:         nil,
+		TerraformName:        []string{},
+		TerraformServiceName: "",
+		FastDiscovery:        false,
+		Summarize:            true,
+	},
+
+	"AWS::AuditManager::EvidenceFolder": {
+		Connector:            source.CloudAWS,
+		ResourceName:         "AWS::AuditManager::EvidenceFolder",
+		ResourceLabel:        "Audit Manager Evidence Folder",
+		Tags:                 map[string][]string{},
+		ServiceName:          "AuditManager",
+		ListDescriber:        ParallelDescribeRegional(describer.AuditManagerEvidenceFolder),
+		GetDescriber:         nil,
+		TerraformName:        []string{},
+		TerraformServiceName: "",
+		FastDiscovery:        false,
+		Summarize:            true,
+	},
+
+	"AWS::AuditManager::Evidence": {
+		Connector:            source.CloudAWS,
+		ResourceName:         "AWS::AuditManager::Evidence",
+		ResourceLabel:        "Audit Manager Evidence",
+		Tags:                 map[string][]string{},
+		ServiceName:          "AuditManager",
+		ListDescriber:        ParallelDescribeRegional(describer.AuditManagerEvidence),
+		GetDescriber:         nil,
+		TerraformName:        []string{},
+		TerraformServiceName: "",
+		FastDiscovery:        false,
+		Summarize:            true,
+	},
+
+	"AWS::AuditManager::Control": {
+		Connector:            source.CloudAWS,
+		ResourceName:         "AWS::AuditManager::Control",
+		ResourceLabel:        "Audit Manager Control",
+		Tags:                 map[string][]string{},
+		ServiceName:          "AuditManager",
+		ListDescriber:        ParallelDescribeRegional(describer.AuditManagerControl),
+		GetDescriber:         ParallelDescribeRegionalSingleResource(describer.GetAuditManagerControl),
+		TerraformName:        []string{},
+		TerraformServiceName: "",
+		FastDiscovery:        false,
+		Summarize:            true,
+	},
+
+	"AWS::AuditManager::Assessment": {
+		Connector:            source.CloudAWS,
+		ResourceName:         "AWS::AuditManager::Assessment",
+		ResourceLabel:        "Audit Manager Assessment",
+		Tags:                 map[string][]string{},
+		ServiceName:          "AuditManager",
+		ListDescriber:        ParallelDescribeRegional(describer.AuditManagerAssessment),
+		GetDescriber:         nil,
+		TerraformName:        []string{},
+		TerraformServiceName: "",
+		FastDiscovery:        false,
+		Summarize:            true,
+	},
+
 	"AWS::Logs::MetricFilter": {
 		Connector:            source.CloudAWS,
 		ResourceName:         "AWS::Logs::MetricFilter",
@@ -5967,4 +6037,60 @@ var resourceTypes = map[string]ResourceType{
 		FastDiscovery:        false,
 		Summarize:            true,
 	},
+
+	"AWS::IAM::EffectivePermission": {
+		Connector:            source.CloudAWS,
+		ResourceName:         "AWS::IAM::EffectivePermission",
+		ResourceLabel:        "IAM Effective Permission",
+		Tags:                 map[string][]string{},
+		ServiceName:          "IAM",
+		ListDescriber:        SequentialDescribeGlobal(describer.IAMEffectivePermission),
+		GetDescriber:         nil,
+		TerraformName:        []string{},
+		TerraformServiceName: "iam",
+		FastDiscovery:        false,
+		Summarize:            true,
+	},
+
+	"AWS::Organizations::AccountEffectivePolicy": {
+		Connector:            source.CloudAWS,
+		ResourceName:         "AWS::Organizations::AccountEffectivePolicy",
+		ResourceLabel:        "Organizations Account Effective Policy",
+		Tags:                 map[string][]string{},
+		ServiceName:          "Organizations",
+		ListDescriber:        SequentialDescribeGlobal(describer.OrganizationsAccountEffectivePolicy),
+		GetDescriber:         nil,
+		TerraformName:        []string{},
+		TerraformServiceName: "organizations",
+		FastDiscovery:        false,
+		Summarize:            true,
+	},
+
+	"AWS::EC2::NetworkExposure": {
+		Connector:            source.CloudAWS,
+		ResourceName:         "AWS::EC2::NetworkExposure",
+		ResourceLabel:        "Network Exposure",
+		Tags:                 map[string][]string{},
+		ServiceName:          "EC2.Network",
+		ListDescriber:        ParallelDescribeRegional(describer.EC2NetworkExposure),
+		GetDescriber:         nil,
+		TerraformName:        []string{},
+		TerraformServiceName: "ec2",
+		FastDiscovery:        false,
+		Summarize:            true,
+	},
+
+	"AWS::Backup::ResourceCoverage": {
+		Connector:            source.CloudAWS,
+		ResourceName:         "AWS::Backup::ResourceCoverage",
+		ResourceLabel:        "Resource Coverage",
+		Tags:                 map[string][]string{},
+		ServiceName:          "Backup",
+		ListDescriber:        ParallelDescribeRegional(describer.BackupResourceCoverage),
+		GetDescriber:         nil,
+		TerraformName:        []string{},
+		TerraformServiceName: "backup",
+		FastDiscovery:        false,
+		Summarize:            true,
+	},
 }
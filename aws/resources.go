@@ -1,4 +1,4 @@
-//go:generate go run ../inventory-data/resource_types_generator.go --provider aws --output resource_types.go --index-map ../pkg/steampipe/table_index_map.go && gofmt -w -s resource_types.go  && goimports -w resource_types.go
+//go:generate go run ../inventory-data/resource_types_generator.go --provider aws --output resource_types.go --index-map ../pkg/steampipe/table_index_map.go --describer-dir describer --describers-output resource_type_describers.go && gofmt -w resource_type_describers.go && gofmt -w -s resource_types.go  && goimports -w resource_types.go
 
 package aws
 
@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"go.uber.org/zap"
+	"reflect"
+	"runtime"
 	"sort"
 	"strings"
 
@@ -81,6 +83,39 @@ func (r ResourceType) IsSummarized() bool {
 	return r.Summarize
 }
 
+// ListDescriberKind returns the name of the function that built the list describer, e.g.
+// ParallelDescribeRegional or SequentialDescribeGlobal.
+func (r ResourceType) ListDescriberKind() string {
+	if r.ListDescriber == nil {
+		return ""
+	}
+	// The describer is a closure named like <package path>.ParallelDescribeRegional.func1.
+	name := runtime.FuncForPC(reflect.ValueOf(r.ListDescriber).Pointer()).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	parts := strings.Split(name, ".")
+	if len(parts) < 2 {
+		return name
+	}
+	return parts[1]
+}
+
+// DescriberInfo is how a resource type is described, as found by the resource types generator in
+// the describer sources.
+type DescriberInfo struct {
+	// ListDescriber and GetDescriber are the names of the describer package functions.
+	ListDescriber string
+	GetDescriber  string
+	// Actions are the IAM actions of the sdk calls of the describers, and of the describer
+	// package functions they call, sorted.
+	Actions []string
+}
+
+// GetDescriberInfo returns the describer info of the resource type.
+func GetDescriberInfo(resourceType string) (DescriberInfo, bool) {
+	info, ok := describerInfos[resourceType]
+	return info, ok
+}
+
 func ListResourceTypes() []string {
 	var list []string
 	for k := range resourceTypes {
@@ -140,14 +175,12 @@ type Resources struct {
 	ErrorCode string
 }
 
-func GetResources(ctx context.Context, logger *zap.Logger,
-	resourceType string, triggerType enums.DescribeTriggerType,
-	accountId string, regions []string,
-	credAccountId, accessKey, secretKey, sessionToken, assumeRoleName, assumeAdminRoleName string, externalId *string,
-	includeDisabledRegions bool, stream *describer.StreamSender) (*Resources, error) {
-	var err error
-	var cfg aws.Config
-
+// GetDescribeConfig returns the configuration used to describe resourceType in accountId.
+// Org-wide resource types (cost explorer, sso admin) are described from the credential
+// account using the admin role, other types assume a role in the target account.
+func GetDescribeConfig(ctx context.Context,
+	resourceType, accountId, credAccountId, accessKey, secretKey, sessionToken, assumeRoleName, assumeAdminRoleName string,
+	externalId *string, endpoints *EndpointConfig) (aws.Config, error) {
 	needToRunOnOrgMaster := false
 	if strings.HasPrefix(strings.ToLower(resourceType), "aws::costexplorer") {
 		needToRunOnOrgMaster = true
@@ -157,18 +190,33 @@ func GetResources(ctx context.Context, logger *zap.Logger,
 
 	if accountId != credAccountId && !needToRunOnOrgMaster {
 		assumeRoleArn := GetRoleArnFromName(accountId, assumeRoleName)
-		cfg, err = GetConfig(ctx, accessKey, secretKey, sessionToken, assumeRoleArn, externalId)
+		return GetConfig(ctx, accessKey, secretKey, sessionToken, assumeRoleArn, externalId, endpoints)
 	} else if accountId != credAccountId && needToRunOnOrgMaster {
 		assumeAdminRoleArn := GetRoleArnFromName(credAccountId, assumeAdminRoleName)
-		cfg, err = GetConfig(ctx, accessKey, secretKey, sessionToken, assumeAdminRoleArn, externalId)
-	} else {
-		assumeAdminRoleArn := GetRoleArnFromName(accountId, assumeAdminRoleName)
-		cfg, err = GetConfig(ctx, accessKey, secretKey, sessionToken, assumeAdminRoleArn, externalId)
+		return GetConfig(ctx, accessKey, secretKey, sessionToken, assumeAdminRoleArn, externalId, endpoints)
 	}
+	assumeAdminRoleArn := GetRoleArnFromName(accountId, assumeAdminRoleName)
+	return GetConfig(ctx, accessKey, secretKey, sessionToken, assumeAdminRoleArn, externalId, endpoints)
+}
+
+func GetResources(ctx context.Context, logger *zap.Logger,
+	resourceType string, triggerType enums.DescribeTriggerType,
+	accountId string, regions []string,
+	credAccountId, accessKey, secretKey, sessionToken, assumeRoleName, assumeAdminRoleName string, externalId *string,
+	endpoints *EndpointConfig, includeDisabledRegions bool, stream *describer.StreamSender) (*Resources, error) {
+	cfg, err := GetDescribeConfig(ctx, resourceType, accountId, credAccountId, accessKey, secretKey, sessionToken, assumeRoleName, assumeAdminRoleName, externalId, endpoints)
 	if err != nil {
 		return nil, err
 	}
+	return GetResourcesWithConfig(ctx, logger, cfg, resourceType, triggerType, accountId, regions, includeDisabledRegions, stream)
+}
 
+// GetResourcesWithConfig is GetResources with the config returned by GetDescribeConfig, for
+// callers that use the config for more than describing so the role is assumed once.
+func GetResourcesWithConfig(ctx context.Context, logger *zap.Logger, cfg aws.Config,
+	resourceType string, triggerType enums.DescribeTriggerType,
+	accountId string, regions []string,
+	includeDisabledRegions bool, stream *describer.StreamSender) (*Resources, error) {
 	if len(regions) == 0 {
 		cfgClone := cfg.Copy()
 		cfgClone.Region = "us-east-1"
@@ -215,11 +263,12 @@ func GetSingleResource(
 	sessionToken,
 	assumeRoleName string,
 	externalId *string,
+	endpoints *EndpointConfig,
 	includeDisabledRegions bool,
 	fields map[string]string,
 ) (*Resources, error) {
 	assumeRoleArn := GetRoleArnFromName(accountId, assumeRoleName)
-	cfg, err := GetConfig(ctx, accessKey, secretKey, sessionToken, assumeRoleArn, externalId)
+	cfg, err := GetConfig(ctx, accessKey, secretKey, sessionToken, assumeRoleArn, externalId, endpoints)
 	if err != nil {
 		return nil, err
 	}
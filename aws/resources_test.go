@@ -0,0 +1,160 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/opengovern/og-aws-describer/aws/describer"
+	"github.com/opengovern/og-aws-describer/pkg/awsfixture"
+	"github.com/opengovern/og-util/pkg/describe/enums"
+	"github.com/opengovern/og-util/pkg/es"
+)
+
+// describedResource is a resource described from a fixture, as compared with the golden file of
+// the fixture. The description is reduced to its shape, the type of every field.
+type describedResource struct {
+	ARN    string `json:"arn"`
+	Name   string `json:"name"`
+	Region string `json:"region"`
+	Shape  any    `json:"shape"`
+}
+
+// TestListDescribers runs the list describer of every resource type with a fixture in
+// testdata/describers against its recorded calls, and compares the resources with the golden
+// file of the fixture. Golden files are written with AWS_FIXTURES=golden or when recording, see
+// awsfixture.
+//
+// Besides <index>.http.json, a resource type can have variant fixtures, <index>.<variant>.http.json
+// with their <index>.<variant>.golden.json, for the cases a single account doesn't cover, e.g. a
+// member account of an organization. Variants run as subtests of the resource type.
+func TestListDescribers(t *testing.T) {
+	for _, name := range ListResourceTypes() {
+		rt := resourceTypes[name]
+		index := es.ResourceTypeToESIndex(name)
+		fixture := filepath.Join("testdata", "describers", index+".http.json")
+		golden := filepath.Join("testdata", "describers", index+".golden.json")
+
+		t.Run(name, func(t *testing.T) {
+			variants, err := filepath.Glob(filepath.Join("testdata", "describers", index+".*.http.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, variantFixture := range variants {
+				variant := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(variantFixture), index+"."), ".http.json")
+				t.Run(variant, func(t *testing.T) {
+					testListDescriber(t, name, rt, variantFixture, strings.TrimSuffix(variantFixture, ".http.json")+".golden.json")
+				})
+			}
+
+			if _, err := os.Stat(fixture); err != nil && !awsfixture.Recording() {
+				if len(variants) == 0 {
+					t.Skip("no fixture")
+				}
+				return
+			}
+			testListDescriber(t, name, rt, fixture, golden)
+		})
+	}
+}
+
+// testListDescriber runs the list describer of the resource type name against fixture and
+// compares the resources with golden.
+func testListDescriber(t *testing.T, name string, rt ResourceType, fixture, golden string) {
+	t.Helper()
+
+	cfg := awsfixture.Config(t, fixture)
+	accountID := awsfixture.CallerAccountID(cfg)
+	output, err := rt.ListDescriber(context.Background(), cfg, accountID, []string{awsfixture.Region},
+		name, enums.DescribeTriggerTypeManual, nil)
+	if err != nil {
+		t.Fatalf("describe failed: %v", err)
+	}
+	for region, regionErr := range output.Errors {
+		t.Errorf("describe failed in %s: %s", region, regionErr)
+	}
+
+	got := describedResources(t, accountID, output.Resources)
+	gotJSON, err := json.MarshalIndent(got, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotJSON = append([]byte(awsfixture.Sanitize(cfg, string(gotJSON))), '\n')
+
+	if awsfixture.UpdateGolden() {
+		if err := os.WriteFile(golden, gotJSON, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	wantJSON, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("resources differ from %s, got:\n%s", golden, gotJSON)
+	}
+}
+
+func describedResources(t *testing.T, accountID string, resources map[string][]describer.Resource) []describedResource {
+	t.Helper()
+
+	got := []describedResource{}
+	for _, regionResources := range resources {
+		for _, r := range regionResources {
+			if r.Account != accountID {
+				t.Errorf("resource %s has account %s", r.ARN, r.Account)
+			}
+
+			description, err := json.Marshal(r.Description)
+			if err != nil {
+				t.Fatalf("failed to marshal description of %s: %v", r.ARN, err)
+			}
+			var v any
+			if err := json.Unmarshal(description, &v); err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, describedResource{
+				ARN:    r.ARN,
+				Name:   r.Name,
+				Region: r.Region,
+				Shape:  shapeOf(v),
+			})
+		}
+	}
+	sort.Slice(got, func(i, j int) bool {
+		return got[i].ARN < got[j].ARN
+	})
+	return got
+}
+
+// shapeOf replaces every value of a JSON document with its type, arrays are reduced to the
+// shape of their first item.
+func shapeOf(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		shape := map[string]any{}
+		for key, value := range v {
+			shape[key] = shapeOf(value)
+		}
+		return shape
+	case []any:
+		if len(v) == 0 {
+			return []any{}
+		}
+		return []any{shapeOf(v[0])}
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "bool"
+	default:
+		return "null"
+	}
+}
@@ -0,0 +1,100 @@
+package aws
+
+import (
+	"context"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/opengovern/og-aws-describer/aws/describer"
+	"go.uber.org/zap"
+)
+
+const (
+	// TagsSourceDescriber marks tags that were returned by the resource describer itself.
+	TagsSourceDescriber = "describer"
+	// TagsSourceTaggingAPI marks tags that were merged from the Resource Groups Tagging API.
+	TagsSourceTaggingAPI = "resourcegroupstaggingapi"
+
+	// taggingGlobalRegion is where the tagging api reports tags of global resources.
+	taggingGlobalRegion = "us-east-1"
+)
+
+// TagEnricher looks up resource tags through the Resource Groups Tagging API for resources
+// whose describers return none. The tag map of each region is fetched once and cached for
+// the lifetime of the enricher, which is a single describe job.
+type TagEnricher struct {
+	cfg    awssdk.Config
+	logger *zap.Logger
+
+	mu      sync.Mutex
+	regions map[string]*regionTags
+}
+
+type regionTags struct {
+	once sync.Once
+	tags map[string]map[string]string
+}
+
+func NewTagEnricher(cfg awssdk.Config, logger *zap.Logger) *TagEnricher {
+	return &TagEnricher{
+		cfg:     cfg,
+		logger:  logger,
+		regions: make(map[string]*regionTags),
+	}
+}
+
+// Lookup returns the tags of the resource identified by arn in region. A failure to call the
+// tagging api is logged and treated as an empty tag map, so enrichment never fails a job.
+func (e *TagEnricher) Lookup(ctx context.Context, region, arn string) map[string]string {
+	if arn == "" {
+		return nil
+	}
+	if region == "" || region == "global" {
+		region = taggingGlobalRegion
+	}
+
+	e.mu.Lock()
+	rt, ok := e.regions[region]
+	if !ok {
+		rt = &regionTags{}
+		e.regions[region] = rt
+	}
+	e.mu.Unlock()
+
+	rt.once.Do(func() {
+		cfg := e.cfg.Copy()
+		cfg.Region = region
+
+		tags, err := describer.TaggingResourceTags(ctx, cfg)
+		if err != nil {
+			e.logger.Warn("failed to fetch tags from tagging api", zap.String("region", region), zap.Error(err))
+			return
+		}
+		e.logger.Info("fetched tags from tagging api", zap.String("region", region), zap.Int("resources", len(tags)))
+		rt.tags = tags
+	})
+
+	return rt.tags[arn]
+}
+
+// Enrich returns the tags of a resource along with where they came from. Tags returned by the
+// describer are kept as is, otherwise the tagging api tags for arn are used.
+func (e *TagEnricher) Enrich(ctx context.Context, region, arn string, tags map[string]string) (map[string]string, string) {
+	if len(tags) > 0 {
+		return tags, TagsSourceDescriber
+	}
+	if e == nil {
+		return tags, ""
+	}
+
+	apiTags := e.Lookup(ctx, region, arn)
+	if len(apiTags) == 0 {
+		return tags, ""
+	}
+
+	merged := make(map[string]string, len(apiTags))
+	for k, v := range apiTags {
+		merged[k] = v
+	}
+	return merged, TagsSourceTaggingAPI
+}
@@ -0,0 +1,130 @@
+package aws
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"go.uber.org/zap"
+)
+
+// taggingAPI answers the GetResources calls of the tagging api with the tags of its regions,
+// regions missing from tags fail with AccessDenied.
+type taggingAPI struct {
+	tags map[string]string
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (api *taggingAPI) Do(req *http.Request) (*http.Response, error) {
+	// the host is tagging.<region>.amazonaws.com.
+	region := strings.Split(req.URL.Host, ".")[1]
+	api.mu.Lock()
+	api.calls[region]++
+	api.mu.Unlock()
+
+	status, body := http.StatusOK, api.tags[region]
+	if body == "" {
+		status, body = http.StatusBadRequest, `{"__type":"AccessDeniedException","message":"denied"}`
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/x-amz-json-1.1"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func newTestTagEnricher(api *taggingAPI) *TagEnricher {
+	return NewTagEnricher(awssdk.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "secret", ""),
+		HTTPClient:  api,
+		Retryer: func() awssdk.Retryer {
+			return awssdk.NopRetryer{}
+		},
+	}, zap.NewNop())
+}
+
+const instanceARN = "arn:aws:ec2:eu-west-1:123456789012:instance/i-1"
+
+func TestTagEnricherCachesRegions(t *testing.T) {
+	api := &taggingAPI{
+		tags: map[string]string{
+			"eu-west-1": `{"ResourceTagMappingList":[{"ResourceARN":"` + instanceARN + `","Tags":[{"Key":"env","Value":"prod"}]}]}`,
+			"us-east-1": `{"ResourceTagMappingList":[]}`,
+		},
+		calls: map[string]int{},
+	}
+	e := newTestTagEnricher(api)
+
+	for i := 0; i < 3; i++ {
+		if got := e.Lookup(context.Background(), "eu-west-1", instanceARN); !reflect.DeepEqual(got, map[string]string{"env": "prod"}) {
+			t.Errorf("got tags %v", got)
+		}
+	}
+	// global resources are looked up in us-east-1.
+	e.Lookup(context.Background(), "global", "arn:aws:iam::123456789012:role/r")
+	e.Lookup(context.Background(), "", "arn:aws:iam::123456789012:role/r")
+
+	if want := map[string]int{"eu-west-1": 1, "us-east-1": 1}; !reflect.DeepEqual(api.calls, want) {
+		t.Errorf("got calls %v, want %v", api.calls, want)
+	}
+}
+
+func TestTagEnricherEnrich(t *testing.T) {
+	api := &taggingAPI{
+		tags: map[string]string{
+			"eu-west-1": `{"ResourceTagMappingList":[{"ResourceARN":"` + instanceARN + `","Tags":[{"Key":"env","Value":"prod"}]}]}`,
+		},
+		calls: map[string]int{},
+	}
+	e := newTestTagEnricher(api)
+	ctx := context.Background()
+
+	tags, source := e.Enrich(ctx, "eu-west-1", instanceARN, map[string]string{"team": "a"})
+	if !reflect.DeepEqual(tags, map[string]string{"team": "a"}) || source != TagsSourceDescriber {
+		t.Errorf("describer tags: got %v from %q", tags, source)
+	}
+	if len(api.calls) != 0 {
+		t.Errorf("tagging api called for a resource with tags")
+	}
+
+	tags, source = e.Enrich(ctx, "eu-west-1", instanceARN, nil)
+	if !reflect.DeepEqual(tags, map[string]string{"env": "prod"}) || source != TagsSourceTaggingAPI {
+		t.Errorf("fallback: got %v from %q", tags, source)
+	}
+
+	tags, source = e.Enrich(ctx, "eu-west-1", "arn:aws:ec2:eu-west-1:123456789012:instance/i-2", nil)
+	if len(tags) != 0 || source != "" {
+		t.Errorf("untagged: got %v from %q", tags, source)
+	}
+
+	var nilEnricher *TagEnricher
+	if tags, source = nilEnricher.Enrich(ctx, "eu-west-1", instanceARN, nil); len(tags) != 0 || source != "" {
+		t.Errorf("nil enricher: got %v from %q", tags, source)
+	}
+}
+
+func TestTagEnricherError(t *testing.T) {
+	api := &taggingAPI{calls: map[string]int{}}
+	e := newTestTagEnricher(api)
+
+	for i := 0; i < 2; i++ {
+		tags, source := e.Enrich(context.Background(), "eu-west-1", instanceARN, nil)
+		if len(tags) != 0 || source != "" {
+			t.Errorf("got %v from %q on a tagging api failure", tags, source)
+		}
+	}
+	// the failure is cached too, the job does not retry it for every resource.
+	if api.calls["eu-west-1"] != 1 {
+		t.Errorf("got %d calls, want 1", api.calls["eu-west-1"])
+	}
+}
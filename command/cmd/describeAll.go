@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opengovern/og-aws-describer/aws"
+	"github.com/opengovern/og-aws-describer/aws/describer"
+	"github.com/opengovern/og-util/pkg/describe/enums"
+	"github.com/opengovern/og-util/pkg/es"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	accountIDs, resourceTypes, regions []string
+	outputDir, profile                 string
+	gzipOutput, fastOnly               bool
+	concurrency                        int
+)
+
+// Manifest describes the files written by describe-all.
+type Manifest struct {
+	StartedAt     time.Time       `json:"startedAt"`
+	FinishedAt    time.Time       `json:"finishedAt"`
+	Accounts      []string        `json:"accounts"`
+	Regions       []string        `json:"regions,omitempty"`
+	ResourceTypes []ManifestEntry `json:"resourceTypes"`
+}
+
+// ManifestEntry is the outcome of describing one resource type in one account.
+type ManifestEntry struct {
+	AccountID    string            `json:"accountId"`
+	ResourceType string            `json:"resourceType"`
+	File         string            `json:"file"`
+	Count        int               `json:"count"`
+	DurationMs   int64             `json:"durationMs"`
+	Error        string            `json:"error,omitempty"`
+	RegionErrors map[string]string `json:"regionErrors,omitempty"`
+}
+
+// describeAllCmd represents the describe-all command
+var describeAllCmd = &cobra.Command{
+	Use:   "describe-all",
+	Short: "Describe every resource type of one or more accounts into a directory of JSONL files",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger, _ := zap.NewProduction()
+		if outputDir == "" {
+			return fmt.Errorf("--output is required")
+		}
+		if len(accountIDs) == 0 {
+			return fmt.Errorf("at least one --accountID is required")
+		}
+		if concurrency < 1 {
+			concurrency = 1
+		}
+		if profile != "" {
+			// GetConfig loads the default config chain, which picks the profile up.
+			if err := os.Setenv("AWS_PROFILE", profile); err != nil {
+				return err
+			}
+		}
+
+		types, err := describeAllResourceTypes()
+		if err != nil {
+			return err
+		}
+
+		manifest := Manifest{
+			StartedAt: time.Now().UTC(),
+			Accounts:  accountIDs,
+			Regions:   regions,
+		}
+
+		type job struct {
+			accountID    string
+			resourceType string
+		}
+		jobs := make(chan job)
+		entries := make(chan ManifestEntry)
+
+		var wg sync.WaitGroup
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := range jobs {
+					entries <- describeAllResourceType(cmd.Context(), logger, j.accountID, j.resourceType)
+				}
+			}()
+		}
+		go func() {
+			for _, account := range accountIDs {
+				for _, resourceType := range types {
+					jobs <- job{accountID: account, resourceType: resourceType}
+				}
+			}
+			close(jobs)
+			wg.Wait()
+			close(entries)
+		}()
+
+		failed := 0
+		for entry := range entries {
+			if entry.Error != "" {
+				failed++
+				logger.Warn("failed to describe resource type", zap.String("accountID", entry.AccountID),
+					zap.String("resourceType", entry.ResourceType), zap.String("error", entry.Error))
+			}
+			manifest.ResourceTypes = append(manifest.ResourceTypes, entry)
+		}
+		sort.Slice(manifest.ResourceTypes, func(i, j int) bool {
+			a, b := manifest.ResourceTypes[i], manifest.ResourceTypes[j]
+			if a.AccountID != b.AccountID {
+				return a.AccountID < b.AccountID
+			}
+			return a.ResourceType < b.ResourceType
+		})
+		manifest.FinishedAt = time.Now().UTC()
+
+		js, err := json.MarshalIndent(manifest, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(outputDir, "manifest.json"), js, 0o644); err != nil {
+			return err
+		}
+		logger.Info("described inventory", zap.Int("resourceTypes", len(manifest.ResourceTypes)), zap.Int("failed", failed))
+		// the inventory and the manifest are written anyway, the failures are listed in the manifest
+		if failed > 0 {
+			return fmt.Errorf("%d of %d resource types failed, see %s", failed, len(manifest.ResourceTypes), filepath.Join(outputDir, "manifest.json"))
+		}
+		return nil
+	},
+}
+
+// describeAllResourceTypes returns the resource types selected by --resourceTypes and --fastOnly.
+func describeAllResourceTypes() ([]string, error) {
+	all := aws.ListResourceTypes()
+	if fastOnly {
+		all = aws.ListFastDiscoveryResourceTypes()
+	}
+	if len(resourceTypes) == 0 {
+		return all, nil
+	}
+
+	selected := map[string]bool{}
+	for _, name := range resourceTypes {
+		rt, err := aws.GetResourceType(name)
+		if err != nil {
+			return nil, err
+		}
+		selected[rt.ResourceName] = true
+	}
+	var types []string
+	for _, name := range all {
+		if selected[name] {
+			types = append(types, name)
+		}
+	}
+	return types, nil
+}
+
+// describeAllResourceType describes resourceType in accountID, streaming the resources into
+// the JSONL file of the type.
+func describeAllResourceType(ctx context.Context, logger *zap.Logger, accountID, resourceType string) ManifestEntry {
+	started := time.Now()
+	file := filepath.Join(accountID, es.ResourceTypeToESIndex(resourceType)+".jsonl")
+	if gzipOutput {
+		file += ".gz"
+	}
+	entry := ManifestEntry{
+		AccountID:    accountID,
+		ResourceType: resourceType,
+		File:         file,
+	}
+
+	w, err := newJSONLWriter(filepath.Join(outputDir, file), gzipOutput)
+	if err != nil {
+		entry.Error = err.Error()
+		return entry
+	}
+
+	f := func(resource describer.Resource) error {
+		if resource.Description == nil {
+			return nil
+		}
+		partition, _ := aws.PartitionOf(resource.Region)
+		if partition == "" {
+			partition = "aws"
+		}
+		resource.Account = accountID
+		resource.Type = strings.ToLower(resourceType)
+		resource.Partition = partition
+		return w.Write(resource)
+	}
+	stream := (*describer.StreamSender)(&f)
+
+	externalIdPtr := &externalId
+	if externalId == "" {
+		externalIdPtr = nil
+	}
+	output, err := aws.GetResources(
+		ctx, logger,
+		resourceType, enums.DescribeTriggerTypeManual,
+		accountID, regions,
+		credentialAccountId, accessKey, secretKey, "", assumeRoleArn, "", externalIdPtr,
+		endpointConfig(), false, stream)
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		for region, regionErr := range output.Errors {
+			if regionErr == "" {
+				continue
+			}
+			if entry.RegionErrors == nil {
+				entry.RegionErrors = map[string]string{}
+			}
+			entry.RegionErrors[region] = regionErr
+		}
+	}
+
+	entry.Count, err = w.Close()
+	if err != nil && entry.Error == "" {
+		entry.Error = err.Error()
+	}
+	entry.DurationMs = time.Since(started).Milliseconds()
+	return entry
+}
+
+// jsonlWriter writes one JSON document per line, it is safe for concurrent use since the
+// regional describers stream from one goroutine per region.
+type jsonlWriter struct {
+	mu    sync.Mutex
+	file  *os.File
+	gz    *gzip.Writer
+	buf   *bufio.Writer
+	enc   *json.Encoder
+	count int
+}
+
+func newJSONLWriter(path string, compress bool) (*jsonlWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &jsonlWriter{file: file}
+	var out io.Writer = file
+	if compress {
+		w.gz = gzip.NewWriter(file)
+		out = w.gz
+	}
+	w.buf = bufio.NewWriter(out)
+	w.enc = json.NewEncoder(w.buf)
+	return w, nil
+}
+
+func (w *jsonlWriter) Write(v any) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.enc.Encode(v); err != nil {
+		return err
+	}
+	w.count++
+	return nil
+}
+
+// Close flushes the file and returns the number of documents written.
+func (w *jsonlWriter) Close() (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	err := w.buf.Flush()
+	if w.gz != nil {
+		if gzErr := w.gz.Close(); err == nil {
+			err = gzErr
+		}
+	}
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	return w.count, err
+}
+
+func init() {
+	describeAllCmd.Flags().StringSliceVar(&accountIDs, "accountID", nil, "Account IDs to describe, repeat or comma separate for several")
+	describeAllCmd.Flags().StringSliceVar(&resourceTypes, "resourceType", nil, "Resource types to describe, all of them when empty")
+	describeAllCmd.Flags().StringSliceVar(&regions, "region", nil, "Regions to describe, every enabled region when empty")
+	describeAllCmd.Flags().BoolVar(&fastOnly, "fastOnly", false, "Describe the fast discovery resource types only")
+	describeAllCmd.Flags().IntVar(&concurrency, "concurrency", 4, "Number of resource types described at the same time")
+	describeAllCmd.Flags().StringVar(&outputDir, "output", "", "Directory the JSONL files and the manifest are written to")
+	describeAllCmd.Flags().BoolVar(&gzipOutput, "gzip", false, "Gzip the JSONL files")
+	describeAllCmd.Flags().StringVar(&profile, "profile", "", "AWS shared config profile")
+	describeAllCmd.Flags().StringVar(&accessKey, "accessKey", "", "Access key")
+	describeAllCmd.Flags().StringVar(&secretKey, "secretKey", "", "Secret key")
+	describeAllCmd.Flags().StringVar(&assumeRoleArn, "assumeRoleName", "", "Assume role name")
+	describeAllCmd.Flags().StringVar(&externalId, "externalId", "", "externalId")
+	describeAllCmd.Flags().StringVar(&credentialAccountId, "credentialAccountId", "", "Credential account id")
+	addEndpointFlags(describeAllCmd)
+}
@@ -30,7 +30,7 @@ var describerCmd = &cobra.Command{
 		}
 
 		logger.Info("getting config")
-		cfg, err := aws.GetConfig(context.Background(), accessKey, secretKey, "", assumeRoleArn, externalIdPtr)
+		cfg, err := aws.GetConfig(context.Background(), accessKey, secretKey, "", assumeRoleArn, externalIdPtr, endpointConfig())
 		if err != nil {
 			return fmt.Errorf("AWS: %w", err)
 		}
@@ -46,7 +46,7 @@ var describerCmd = &cobra.Command{
 			resourceType, enums.DescribeTriggerTypeManual,
 			accountID, nil,
 			credentialAccountId, accessKey, secretKey, "", assumeRoleArn, "", externalIdPtr,
-			false, nil)
+			endpointConfig(), false, nil)
 		if err != nil {
 			return fmt.Errorf("AWS: %w", err)
 		}
@@ -69,4 +69,5 @@ func init() {
 	describerCmd.Flags().StringVar(&assumeRoleArn, "assumeRoleName", "", "Assume role name")
 	describerCmd.Flags().StringVar(&externalId, "externalId", "", "externalId")
 	describerCmd.Flags().StringVar(&credentialAccountId, "credentialAccountId", "", "Credential account id")
+	addEndpointFlags(describerCmd)
 }
@@ -0,0 +1,420 @@
+package cmd
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/opengovern/og-aws-describer/aws/describer"
+	jobdescriber "github.com/opengovern/og-aws-describer/describer"
+	"github.com/spf13/cobra"
+)
+
+var diffTags []string
+
+// exportedResource is a line of an inventory export. It is either a describer.Resource, as
+// written by describe-all, or a resource document as sent to the sink, optionally wrapped in a
+// search hit. Field names are matched case insensitively, so both forms fit.
+type exportedResource struct {
+	ARN         string          `json:"arn"`
+	ID          string          `json:"id"`
+	Description json.RawMessage `json:"description"`
+	Name        string          `json:"name"`
+	Account     string          `json:"account"`
+	Region      string          `json:"region"`
+	Partition   string          `json:"partition"`
+	Type        string          `json:"type"`
+
+	ResourceType  string            `json:"resource_type"`
+	Location      string            `json:"location"`
+	SourceID      string            `json:"source_id"`
+	CanonicalTags []exportedTag     `json:"canonical_tags"`
+	Metadata      map[string]string `json:"metadata"`
+	Source        *exportedResource `json:"_source"`
+}
+
+type exportedTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// InventoryResource is a resource of an inventory export.
+type InventoryResource struct {
+	UniqueID     string            `json:"uniqueId"`
+	ResourceType string            `json:"resourceType"`
+	Region       string            `json:"region"`
+	Name         string            `json:"name"`
+	Tags         map[string]string `json:"tags,omitempty"`
+	Description  any               `json:"-"`
+}
+
+// ModifiedResource is a resource whose description differs between the two exports.
+type ModifiedResource struct {
+	InventoryResource
+	Patch []jobdescriber.PatchOperation `json:"patch"`
+}
+
+// InventoryDiff is the difference between two inventory exports.
+type InventoryDiff struct {
+	Added    []InventoryResource `json:"added"`
+	Removed  []InventoryResource `json:"removed"`
+	Modified []ModifiedResource  `json:"modified"`
+}
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff <before> <after>",
+	Short: "Compare two inventory exports, describe-all directories or sink snapshot files",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		before, err := loadInventory(args[0])
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+		after, err := loadInventory(args[1])
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[1], err)
+		}
+
+		tags, err := parseTagFilters(diffTags)
+		if err != nil {
+			return err
+		}
+
+		diff := diffInventories(before, after, tags)
+
+		return printOutput(diff, func(w io.Writer) {
+			for _, r := range diff.Added {
+				fmt.Fprintf(w, "+ %s\t%s\t%s\n", r.ResourceType, r.Region, r.UniqueID)
+			}
+			for _, r := range diff.Removed {
+				fmt.Fprintf(w, "- %s\t%s\t%s\n", r.ResourceType, r.Region, r.UniqueID)
+			}
+			for _, r := range diff.Modified {
+				fmt.Fprintf(w, "~ %s\t%s\t%s\n", r.ResourceType, r.Region, r.UniqueID)
+				for _, op := range r.Patch {
+					if op.Op == "remove" {
+						fmt.Fprintf(w, "    %s %s\n", op.Op, op.Path)
+						continue
+					}
+					value, _ := json.Marshal(op.Value)
+					fmt.Fprintf(w, "    %s %s = %s\n", op.Op, op.Path, value)
+				}
+			}
+			fmt.Fprintf(w, "%d added, %d removed, %d modified\n", len(diff.Added), len(diff.Removed), len(diff.Modified))
+		})
+	},
+}
+
+// inventoryKey identifies a resource of an inventory. The unique id alone is not enough, resource
+// types derived from another, e.g. the backup coverage or the network exposure of a resource,
+// reuse the ARN of the resource they are about. The resource type is lower cased since sink
+// documents lower case it.
+type inventoryKey struct {
+	ResourceType string
+	UniqueID     string
+}
+
+func inventoryKeyOf(r InventoryResource) inventoryKey {
+	return inventoryKey{ResourceType: strings.ToLower(r.ResourceType), UniqueID: r.UniqueID}
+}
+
+// loadInventory reads the export at path, a directory of JSONL files as written by
+// describe-all or a single JSONL or JSON array file, both optionally gzipped, and returns its
+// resources, filtered by --resourceType and --region, by resource type and unique id.
+func loadInventory(path string) (map[inventoryKey]InventoryResource, error) {
+	inventory := map[inventoryKey]InventoryResource{}
+	err := walkExport(path, func(e exportedResource) error {
+		r, err := inventoryResource(e)
+		if err != nil {
+			return err
+		}
+		if len(resourceTypes) > 0 && !containsFold(resourceTypes, r.ResourceType) {
+			return nil
+		}
+		if len(regions) > 0 && !containsFold(regions, r.Region) {
+			return nil
+		}
+		inventory[inventoryKeyOf(r)] = r
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inventory, nil
+}
+
+// diffInventories returns the resources added to, removed from and modified between before and
+// after, of the resources with the tags in either of them.
+func diffInventories(before, after map[inventoryKey]InventoryResource, tags map[string]*string) InventoryDiff {
+	diff := InventoryDiff{
+		Added:    []InventoryResource{},
+		Removed:  []InventoryResource{},
+		Modified: []ModifiedResource{},
+	}
+	for key, b := range before {
+		a, ok := after[key]
+		if !ok {
+			if matchesInventoryFilters(b, tags) {
+				diff.Removed = append(diff.Removed, b)
+			}
+			continue
+		}
+		if !matchesInventoryFilters(a, tags) && !matchesInventoryFilters(b, tags) {
+			continue
+		}
+		if patch := jobdescriber.JSONPatch(b.Description, a.Description); len(patch) > 0 {
+			diff.Modified = append(diff.Modified, ModifiedResource{InventoryResource: a, Patch: patch})
+		}
+	}
+	for key, a := range after {
+		if _, ok := before[key]; !ok && matchesInventoryFilters(a, tags) {
+			diff.Added = append(diff.Added, a)
+		}
+	}
+	sortInventoryResources(diff.Added)
+	sortInventoryResources(diff.Removed)
+	sort.Slice(diff.Modified, func(i, j int) bool {
+		return inventoryResourceLess(diff.Modified[i].InventoryResource, diff.Modified[j].InventoryResource)
+	})
+	return diff
+}
+
+// walkExport calls f with every resource of the export at path, a directory of JSONL files or a
+// single file.
+func walkExport(path string, f func(exportedResource) error) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	var files []string
+	if info.IsDir() {
+		err := filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && (strings.HasSuffix(p, ".jsonl") || strings.HasSuffix(p, ".jsonl.gz")) {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		files = []string{path}
+	}
+
+	for _, file := range files {
+		if err := readExportFile(file, f); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+func readExportFile(path string, f func(exportedResource) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	reader := bufio.NewReader(r)
+	first, err := reader.Peek(1)
+	if err == io.EOF {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(reader)
+	if first[0] == '[' {
+		var resources []exportedResource
+		if err := dec.Decode(&resources); err != nil {
+			return err
+		}
+		for _, e := range resources {
+			if err := f(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for {
+		var e exportedResource
+		if err := dec.Decode(&e); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := f(e); err != nil {
+			return err
+		}
+	}
+}
+
+func inventoryResource(e exportedResource) (InventoryResource, error) {
+	if e.Source != nil {
+		e = *e.Source
+	}
+
+	var description any
+	if len(e.Description) > 0 {
+		if err := json.Unmarshal(e.Description, &description); err != nil {
+			return InventoryResource{}, err
+		}
+	}
+
+	var r InventoryResource
+	if e.ResourceType != "" {
+		// a sink document, its id is the unique id of the resource
+		r = InventoryResource{
+			UniqueID:     e.ID,
+			ResourceType: e.ResourceType,
+			Region:       e.Location,
+			Name:         e.Name,
+		}
+		if len(e.CanonicalTags) > 0 {
+			r.Tags = map[string]string{}
+			for _, t := range e.CanonicalTags {
+				r.Tags[t.Key] = t.Value
+			}
+		}
+	} else {
+		resource := describer.Resource{
+			ARN:       e.ARN,
+			ID:        e.ID,
+			Name:      e.Name,
+			Account:   e.Account,
+			Region:    e.Region,
+			Partition: e.Partition,
+			Type:      e.Type,
+		}
+		r = InventoryResource{
+			UniqueID:     resource.UniqueID(),
+			ResourceType: e.Type,
+			Region:       e.Region,
+			Name:         e.Name,
+			Tags:         descriptionTags(description),
+		}
+	}
+
+	canonical, err := jobdescriber.CanonicalDescription(r.ResourceType, description)
+	if err != nil {
+		return InventoryResource{}, err
+	}
+	r.Description = canonical
+	return r, nil
+}
+
+// descriptionTags finds the tags of a description, the Tags, TagList or TagSet field of the
+// description or of one of its top level fields, either a map or a list of Key and Value pairs.
+func descriptionTags(description any) map[string]string {
+	obj, ok := description.(map[string]any)
+	if !ok {
+		return nil
+	}
+	if tags := tagsField(obj); tags != nil {
+		return tags
+	}
+	for _, v := range obj {
+		if nested, ok := v.(map[string]any); ok {
+			if tags := tagsField(nested); tags != nil {
+				return tags
+			}
+		}
+	}
+	return nil
+}
+
+func tagsField(obj map[string]any) map[string]string {
+	for _, field := range []string{"Tags", "TagList", "TagSet"} {
+		switch v := obj[field].(type) {
+		case map[string]any:
+			tags := map[string]string{}
+			for key, value := range v {
+				tags[key] = fmt.Sprint(value)
+			}
+			return tags
+		case []any:
+			tags := map[string]string{}
+			for _, item := range v {
+				if t, ok := item.(map[string]any); ok {
+					key, _ := t["Key"].(string)
+					value, _ := t["Value"].(string)
+					tags[key] = value
+				}
+			}
+			return tags
+		}
+	}
+	return nil
+}
+
+// parseTagFilters parses key=value tag filters, a filter without a value matches any value.
+func parseTagFilters(filters []string) (map[string]*string, error) {
+	tags := map[string]*string{}
+	for _, filter := range filters {
+		key, value, hasValue := strings.Cut(filter, "=")
+		if key == "" {
+			return nil, fmt.Errorf("invalid tag filter %s, expected key or key=value", filter)
+		}
+		if hasValue {
+			tags[key] = &value
+		} else {
+			tags[key] = nil
+		}
+	}
+	return tags, nil
+}
+
+func matchesInventoryFilters(r InventoryResource, tags map[string]*string) bool {
+	for key, want := range tags {
+		value, ok := r.Tags[key]
+		if !ok || (want != nil && value != *want) {
+			return false
+		}
+	}
+	return true
+}
+
+func inventoryResourceLess(a, b InventoryResource) bool {
+	if a.ResourceType != b.ResourceType {
+		return a.ResourceType < b.ResourceType
+	}
+	if a.Region != b.Region {
+		return a.Region < b.Region
+	}
+	return a.UniqueID < b.UniqueID
+}
+
+func sortInventoryResources(resources []InventoryResource) {
+	sort.Slice(resources, func(i, j int) bool {
+		return inventoryResourceLess(resources[i], resources[j])
+	})
+}
+
+func init() {
+	diffCmd.Flags().StringSliceVar(&resourceTypes, "resourceType", nil, "Only the resources of the resource types")
+	diffCmd.Flags().StringSliceVar(&regions, "region", nil, "Only the resources of the regions")
+	diffCmd.Flags().StringSliceVar(&diffTags, "tag", nil, "Only the resources with the tag, key or key=value")
+	diffCmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format, table, json or yaml")
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeExport(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "export.jsonl")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestDiffSharedARN checks resources of different types sharing an ARN, e.g. a DB instance and
+// its backup coverage, are diffed separately.
+func TestDiffSharedARN(t *testing.T) {
+	arn := "arn:aws:rds:us-east-1:123456789012:db:orders"
+	before, err := loadInventory(writeExport(t,
+		`{"arn":"`+arn+`","type":"AWS::RDS::DBInstance","region":"us-east-1","description":{"DBInstance":{"DBInstanceClass":"db.t3.micro"}}}
+{"arn":"`+arn+`","type":"AWS::Backup::ResourceCoverage","region":"us-east-1","description":{"IsProtected":false}}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := loadInventory(writeExport(t,
+		`{"arn":"`+arn+`","type":"AWS::Backup::ResourceCoverage","region":"us-east-1","description":{"IsProtected":true}}
+{"arn":"`+arn+`","type":"AWS::RDS::DBInstance","region":"us-east-1","description":{"DBInstance":{"DBInstanceClass":"db.t3.micro"}}}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(before) != 2 || len(after) != 2 {
+		t.Fatalf("loaded %d and %d resources, want 2 of each", len(before), len(after))
+	}
+
+	diff := diffInventories(before, after, nil)
+	if len(diff.Added) != 0 || len(diff.Removed) != 0 {
+		t.Errorf("added %v, removed %v, want none", diff.Added, diff.Removed)
+	}
+	if len(diff.Modified) != 1 || diff.Modified[0].ResourceType != "AWS::Backup::ResourceCoverage" {
+		t.Fatalf("modified %v, want the backup coverage only", diff.Modified)
+	}
+	if patch := diff.Modified[0].Patch; len(patch) != 1 || patch[0].Path != "/IsProtected" {
+		t.Errorf("patch %v, want IsProtected replaced", patch)
+	}
+}
+
+// TestDiffResourceTypeCase checks a describe-all export and a sink snapshot, which lower cases
+// the resource type, match the same resources.
+func TestDiffResourceTypeCase(t *testing.T) {
+	arn := "arn:aws:s3:::logs"
+	before, err := loadInventory(writeExport(t,
+		`{"arn":"`+arn+`","type":"AWS::S3::Bucket","region":"us-east-1","description":{"Name":"logs"}}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := loadInventory(writeExport(t,
+		`{"id":"`+arn+`","resource_type":"aws::s3::bucket","location":"us-east-1","description":{"Name":"logs"}}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff := diffInventories(before, after, nil)
+	if len(diff.Added) != 0 || len(diff.Removed) != 0 || len(diff.Modified) != 0 {
+		t.Errorf("diff %+v, want no changes", diff)
+	}
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"github.com/opengovern/og-aws-describer/aws"
+	"github.com/spf13/cobra"
+)
+
+var (
+	endpointURL                    string
+	serviceEndpoints               map[string]string
+	s3ForcePathStyle, anonymousAWS bool
+)
+
+// addEndpointFlags adds the flags pointing the describers at an emulator rather than AWS.
+func addEndpointFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&endpointURL, "endpointURL", "", "Endpoint of every AWS service, a LocalStack or moto server for instance")
+	cmd.Flags().StringToStringVar(&serviceEndpoints, "endpoint", nil, "Endpoint of a single service, service=url, repeat for several")
+	cmd.Flags().BoolVar(&s3ForcePathStyle, "s3ForcePathStyle", false, "Address S3 buckets in the path of the endpoint rather than in its host, requires --endpointURL or an s3 --endpoint")
+	cmd.Flags().BoolVar(&anonymousAWS, "anonymous", false, "Send unsigned requests, for emulators that don't check credentials")
+}
+
+// endpointConfig returns the endpoints set by the flags of addEndpointFlags, nil if none is.
+func endpointConfig() *aws.EndpointConfig {
+	if endpointURL == "" && len(serviceEndpoints) == 0 && !s3ForcePathStyle && !anonymousAWS {
+		return nil
+	}
+	return &aws.EndpointConfig{
+		URL:              endpointURL,
+		Services:         serviceEndpoints,
+		S3ForcePathStyle: s3ForcePathStyle,
+		Anonymous:        anonymousAWS,
+	}
+}
@@ -38,6 +38,7 @@ var getDescriberCmd = &cobra.Command{
 			"",
 			"",
 			nil,
+			endpointConfig(),
 			false,
 			fields,
 		)
@@ -63,4 +64,5 @@ func init() {
 	getDescriberCmd.Flags().StringVar(&accountID, "accountID", "", "AccountID")
 	getDescriberCmd.Flags().StringVar(&accessKey, "accessKey", "", "Access key")
 	getDescriberCmd.Flags().StringVar(&secretKey, "secretKey", "", "Secret key")
+	addEndpointFlags(getDescriberCmd)
 }
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/opengovern/og-aws-describer/aws"
+	"github.com/opengovern/og-aws-describer/pkg/opengovernance-es-sdk"
+	"github.com/opengovern/og-util/pkg/es"
+	"github.com/spf13/cobra"
+)
+
+// describerKinds are the readable names of the list describer kinds.
+var describerKinds = map[string]string{
+	"ParallelDescribeRegional":   "parallel regional",
+	"SequentialDescribeRegional": "sequential regional",
+	"SequentialDescribeGlobal":   "sequential global",
+}
+
+// ResourceTypeDetails is a resource type as shown by inspect.
+type ResourceTypeDetails struct {
+	ResourceTypeSummary
+	Label                string            `json:"label"`
+	DescriberKind        string            `json:"describerKind"`
+	ListDescriber        string            `json:"listDescriber"`
+	GetDescriber         string            `json:"getDescriber,omitempty"`
+	Index                string            `json:"index"`
+	ListFilters          map[string]string `json:"listFilters"`
+	GetFilters           map[string]string `json:"getFilters"`
+	Actions              []string          `json:"actions"`
+	TerraformServiceName string            `json:"terraformServiceName"`
+}
+
+// inspectCmd represents the inspect command
+var inspectCmd = &cobra.Command{
+	Use:   "inspect <resource type>",
+	Short: "Show how a resource type is described, indexed and queried",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rt, err := aws.GetResourceType(args[0])
+		if err != nil {
+			return err
+		}
+
+		kind := rt.ListDescriberKind()
+		if v, ok := describerKinds[kind]; ok {
+			kind = v
+		}
+		info, _ := aws.GetDescriberInfo(rt.ResourceName)
+		details := ResourceTypeDetails{
+			ResourceTypeSummary:  resourceTypeSummary(*rt),
+			Label:                rt.ResourceLabel,
+			DescriberKind:        kind,
+			ListDescriber:        info.ListDescriber,
+			GetDescriber:         info.GetDescriber,
+			Index:                es.ResourceTypeToESIndex(rt.ResourceName),
+			ListFilters:          opengovernance.ListFilters[rt.ResourceName],
+			GetFilters:           opengovernance.GetFilters[rt.ResourceName],
+			Actions:              info.Actions,
+			TerraformServiceName: rt.TerraformServiceName,
+		}
+
+		return printOutput(details, func(w io.Writer) {
+			fmt.Fprintf(w, "Resource type:\t%s\n", details.ResourceType)
+			fmt.Fprintf(w, "Label:\t%s\n", details.Label)
+			fmt.Fprintf(w, "Service:\t%s\n", details.Service)
+			fmt.Fprintf(w, "Describer:\t%s (%s)\n", details.ListDescriber, details.DescriberKind)
+			fmt.Fprintf(w, "Get describer:\t%s\n", details.GetDescriber)
+			fmt.Fprintf(w, "Fast discovery:\t%t\n", details.FastDiscovery)
+			fmt.Fprintf(w, "Cost discovery:\t%t\n", details.CostDiscovery)
+			fmt.Fprintf(w, "Summarize:\t%t\n", details.Summarize)
+			fmt.Fprintf(w, "Steampipe table:\t%s\n", details.SteampipeTable)
+			fmt.Fprintf(w, "Index:\t%s\n", details.Index)
+			fmt.Fprintf(w, "Terraform:\t%s (%s)\n", strings.Join(details.TerraformNames, ", "), details.TerraformServiceName)
+			printFilters(w, "List filters:", details.ListFilters)
+			printFilters(w, "Get filters:", details.GetFilters)
+			fmt.Fprintln(w, "IAM actions:")
+			for _, action := range details.Actions {
+				fmt.Fprintf(w, "\t%s\n", action)
+			}
+		})
+	},
+}
+
+func printFilters(w io.Writer, title string, filters map[string]string) {
+	fmt.Fprintln(w, title)
+	columns := make([]string, 0, len(filters))
+	for column := range filters {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	for _, column := range columns {
+		fmt.Fprintf(w, "\t%s\t%s\n", column, filters[column])
+	}
+}
+
+func init() {
+	inspectCmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format, table, json or yaml")
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/opengovern/og-aws-describer/aws"
+	"github.com/opengovern/og-aws-describer/pkg/steampipe"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serviceFilter, terraformFilter, tableFilter              string
+	fastFilter, costFilter, summarizeFilter, hasGetDescriber bool
+)
+
+// ResourceTypeSummary is a resource type as listed by list-types.
+type ResourceTypeSummary struct {
+	ResourceType    string   `json:"resourceType"`
+	Service         string   `json:"service"`
+	SteampipeTable  string   `json:"steampipeTable"`
+	FastDiscovery   bool     `json:"fastDiscovery"`
+	CostDiscovery   bool     `json:"costDiscovery"`
+	Summarize       bool     `json:"summarize"`
+	HasGetDescriber bool     `json:"hasGetDescriber"`
+	TerraformNames  []string `json:"terraformNames"`
+}
+
+func resourceTypeSummary(rt aws.ResourceType) ResourceTypeSummary {
+	return ResourceTypeSummary{
+		ResourceType:    rt.ResourceName,
+		Service:         rt.ServiceName,
+		SteampipeTable:  steampipe.ExtractTableName(rt.ResourceName),
+		FastDiscovery:   rt.FastDiscovery,
+		CostDiscovery:   rt.CostDiscovery,
+		Summarize:       rt.Summarize,
+		HasGetDescriber: rt.GetDescriber != nil,
+		TerraformNames:  rt.TerraformName,
+	}
+}
+
+// listTypesCmd represents the list-types command
+var listTypesCmd = &cobra.Command{
+	Use:   "list-types",
+	Short: "List the registered resource types",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		types := aws.GetResourceTypesMap()
+
+		var summaries []ResourceTypeSummary
+		for _, name := range aws.ListResourceTypes() {
+			summary := resourceTypeSummary(types[name])
+			if serviceFilter != "" && !strings.EqualFold(summary.Service, serviceFilter) {
+				continue
+			}
+			if fastFilter && !summary.FastDiscovery {
+				continue
+			}
+			if costFilter && !summary.CostDiscovery {
+				continue
+			}
+			if summarizeFilter && !summary.Summarize {
+				continue
+			}
+			if hasGetDescriber && !summary.HasGetDescriber {
+				continue
+			}
+			if tableFilter != "" && !strings.EqualFold(summary.SteampipeTable, tableFilter) {
+				continue
+			}
+			if terraformFilter != "" && !containsFold(summary.TerraformNames, terraformFilter) {
+				continue
+			}
+			summaries = append(summaries, summary)
+		}
+
+		return printOutput(summaries, func(w io.Writer) {
+			fmt.Fprintln(w, "RESOURCE TYPE\tSERVICE\tSTEAMPIPE TABLE\tFAST\tCOST\tSUMMARIZE\tGET\tTERRAFORM")
+			for _, s := range summaries {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%t\t%t\t%t\t%s\n", s.ResourceType, s.Service, s.SteampipeTable,
+					s.FastDiscovery, s.CostDiscovery, s.Summarize, s.HasGetDescriber, strings.Join(s.TerraformNames, ","))
+			}
+		})
+	},
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	listTypesCmd.Flags().StringVar(&serviceFilter, "service", "", "Only the resource types of the service")
+	listTypesCmd.Flags().BoolVar(&fastFilter, "fast", false, "Only the fast discovery resource types")
+	listTypesCmd.Flags().BoolVar(&costFilter, "cost", false, "Only the cost discovery resource types")
+	listTypesCmd.Flags().BoolVar(&summarizeFilter, "summarize", false, "Only the summarized resource types")
+	listTypesCmd.Flags().BoolVar(&hasGetDescriber, "hasGetDescriber", false, "Only the resource types with a get describer")
+	listTypesCmd.Flags().StringVar(&terraformFilter, "terraform", "", "Only the resource types mapped to the Terraform resource")
+	listTypesCmd.Flags().StringVar(&tableFilter, "table", "", "Only the resource types of the Steampipe table")
+	listTypesCmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format, table, json or yaml")
+}
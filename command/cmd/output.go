@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ghodss/yaml"
+)
+
+var outputFormat string
+
+// printOutput writes v to stdout in the --output format, table uses printTable.
+func printOutput(v any, printTable func(w io.Writer)) error {
+	switch outputFormat {
+	case "", "table":
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		printTable(w)
+		return w.Flush()
+	case "json":
+		js, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(js))
+		return nil
+	case "yaml":
+		ys, err := yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(ys))
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %s, expected table, json or yaml", outputFormat)
+	}
+}
@@ -0,0 +1,313 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opengovern/og-aws-describer/aws"
+	"github.com/opengovern/og-aws-describer/aws/describer"
+	jobdescriber "github.com/opengovern/og-aws-describer/describer"
+	"github.com/opengovern/og-aws-describer/pkg/steampipe"
+	"github.com/opengovern/og-util/pkg/source"
+	"github.com/spf13/cobra"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"go.uber.org/zap"
+	_ "modernc.org/sqlite"
+)
+
+var queryDatabase string
+
+// queryCmd represents the query command
+var queryCmd = &cobra.Command{
+	Use:   "query <export> <sql>",
+	Short: "Run a SQL query over an inventory export, without Steampipe or OpenSearch",
+	Long: `Load an inventory export, a describe-all directory or a sink snapshot file, into an
+embedded SQLite database and run a query over it. Every table of the plugin is created, with the
+columns of the plugin table computed the same way the hosted tables are. JSON columns are stored
+as text, use the SQLite json functions to query them.
+
+Pass - as the query to read it from stdin.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger, _ := zap.NewProduction()
+
+		query := args[1]
+		if query == "-" {
+			content, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return err
+			}
+			query = string(content)
+		}
+
+		db, err := sql.Open("sqlite", queryDatabase)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		// An in memory database only lives as long as its connection.
+		db.SetMaxOpenConns(1)
+
+		if err := loadQueryTables(cmd.Context(), logger, db, steampipe.Plugin(), args[0]); err != nil {
+			return err
+		}
+
+		rows, err := db.QueryContext(cmd.Context(), query)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		columns, err := rows.Columns()
+		if err != nil {
+			return err
+		}
+		var table [][]any
+		for rows.Next() {
+			values := make([]any, len(columns))
+			pointers := make([]any, len(columns))
+			for i := range values {
+				pointers[i] = &values[i]
+			}
+			if err := rows.Scan(pointers...); err != nil {
+				return err
+			}
+			for i, v := range values {
+				if b, ok := v.([]byte); ok {
+					values[i] = string(b)
+				}
+			}
+			table = append(table, values)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		result := make([]map[string]any, 0, len(table))
+		for _, values := range table {
+			row := map[string]any{}
+			for i, column := range columns {
+				row[column] = values[i]
+			}
+			result = append(result, row)
+		}
+		return printOutput(result, func(w io.Writer) {
+			fmt.Fprintln(w, strings.Join(columns, "\t"))
+			for _, values := range table {
+				cells := make([]string, len(values))
+				for i, v := range values {
+					if v == nil {
+						continue
+					}
+					cells[i] = fmt.Sprint(v)
+				}
+				fmt.Fprintln(w, strings.Join(cells, "\t"))
+			}
+		})
+	},
+}
+
+// loadQueryTables creates a table for every plugin table mapped to a resource type and inserts
+// the resources of the export at path into them. Resources whose columns cannot be computed are
+// skipped with a warning.
+func loadQueryTables(ctx context.Context, logger *zap.Logger, db *sql.DB, plg *plugin.Plugin, path string) error {
+	var tableNames []string
+	for tableName := range steampipe.AWSReverseMap {
+		if _, ok := plg.TableMap[tableName]; ok {
+			tableNames = append(tableNames, tableName)
+		}
+	}
+	sort.Strings(tableNames)
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	tableColumns := map[string][]*plugin.Column{}
+	inserts := map[string]*sql.Stmt{}
+	for _, tableName := range tableNames {
+		var columns []*plugin.Column
+		var definitions, names, params []string
+		seen := map[string]bool{}
+		for _, column := range plg.TableMap[tableName].Columns {
+			if column == nil || column.Transform == nil || seen[column.Name] {
+				continue
+			}
+			seen[column.Name] = true
+			columns = append(columns, column)
+			definitions = append(definitions, fmt.Sprintf("%s %s", quoteIdentifier(column.Name), sqliteColumnType(column.Type)))
+			names = append(names, quoteIdentifier(column.Name))
+			params = append(params, "?")
+		}
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", quoteIdentifier(tableName))); err != nil {
+			return fmt.Errorf("drop table %s: %w", tableName, err)
+		}
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("CREATE TABLE %s (%s)", quoteIdentifier(tableName), strings.Join(definitions, ", "))); err != nil {
+			return fmt.Errorf("create table %s: %w", tableName, err)
+		}
+		insert, err := tx.PrepareContext(ctx, fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+			quoteIdentifier(tableName), strings.Join(names, ", "), strings.Join(params, ", ")))
+		if err != nil {
+			return fmt.Errorf("prepare insert into %s: %w", tableName, err)
+		}
+		defer insert.Close()
+		tableColumns[tableName] = columns
+		inserts[tableName] = insert
+	}
+
+	inserted, skipped := 0, 0
+	err = walkExport(path, func(e exportedResource) error {
+		resourceType, doc, err := queryDocument(e)
+		if err != nil {
+			return err
+		}
+		tableName := steampipe.ExtractTableName(resourceType)
+		insert, ok := inserts[tableName]
+		if !ok {
+			logger.Warn("skipping resource without a table", zap.String("resourceType", resourceType), zap.String("id", doc.ID))
+			skipped++
+			return nil
+		}
+
+		record, err := steampipe.AWSResourceToRecord(nil, plg, resourceType, doc)
+		if err != nil {
+			logger.Warn("skipping resource", zap.String("resourceType", resourceType), zap.String("id", doc.ID), zap.Error(err))
+			skipped++
+			return nil
+		}
+
+		columns := tableColumns[tableName]
+		values := make([]any, len(columns))
+		for i, column := range columns {
+			values[i] = sqliteValue(record[column.Name])
+		}
+		if _, err := insert.ExecContext(ctx, values...); err != nil {
+			return fmt.Errorf("insert into %s: %w", tableName, err)
+		}
+		inserted++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("loaded export", zap.Int("resources", inserted), zap.Int("skipped", skipped))
+	return tx.Commit()
+}
+
+// queryDocument returns the resource type of an exported resource and the document the sink
+// would have indexed for it, the hydrate item of its plugin table. Resources written by
+// describe-all don't belong to an opengovernance connection, their account is used as the
+// SourceID the kaytu_account_id column is read from.
+func queryDocument(e exportedResource) (string, jobdescriber.Resource, error) {
+	if e.Source != nil {
+		e = *e.Source
+	}
+
+	if e.ResourceType != "" {
+		return e.ResourceType, jobdescriber.Resource{
+			ID:           e.ID,
+			ARN:          e.ARN,
+			Description:  e.Description,
+			SourceType:   source.CloudAWS,
+			ResourceType: e.ResourceType,
+			Name:         e.Name,
+			Location:     e.Location,
+			SourceID:     e.SourceID,
+			Metadata:     e.Metadata,
+		}, nil
+	}
+
+	resource := describer.Resource{
+		ARN:       e.ARN,
+		ID:        e.ID,
+		Name:      e.Name,
+		Account:   e.Account,
+		Region:    e.Region,
+		Partition: e.Partition,
+		Type:      e.Type,
+	}
+	uniqueID := resource.UniqueID()
+	metadata, err := aws.ResourceMetadata(&resource, e.Type, e.Account, e.Account)
+	if err != nil {
+		return "", jobdescriber.Resource{}, err
+	}
+	resourceType := strings.ToLower(e.Type)
+	return resourceType, jobdescriber.Resource{
+		ID:           uniqueID,
+		ARN:          e.ARN,
+		Description:  e.Description,
+		SourceType:   source.CloudAWS,
+		ResourceType: resourceType,
+		Name:         e.Name,
+		Location:     e.Region,
+		SourceID:     e.Account,
+		Metadata:     metadata,
+	}, nil
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func sqliteColumnType(t proto.ColumnType) string {
+	switch t {
+	case proto.ColumnType_BOOL, proto.ColumnType_INT:
+		return "INTEGER"
+	case proto.ColumnType_DOUBLE:
+		return "REAL"
+	default:
+		return "TEXT"
+	}
+}
+
+// sqliteValue converts a column value to the value stored in its SQLite column, JSON is stored
+// as text and timestamps as RFC 3339 text.
+func sqliteValue(c *proto.Column) any {
+	if c == nil {
+		return nil
+	}
+	switch v := c.Value.(type) {
+	case *proto.Column_DoubleValue:
+		return v.DoubleValue
+	case *proto.Column_IntValue:
+		return v.IntValue
+	case *proto.Column_StringValue:
+		return v.StringValue
+	case *proto.Column_BoolValue:
+		return v.BoolValue
+	case *proto.Column_JsonValue:
+		if !json.Valid(v.JsonValue) || string(v.JsonValue) == "null" {
+			return nil
+		}
+		return string(v.JsonValue)
+	case *proto.Column_TimestampValue:
+		if v.TimestampValue == nil {
+			return nil
+		}
+		return v.TimestampValue.AsTime().UTC().Format(time.RFC3339)
+	case *proto.Column_IpAddrValue:
+		return v.IpAddrValue
+	case *proto.Column_CidrRangeValue:
+		return v.CidrRangeValue
+	case *proto.Column_LtreeValue:
+		return v.LtreeValue
+	default:
+		return nil
+	}
+}
+
+func init() {
+	queryCmd.Flags().StringVar(&queryDatabase, "database", ":memory:", "SQLite database file the export is loaded into")
+	queryCmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format, table, json or yaml")
+}
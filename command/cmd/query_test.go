@@ -0,0 +1,27 @@
+package cmd
+
+import "testing"
+
+// TestQueryDocumentDescribeAll checks the rows of describe-all exports get the metadata the
+// worker indexes, with the account as the SourceID of kaytu_account_id.
+func TestQueryDocumentDescribeAll(t *testing.T) {
+	resourceType, doc, err := queryDocument(exportedResource{
+		ARN:     "arn:aws:s3:::logs",
+		Name:    "logs",
+		Account: "123456789012",
+		Region:  "us-east-1",
+		Type:    "AWS::S3::Bucket",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resourceType != "aws::s3::bucket" {
+		t.Errorf("resource type %s, want aws::s3::bucket", resourceType)
+	}
+	if doc.SourceID != "123456789012" || doc.Metadata["SourceID"] != "123456789012" {
+		t.Errorf("source id %q, metadata SourceID %q, want the account", doc.SourceID, doc.Metadata["SourceID"])
+	}
+	if doc.Metadata["AccountID"] != "123456789012" || doc.Metadata["Partition"] != "aws" {
+		t.Errorf("metadata %v, want the account and partition", doc.Metadata)
+	}
+}
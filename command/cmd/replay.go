@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	jobdescriber "github.com/opengovern/og-aws-describer/describer"
+	"github.com/opengovern/og-aws-describer/describer/describertest"
+	"github.com/opengovern/og-util/pkg/describe"
+	"github.com/opengovern/og-util/pkg/vault"
+	"github.com/opengovern/og-util/proto/src/golang"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var replayConfig string
+
+// replayCmd represents the replay command
+var replayCmd = &cobra.Command{
+	Use:   "replay <input>",
+	Short: "Run describe worker inputs against local fake scheduler and sink services, capturing their calls",
+	Long: `Run the describe worker inputs of a JSON or JSONL file, one input per line, the way the
+describe worker does, against an in-process scheduler DescribeService and EsSinkService.
+Every SetInProgress, Ingest and DeliverResult call is written to calls.jsonl in the output
+directory. The command fails when any of the jobs fails.
+
+With --config, the account config is read from a plaintext JSON file instead of decrypting
+the job config with the vault of the input.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger, _ := zap.NewProduction()
+		if outputDir == "" {
+			return fmt.Errorf("--output is required")
+		}
+
+		inputs, err := readDescribeWorkerInputs(args[0])
+		if err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+
+		var vaultSc vault.VaultSourceConfig
+		if replayConfig != "" {
+			content, err := os.ReadFile(replayConfig)
+			if err != nil {
+				return err
+			}
+			var config map[string]any
+			if err := json.Unmarshal(content, &config); err != nil {
+				return fmt.Errorf("%s: %w", replayConfig, err)
+			}
+			vaultSc = describertest.PlaintextVault{Config: config}
+		}
+
+		if err := os.MkdirAll(outputDir, 0o755); err != nil {
+			return err
+		}
+		calls, err := os.Create(filepath.Join(outputDir, "calls.jsonl"))
+		if err != nil {
+			return err
+		}
+		defer calls.Close()
+
+		server, err := describertest.Start(describertest.NewRecorder(calls))
+		if err != nil {
+			return err
+		}
+		defer server.Stop()
+
+		failed := 0
+		for _, input := range inputs {
+			input.JobEndpoint = server.Addr()
+			input.DeliverEndpoint = server.Addr()
+			input.EndpointAuth = false
+			input.UseOpenSearch = false
+
+			logger.Info("replaying describe job", zap.Uint("jobID", input.DescribeJob.JobID),
+				zap.String("resourceType", input.DescribeJob.ResourceType), zap.String("accountID", input.DescribeJob.AccountID))
+			if err := jobdescriber.DescribeHandlerWithVault(cmd.Context(), logger, jobdescriber.TriggeredByLocal, input, vaultSc); err != nil {
+				logger.Error("failed to replay describe job", zap.Uint("jobID", input.DescribeJob.JobID), zap.Error(err))
+				failed++
+			}
+		}
+		// the handler reports failed describes through the result it delivers, not its error
+		for _, call := range server.Recorder.Calls() {
+			if result, ok := call.Request.(*golang.DeliverResultRequest); ok && result.Status == jobdescriber.DescribeResourceJobFailed {
+				logger.Error("describe job failed", zap.Uint32("jobID", result.JobId), zap.String("error", result.Error))
+				failed++
+			}
+		}
+
+		logger.Info("replayed describe jobs", zap.Int("jobs", len(inputs)), zap.Int("failed", failed), zap.Int("calls", len(server.Recorder.Calls())))
+		if failed > 0 {
+			return fmt.Errorf("%d of %d describe jobs failed", failed, len(inputs))
+		}
+		return nil
+	},
+}
+
+// readDescribeWorkerInputs reads the describe worker inputs of path, a single JSON document or
+// one document per line.
+func readDescribeWorkerInputs(path string) ([]describe.DescribeWorkerInput, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var inputs []describe.DescribeWorkerInput
+	dec := json.NewDecoder(bufio.NewReader(file))
+	for dec.More() {
+		var input describe.DescribeWorkerInput
+		if err := dec.Decode(&input); err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, input)
+	}
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("no describe worker input")
+	}
+	return inputs, nil
+}
+
+func init() {
+	replayCmd.Flags().StringVar(&outputDir, "output", "", "Directory the captured calls are written to")
+	replayCmd.Flags().StringVar(&replayConfig, "config", "", "Plaintext JSON account config used instead of the vault")
+}
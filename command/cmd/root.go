@@ -19,6 +19,7 @@ var rootCmd = &cobra.Command{
 		var items []string
 		items = append(items, "describer")
 		items = append(items, "getDescriber")
+		items = append(items, "describe-all")
 		prompt := promptui.Select{
 			Label: "Please select the types of describer",
 			Items: items,
@@ -28,9 +29,12 @@ var rootCmd = &cobra.Command{
 			return fmt.Errorf("[workspaces] : %v", err)
 		}
 		typeDescriber := result
-		if typeDescriber == "describer" {
+		switch typeDescriber {
+		case "describer":
 			return describerCmd.Help()
-		} else {
+		case "describe-all":
+			return describeAllCmd.Help()
+		default:
 			return getDescriberCmd.Help()
 		}
 	},
@@ -46,4 +50,10 @@ func Execute() {
 func init() {
 	rootCmd.AddCommand(getDescriberCmd)
 	rootCmd.AddCommand(describerCmd)
+	rootCmd.AddCommand(describeAllCmd)
+	rootCmd.AddCommand(listTypesCmd)
+	rootCmd.AddCommand(inspectCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(replayCmd)
+	rootCmd.AddCommand(queryCmd)
 }
@@ -0,0 +1,226 @@
+package describer
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/opengovern/og-util/pkg/es"
+	"github.com/opengovern/og-util/pkg/source"
+)
+
+const (
+	ResourceChangeIndex = "aws_resource_changes"
+
+	ChangeTypeCreated  = "CREATED"
+	ChangeTypeModified = "MODIFIED"
+)
+
+// PatchOperation is a single RFC 6902 JSON patch operation.
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+// ResourceChange records how the description of a resource changed between two describes.
+type ResourceChange struct {
+	EsID    string `json:"es_id"`
+	EsIndex string `json:"es_index"`
+
+	ResourceID          string           `json:"resource_id"`
+	SourceType          source.Type      `json:"source_type"`
+	ResourceType        string           `json:"resource_type"`
+	SourceID            string           `json:"source_id"`
+	ResourceJobID       uint             `json:"resource_job_id"`
+	ChangeType          string           `json:"change_type"`
+	PreviousFingerprint string           `json:"previous_fingerprint,omitempty"`
+	Fingerprint         string           `json:"fingerprint"`
+	Patch               []PatchOperation `json:"patch,omitempty"`
+	CreatedAt           int64            `json:"created_at"`
+}
+
+func (r ResourceChange) KeysAndIndex() ([]string, string) {
+	return []string{
+		r.ResourceID,
+		r.SourceID,
+		r.Fingerprint,
+	}, ResourceChangeIndex
+}
+
+// DescriptionSnapshot is the last seen state of a resource.
+type DescriptionSnapshot struct {
+	Fingerprint string `json:"fingerprint"`
+	Description any    `json:"description"`
+}
+
+// SnapshotStore keeps the last seen snapshot of every resource, keyed by resource unique id.
+type SnapshotStore interface {
+	Get(ctx context.Context, resourceID string) (*DescriptionSnapshot, error)
+	Put(ctx context.Context, resourceID string, snapshot DescriptionSnapshot) error
+}
+
+// MemorySnapshotStore is a SnapshotStore that only lives as long as the process.
+type MemorySnapshotStore struct {
+	mu        sync.RWMutex
+	snapshots map[string]DescriptionSnapshot
+}
+
+func NewMemorySnapshotStore() *MemorySnapshotStore {
+	return &MemorySnapshotStore{snapshots: make(map[string]DescriptionSnapshot)}
+}
+
+func (s *MemorySnapshotStore) Get(_ context.Context, resourceID string) (*DescriptionSnapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	snapshot, ok := s.snapshots[resourceID]
+	if !ok {
+		return nil, nil
+	}
+	return &snapshot, nil
+}
+
+func (s *MemorySnapshotStore) Put(_ context.Context, resourceID string, snapshot DescriptionSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshots[resourceID] = snapshot
+	return nil
+}
+
+// ChangeTracker compares the fingerprint of described resources with the previous one kept in
+// a SnapshotStore and produces a change record whenever it differs.
+type ChangeTracker struct {
+	store SnapshotStore
+}
+
+func NewChangeTracker(store SnapshotStore) *ChangeTracker {
+	return &ChangeTracker{store: store}
+}
+
+// snapshotPrefetcher is implemented by the snapshot stores that load the snapshots of several
+// resources at once.
+type snapshotPrefetcher interface {
+	Prefetch(ctx context.Context, resourceIDs []string) error
+}
+
+// Prefetch loads the snapshots of resourceIDs ahead of tracking them if the store supports it.
+func (t *ChangeTracker) Prefetch(ctx context.Context, resourceIDs []string) error {
+	if p, ok := t.store.(snapshotPrefetcher); ok {
+		return p.Prefetch(ctx, resourceIDs)
+	}
+	return nil
+}
+
+// Track stores the new snapshot of resource and returns the change against the previous one,
+// or nil if the resource did not change.
+func (t *ChangeTracker) Track(ctx context.Context, resource es.Resource, fingerprint string, canonical any) (*ResourceChange, error) {
+	previous, err := t.store.Get(ctx, resource.ID)
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil && previous.Fingerprint == fingerprint {
+		return nil, nil
+	}
+
+	change := ResourceChange{
+		ResourceID:    resource.ID,
+		SourceType:    resource.SourceType,
+		ResourceType:  resource.ResourceType,
+		SourceID:      resource.SourceID,
+		ResourceJobID: resource.ResourceJobID,
+		ChangeType:    ChangeTypeCreated,
+		Fingerprint:   fingerprint,
+		CreatedAt:     resource.CreatedAt,
+	}
+	if previous != nil {
+		change.ChangeType = ChangeTypeModified
+		change.PreviousFingerprint = previous.Fingerprint
+		change.Patch = JSONPatch(previous.Description, canonical)
+	}
+	keys, idx := change.KeysAndIndex()
+	change.EsID = es.HashOf(keys...)
+	change.EsIndex = idx
+
+	if err := t.store.Put(ctx, resource.ID, DescriptionSnapshot{Fingerprint: fingerprint, Description: canonical}); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// JSONPatch returns the RFC 6902 operations that turn from into to. Both values are expected to
+// be generic json values as produced by encoding/json.
+func JSONPatch(from, to any) []PatchOperation {
+	var ops []PatchOperation
+	diffValues("", from, to, &ops)
+	return ops
+}
+
+func diffValues(path string, from, to any, ops *[]PatchOperation) {
+	switch f := from.(type) {
+	case map[string]any:
+		if t, ok := to.(map[string]any); ok {
+			diffObjects(path, f, t, ops)
+			return
+		}
+	case []any:
+		if t, ok := to.([]any); ok {
+			diffArrays(path, f, t, ops)
+			return
+		}
+	}
+	if !reflect.DeepEqual(from, to) {
+		*ops = append(*ops, PatchOperation{Op: "replace", Path: path, Value: to})
+	}
+}
+
+func diffObjects(path string, from, to map[string]any, ops *[]PatchOperation) {
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		p := path + "/" + escapePointer(k)
+		f, inFrom := from[k]
+		t, inTo := to[k]
+		switch {
+		case !inTo:
+			*ops = append(*ops, PatchOperation{Op: "remove", Path: p})
+		case !inFrom:
+			*ops = append(*ops, PatchOperation{Op: "add", Path: p, Value: t})
+		default:
+			diffValues(p, f, t, ops)
+		}
+	}
+}
+
+func diffArrays(path string, from, to []any, ops *[]PatchOperation) {
+	common := len(from)
+	if len(to) < common {
+		common = len(to)
+	}
+	for i := 0; i < common; i++ {
+		diffValues(path+"/"+strconv.Itoa(i), from[i], to[i], ops)
+	}
+	// removing from the end keeps the indexes of the remaining elements valid
+	for i := len(from) - 1; i >= common; i-- {
+		*ops = append(*ops, PatchOperation{Op: "remove", Path: path + "/" + strconv.Itoa(i)})
+	}
+	for i := common; i < len(to); i++ {
+		*ops = append(*ops, PatchOperation{Op: "add", Path: path + "/-", Value: to[i]})
+	}
+}
+
+func escapePointer(s string) string {
+	s = strings.ReplaceAll(s, "~", "~0")
+	return strings.ReplaceAll(s, "/", "~1")
+}
@@ -0,0 +1,287 @@
+package describer
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/opengovern/og-util/pkg/es"
+	"github.com/opengovern/og-util/pkg/source"
+)
+
+func jsonValue(t *testing.T, s string) any {
+	t.Helper()
+
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestJSONPatch(t *testing.T) {
+	tests := map[string]struct {
+		from, to string
+		want     []PatchOperation
+	}{
+		"equal": {
+			from: `{"a":1,"b":[1,2]}`,
+			to:   `{"b":[1,2],"a":1}`,
+		},
+		"objects": {
+			from: `{"a":1,"b":{"c":"x","d":true},"gone":1}`,
+			to:   `{"a":2,"b":{"c":"y","d":true},"new":[1]}`,
+			want: []PatchOperation{
+				{Op: "replace", Path: "/a", Value: float64(2)},
+				{Op: "replace", Path: "/b/c", Value: "y"},
+				{Op: "remove", Path: "/gone"},
+				{Op: "add", Path: "/new", Value: []any{float64(1)}},
+			},
+		},
+		"shrinking array": {
+			from: `{"a":[1,2,3]}`,
+			to:   `{"a":[9]}`,
+			want: []PatchOperation{
+				{Op: "replace", Path: "/a/0", Value: float64(9)},
+				{Op: "remove", Path: "/a/2"},
+				{Op: "remove", Path: "/a/1"},
+			},
+		},
+		"growing array": {
+			from: `[{"k":"v"}]`,
+			to:   `[{"k":"w"},2,3]`,
+			want: []PatchOperation{
+				{Op: "replace", Path: "/0/k", Value: "w"},
+				{Op: "add", Path: "/-", Value: float64(2)},
+				{Op: "add", Path: "/-", Value: float64(3)},
+			},
+		},
+		"type change": {
+			from: `{"a":{"b":1}}`,
+			to:   `{"a":[1]}`,
+			want: []PatchOperation{
+				{Op: "replace", Path: "/a", Value: []any{float64(1)}},
+			},
+		},
+		"escaped keys": {
+			from: `{"a/b":1,"c~d":1}`,
+			to:   `{"a/b":2,"c~d":2}`,
+			want: []PatchOperation{
+				{Op: "replace", Path: "/a~1b", Value: float64(2)},
+				{Op: "replace", Path: "/c~0d", Value: float64(2)},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := JSONPatch(jsonValue(t, tt.from), jsonValue(t, tt.to))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalDescription(t *testing.T) {
+	description := map[string]any{
+		"Role": map[string]any{
+			"RoleName":     "admin",
+			"RoleLastUsed": map[string]any{"Region": "us-east-1"},
+			"Tags":         []any{map[string]any{"Key": "a", "ResultMetadata": map[string]any{}}},
+		},
+		"ResultMetadata": map[string]any{"RequestId": "1"},
+	}
+
+	got, err := CanonicalDescription("AWS::IAM::Role", description)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := jsonValue(t, `{"Role":{"RoleName":"admin","Tags":[{"Key":"a"}]}}`)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// the volatile fields of a type are resolved from the root, through arrays.
+	got, err = CanonicalDescription("aws::cloudwatch::alarm", jsonValue(t,
+		`{"MetricAlarm":{"AlarmName":"a","StateReason":"x","StateUpdatedTimestamp":"t"},"StateReason":"kept"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = jsonValue(t, `{"MetricAlarm":{"AlarmName":"a"},"StateReason":"kept"}`)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// the description passed in is left untouched.
+	if _, ok := description["ResultMetadata"]; !ok {
+		t.Errorf("description was modified")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	type description struct {
+		Table map[string]any
+	}
+	fingerprint := func(d any) string {
+		t.Helper()
+		f, err := Fingerprint("AWS::DynamoDB::Table", d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+
+	base := fingerprint(description{Table: map[string]any{"TableName": "t", "ItemCount": 1, "BillingMode": "PAY_PER_REQUEST"}})
+	if len(base) != 64 {
+		t.Errorf("got fingerprint %q, want a sha256 hex digest", base)
+	}
+	if f := fingerprint(map[string]any{"Table": map[string]any{"BillingMode": "PAY_PER_REQUEST", "TableName": "t", "ItemCount": 1}}); f != base {
+		t.Errorf("fingerprint depends on the key order or the go type")
+	}
+	if f := fingerprint(description{Table: map[string]any{"TableName": "t", "ItemCount": 500, "TableSizeBytes": 10, "BillingMode": "PAY_PER_REQUEST"}}); f != base {
+		t.Errorf("fingerprint depends on volatile fields")
+	}
+	if f := fingerprint(description{Table: map[string]any{"TableName": "t", "ItemCount": 1, "BillingMode": "PROVISIONED"}}); f == base {
+		t.Errorf("fingerprint did not change with the description")
+	}
+}
+
+func trackedResource(t *testing.T, description string) (es.Resource, string, any) {
+	t.Helper()
+
+	resource := es.Resource{
+		ID:            "arn:aws:iam::123456789012:role/admin",
+		SourceID:      "source",
+		SourceType:    source.CloudAWS,
+		ResourceType:  "aws::iam::role",
+		ResourceJobID: 7,
+		CreatedAt:     1000,
+		Description:   jsonValue(t, description),
+	}
+	canonical, err := CanonicalDescription(resource.ResourceType, resource.Description)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fingerprint, err := fingerprintOf(canonical)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resource, fingerprint, canonical
+}
+
+func TestChangeTracker(t *testing.T) {
+	ctx := context.Background()
+	tracker := NewChangeTracker(NewMemorySnapshotStore())
+
+	resource, fingerprint, canonical := trackedResource(t, `{"Role":{"MaxSessionDuration":3600}}`)
+	change, err := tracker.Track(ctx, resource, fingerprint, canonical)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if change == nil || change.ChangeType != ChangeTypeCreated || change.Patch != nil || change.Fingerprint != fingerprint ||
+		change.ResourceJobID != 7 || change.EsIndex != ResourceChangeIndex || change.EsID == "" {
+		t.Fatalf("got %+v, want a created change", change)
+	}
+
+	// only a volatile field changed.
+	resource, same, canonical := trackedResource(t, `{"Role":{"MaxSessionDuration":3600,"RoleLastUsed":{"Region":"eu-west-1"}}}`)
+	if change, err := tracker.Track(ctx, resource, same, canonical); err != nil || change != nil {
+		t.Fatalf("got %+v, %v for an unchanged resource", change, err)
+	}
+
+	resource, modified, canonical := trackedResource(t, `{"Role":{"MaxSessionDuration":7200}}`)
+	change, err = tracker.Track(ctx, resource, modified, canonical)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []PatchOperation{{Op: "replace", Path: "/Role/MaxSessionDuration", Value: float64(7200)}}
+	if change == nil || change.ChangeType != ChangeTypeModified || change.PreviousFingerprint != fingerprint ||
+		!reflect.DeepEqual(change.Patch, want) {
+		t.Errorf("got %+v, want a modified change", change)
+	}
+}
+
+// fakeSearcher answers the snapshot queries with the documents of one index.
+type fakeSearcher struct {
+	index   string
+	docs    map[string]string
+	queries []string
+}
+
+func (f *fakeSearcher) Search(_ context.Context, index string, query string, response any) error {
+	f.queries = append(f.queries, query)
+	var q struct {
+		Query struct {
+			IDs struct {
+				Values []string `json:"values"`
+			} `json:"ids"`
+		} `json:"query"`
+	}
+	if err := json.Unmarshal([]byte(query), &q); err != nil {
+		return err
+	}
+
+	var hits []string
+	if index == f.index {
+		for _, id := range q.Query.IDs.Values {
+			if doc, ok := f.docs[id]; ok {
+				hits = append(hits, `{"_source":`+doc+`}`)
+			}
+		}
+	}
+	return json.Unmarshal([]byte(`{"hits":{"hits":[`+strings.Join(hits, ",")+`]}}`), response)
+}
+
+func TestOpenSearchSnapshotStore(t *testing.T) {
+	ctx := context.Background()
+	const (
+		admin   = "arn:aws:iam::123456789012:role/admin"
+		reader  = "arn:aws:iam::123456789012:role/reader"
+		created = "arn:aws:iam::123456789012:role/new"
+	)
+	searcher := &fakeSearcher{
+		index: "aws_iam_role",
+		docs: map[string]string{
+			es.HashOf(admin, "source"):  `{"id":"` + admin + `","description":{"Role":{"MaxSessionDuration":3600}},"metadata":{"Fingerprint":"stored"}}`,
+			es.HashOf(reader, "source"): `{"id":"` + reader + `","description":{"Role":{"MaxSessionDuration":3600},"ResultMetadata":{}}}`,
+		},
+	}
+	store := NewOpenSearchSnapshotStore(searcher, "AWS::IAM::Role", "source")
+	tracker := NewChangeTracker(store)
+
+	if err := tracker.Prefetch(ctx, []string{admin, reader, created, admin}); err != nil {
+		t.Fatal(err)
+	}
+	if len(searcher.queries) != 1 {
+		t.Fatalf("got %d queries, want the batch fetched at once", len(searcher.queries))
+	}
+
+	snapshot, err := store.Get(ctx, admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot == nil || snapshot.Fingerprint != "stored" {
+		t.Errorf("got snapshot %+v, want the stored fingerprint", snapshot)
+	}
+
+	// documents without a fingerprint get the one of their canonical description.
+	resource, fingerprint, canonical := trackedResource(t, `{"Role":{"MaxSessionDuration":3600}}`)
+	resource.ID = reader
+	if change, err := tracker.Track(ctx, resource, fingerprint, canonical); err != nil || change != nil {
+		t.Errorf("got %+v, %v for an unchanged resource", change, err)
+	}
+
+	resource.ID = created
+	change, err := tracker.Track(ctx, resource, fingerprint, canonical)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if change == nil || change.ChangeType != ChangeTypeCreated {
+		t.Errorf("got %+v, want a created change", change)
+	}
+	if len(searcher.queries) != 1 {
+		t.Errorf("got %d queries, want the prefetched snapshots reused", len(searcher.queries))
+	}
+}
@@ -0,0 +1,298 @@
+// Package describertest provides in-process stand-ins for the services a describe job talks
+// to: the scheduler DescribeService, the EsSinkService and the vault holding the account config.
+package describertest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/opengovern/og-util/proto/src/golang"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	MethodSetInProgress = "SetInProgress"
+	MethodIngest        = "Ingest"
+	MethodDeliverResult = "DeliverResult"
+)
+
+// Call is a request received by one of the fake services.
+type Call struct {
+	Time    time.Time `json:"time"`
+	Method  string    `json:"method"`
+	Request any       `json:"request"`
+	// Error is the error the call was failed with, see Server.Fail.
+	Error string `json:"error,omitempty"`
+}
+
+// IngestRequest is the request of an Ingest call with the documents decoded.
+type IngestRequest struct {
+	// ResourceJobID is the resource-job-id metadata of the call.
+	ResourceJobID string            `json:"resourceJobId"`
+	Docs          []json.RawMessage `json:"docs"`
+}
+
+// Recorder keeps the calls received by the fake services, in order, and writes each of them as
+// a JSON line to its writer, if any.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+	w     io.Writer
+}
+
+// NewRecorder returns a recorder writing the calls to w, nil to only keep them in memory.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w}
+}
+
+// Calls returns the calls received so far.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// Methods returns the method of every call received so far, failed calls are suffixed with !.
+func (r *Recorder) Methods() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	methods := make([]string, 0, len(r.calls))
+	for _, call := range r.calls {
+		if call.Error != "" {
+			methods = append(methods, call.Method+"!")
+		} else {
+			methods = append(methods, call.Method)
+		}
+	}
+	return methods
+}
+
+func (r *Recorder) record(method string, request any, callErr error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	call := Call{Time: time.Now().UTC(), Method: method, Request: request}
+	if callErr != nil {
+		call.Error = callErr.Error()
+	}
+	r.calls = append(r.calls, call)
+	if r.w == nil {
+		return nil
+	}
+	line, err := json.Marshal(call)
+	if err != nil {
+		return err
+	}
+	_, err = r.w.Write(append(line, '\n'))
+	return err
+}
+
+// faults are the errors the next calls of each method fail with.
+type faults struct {
+	mu      sync.Mutex
+	pending map[string][]error
+}
+
+func (f *faults) next(method string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	errs := f.pending[method]
+	if len(errs) == 0 {
+		return nil
+	}
+	f.pending[method] = errs[1:]
+	return errs[0]
+}
+
+// handle records a call and returns the error it fails with, if any.
+func handle(recorder *Recorder, faults *faults, method string, request any) (*golang.ResponseOK, error) {
+	callErr := faults.next(method)
+	if err := recorder.record(method, request, callErr); err != nil {
+		return nil, err
+	}
+	if callErr != nil {
+		return nil, callErr
+	}
+	return &golang.ResponseOK{}, nil
+}
+
+// DescribeService is a scheduler DescribeService accepting every call not failed by the server.
+type DescribeService struct {
+	golang.UnimplementedDescribeServiceServer
+	recorder *Recorder
+	faults   *faults
+}
+
+func (s *DescribeService) SetInProgress(_ context.Context, req *golang.SetInProgressRequest) (*golang.ResponseOK, error) {
+	return handle(s.recorder, s.faults, MethodSetInProgress, req)
+}
+
+func (s *DescribeService) DeliverResult(_ context.Context, req *golang.DeliverResultRequest) (*golang.ResponseOK, error) {
+	return handle(s.recorder, s.faults, MethodDeliverResult, req)
+}
+
+// EsSinkService is an EsSinkService accepting every document not failed by the server.
+type EsSinkService struct {
+	golang.UnimplementedEsSinkServiceServer
+	recorder *Recorder
+	faults   *faults
+}
+
+func (s *EsSinkService) Ingest(ctx context.Context, req *golang.IngestRequest) (*golang.ResponseOK, error) {
+	request := IngestRequest{Docs: make([]json.RawMessage, 0, len(req.Docs))}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("resource-job-id"); len(v) > 0 {
+			request.ResourceJobID = v[0]
+		}
+	}
+	for _, doc := range req.Docs {
+		request.Docs = append(request.Docs, doc.Value)
+	}
+	return handle(s.recorder, s.faults, MethodIngest, request)
+}
+
+// bufconnSize is the buffer of in-process listeners.
+const bufconnSize = 1 << 20
+
+// Server serves a DescribeService and an EsSinkService, recording their calls.
+type Server struct {
+	Recorder *Recorder
+
+	faults  *faults
+	bufconn bool
+
+	mu   sync.Mutex
+	grpc *grpc.Server
+	lis  net.Listener
+}
+
+// Start serves both services on a local TCP port, recording the calls with recorder.
+func Start(recorder *Recorder) (*Server, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := newServer(recorder, false)
+	s.serve(lis)
+	return s, nil
+}
+
+// StartBufconn serves both services on an in-process listener, recording the calls with
+// recorder. Clients reach it at Addr with DialOptions.
+func StartBufconn(recorder *Recorder) *Server {
+	s := newServer(recorder, true)
+	s.serve(bufconn.Listen(bufconnSize))
+	return s
+}
+
+func newServer(recorder *Recorder, inProcess bool) *Server {
+	return &Server{
+		Recorder: recorder,
+		faults:   &faults{pending: map[string][]error{}},
+		bufconn:  inProcess,
+	}
+}
+
+func (s *Server) serve(lis net.Listener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lis = lis
+	s.grpc = grpc.NewServer()
+	golang.RegisterDescribeServiceServer(s.grpc, &DescribeService{recorder: s.Recorder, faults: s.faults})
+	golang.RegisterEsSinkServiceServer(s.grpc, &EsSinkService{recorder: s.Recorder, faults: s.faults})
+	go s.grpc.Serve(lis)
+}
+
+// Addr is the address to use as the job and the deliver endpoint of a describe job.
+func (s *Server) Addr() string {
+	if s.bufconn {
+		return "passthrough:///bufconn"
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lis.Addr().String()
+}
+
+// DialOptions are the options clients need to reach the server, none for a TCP server.
+func (s *Server) DialOptions() []grpc.DialOption {
+	if !s.bufconn {
+		return nil
+	}
+	return []grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			s.mu.Lock()
+			lis := s.lis.(*bufconn.Listener)
+			s.mu.Unlock()
+			return lis.DialContext(ctx)
+		}),
+	}
+}
+
+// Fail fails the next times calls of method with code.
+func (s *Server) Fail(method string, times int, code codes.Code) {
+	s.faults.mu.Lock()
+	defer s.faults.mu.Unlock()
+	for i := 0; i < times; i++ {
+		s.faults.pending[method] = append(s.faults.pending[method], status.Errorf(code, "%s failed by describertest", method))
+	}
+}
+
+// Restart stops the server, closing the connections of its clients, and serves again at the same
+// address. The recorded calls and the pending failures are kept.
+func (s *Server) Restart() error {
+	s.mu.Lock()
+	server, lis := s.grpc, s.lis
+	s.mu.Unlock()
+	server.Stop()
+
+	if s.bufconn {
+		s.serve(bufconn.Listen(bufconnSize))
+		return nil
+	}
+	lis, err := net.Listen("tcp", lis.Addr().String())
+	if err != nil {
+		return err
+	}
+	s.serve(lis)
+	return nil
+}
+
+// Stop stops the server, waiting for the running calls.
+func (s *Server) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.grpc.GracefulStop()
+}
+
+// PlaintextVault is a vault.VaultSourceConfig whose ciphertexts are the plain JSON config. If
+// Config is set, it is returned whatever the ciphertext.
+type PlaintextVault struct {
+	Config map[string]any
+}
+
+func (v PlaintextVault) Encrypt(_ context.Context, data map[string]any) (string, error) {
+	b, err := json.Marshal(data)
+	return string(b), err
+}
+
+func (v PlaintextVault) Decrypt(_ context.Context, cypherText string) (map[string]any, error) {
+	if v.Config != nil {
+		return v.Config, nil
+	}
+	var config map[string]any
+	if err := json.Unmarshal([]byte(cypherText), &config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
@@ -0,0 +1,123 @@
+package describer
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// volatileDescriptionFields lists, per resource type, the description fields that change on
+// every describe without the resource itself changing. They are left out of the fingerprint
+// and of the change patches.
+var volatileDescriptionFields = map[string][]string{
+	"aws::cloudwatch::alarm": {
+		"MetricAlarm.StateReason",
+		"MetricAlarm.StateReasonData",
+		"MetricAlarm.StateUpdatedTimestamp",
+		"MetricAlarm.StateTransitionedTimestamp",
+	},
+	"aws::dynamodb::table": {
+		"Table.ItemCount",
+		"Table.TableSizeBytes",
+	},
+	"aws::ec2::instance": {
+		"InstanceStatus",
+	},
+	"aws::iam::accesskey": {
+		"AccessKeyLastUsed",
+	},
+	"aws::iam::role": {
+		"Role.RoleLastUsed",
+	},
+	"aws::iam::user": {
+		"User.PasswordLastUsed",
+	},
+	"aws::secretsmanager::secret": {
+		"Secret.LastAccessedDate",
+	},
+}
+
+// commonVolatileDescriptionFields are dropped from the description of every resource type.
+var commonVolatileDescriptionFields = []string{
+	"ResultMetadata",
+}
+
+// CanonicalDescription returns the description of a resource as generic json values with
+// the volatile fields of resourceType removed.
+func CanonicalDescription(resourceType string, description any) (any, error) {
+	b, err := json.Marshal(description)
+	if err != nil {
+		return nil, err
+	}
+
+	var canonical any
+	if err := json.Unmarshal(b, &canonical); err != nil {
+		return nil, err
+	}
+
+	removeFields(canonical, commonVolatileDescriptionFields, true)
+	removeFields(canonical, volatileDescriptionFields[strings.ToLower(resourceType)], false)
+	return canonical, nil
+}
+
+// Fingerprint returns a stable hash of the description of a resource. Object keys are
+// sorted and volatile fields excluded, so the fingerprint changes only if the resource did.
+func Fingerprint(resourceType string, description any) (string, error) {
+	canonical, err := CanonicalDescription(resourceType, description)
+	if err != nil {
+		return "", err
+	}
+	return fingerprintOf(canonical)
+}
+
+func fingerprintOf(canonical any) (string, error) {
+	// encoding/json writes map keys in sorted order which makes the output canonical
+	b, err := json.Marshal(canonical)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b)), nil
+}
+
+// removeFields deletes the dotted paths from v. If anywhere is set the last element of each
+// path is removed at every depth, otherwise paths are resolved from the root of v.
+func removeFields(v any, paths []string, anywhere bool) {
+	for _, path := range paths {
+		parts := strings.Split(path, ".")
+		if anywhere {
+			removeKeyEverywhere(v, parts[len(parts)-1])
+		} else {
+			removePath(v, parts)
+		}
+	}
+}
+
+func removePath(v any, parts []string) {
+	switch t := v.(type) {
+	case map[string]any:
+		if len(parts) == 1 {
+			delete(t, parts[0])
+			return
+		}
+		removePath(t[parts[0]], parts[1:])
+	case []any:
+		for _, item := range t {
+			removePath(item, parts)
+		}
+	}
+}
+
+func removeKeyEverywhere(v any, key string) {
+	switch t := v.(type) {
+	case map[string]any:
+		delete(t, key)
+		for _, item := range t {
+			removeKeyEverywhere(item, key)
+		}
+	case []any:
+		for _, item := range t {
+			removeKeyEverywhere(item, key)
+		}
+	}
+}
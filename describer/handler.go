@@ -61,9 +61,28 @@ const (
 	TriggeredByLocal         TriggeredBy = "local"
 )
 
+type dialOptionsKey struct{}
+
+// WithDialOptions returns a context adding opts to the options the scheduler and the sink are
+// dialed with, to reach them over an in-process listener for instance.
+func WithDialOptions(ctx context.Context, opts ...grpc.DialOption) context.Context {
+	return context.WithValue(ctx, dialOptionsKey{}, opts)
+}
+
+func dialOptions(ctx context.Context) []grpc.DialOption {
+	opts, _ := ctx.Value(dialOptionsKey{}).([]grpc.DialOption)
+	return opts
+}
+
 // DescribeHandler
 // TriggeredBy is not used for now but might be relevant in the future
-func DescribeHandler(ctx context.Context, logger *zap.Logger, _ TriggeredBy, input describe.DescribeWorkerInput) error {
+func DescribeHandler(ctx context.Context, logger *zap.Logger, triggeredBy TriggeredBy, input describe.DescribeWorkerInput) error {
+	return DescribeHandlerWithVault(ctx, logger, triggeredBy, input, nil)
+}
+
+// DescribeHandlerWithVault is DescribeHandler decrypting the account config of the job with
+// vaultSc, or with the vault of input.VaultConfig if vaultSc is nil.
+func DescribeHandlerWithVault(ctx context.Context, logger *zap.Logger, _ TriggeredBy, input describe.DescribeWorkerInput, vaultSc vault.VaultSourceConfig) error {
 	var err error
 	defer func() {
 		if r := recover(); r != nil {
@@ -95,6 +114,7 @@ func DescribeHandler(ctx context.Context, logger *zap.Logger, _ TriggeredBy, inp
 	} else {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
+	opts = append(opts, dialOptions(ctx)...)
 	logger.Info("Connecting to grpc server")
 	for retry := 0; retry < 5; retry++ {
 		conn, err := grpc.NewClient(
@@ -130,19 +150,19 @@ func DescribeHandler(ctx context.Context, logger *zap.Logger, _ TriggeredBy, inp
 	}
 
 	logger.Info("Setting up vault")
-	var vaultSc vault.VaultSourceConfig
-	switch input.VaultConfig.Provider {
-	case vault.AwsKMS:
+	switch provider := input.VaultConfig.Provider; {
+	case vaultSc != nil:
+	case provider == vault.AwsKMS:
 		vaultSc, err = vault.NewKMSVaultSourceConfig(ctx, input.VaultConfig.Aws, input.VaultConfig.KeyId)
 		if err != nil {
 			return fmt.Errorf("failed to initialize KMS vault: %w", err)
 		}
-	case vault.AzureKeyVault:
+	case provider == vault.AzureKeyVault:
 		vaultSc, err = vault.NewAzureVaultClient(ctx, logger, input.VaultConfig.Azure, input.VaultConfig.KeyId)
 		if err != nil {
 			return fmt.Errorf("failed to initialize Azure vault: %w", err)
 		}
-	case vault.HashiCorpVault:
+	case provider == vault.HashiCorpVault:
 		vaultSc, err = vault.NewHashiCorpVaultClient(ctx, logger, input.VaultConfig.HashiCorp, input.VaultConfig.KeyId)
 		if err != nil {
 			return fmt.Errorf("failed to initialize HashiCorp vault: %w", err)
@@ -0,0 +1,258 @@
+package describer
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/opengovern/og-aws-describer/describer/describertest"
+	"github.com/opengovern/og-aws-describer/pkg/awsfixture"
+	"github.com/opengovern/og-util/pkg/describe"
+	"github.com/opengovern/og-util/pkg/source"
+	"github.com/opengovern/og-util/proto/src/golang"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+)
+
+func runDescribeHandler(t *testing.T, server *describertest.Server, job describe.DescribeJob) error {
+	t.Helper()
+	return runDescribeHandlerWithContext(t, context.Background(), server, job)
+}
+
+func runDescribeHandlerWithContext(t *testing.T, ctx context.Context, server *describertest.Server, job describe.DescribeJob) error {
+	t.Helper()
+
+	input := describe.DescribeWorkerInput{
+		JobEndpoint:     server.Addr(),
+		DeliverEndpoint: server.Addr(),
+		DescribeJob:     job,
+	}
+	ctx = WithDialOptions(ctx, server.DialOptions()...)
+	return DescribeHandlerWithVault(ctx, zap.NewNop(), TriggeredByLocal, input, describertest.PlaintextVault{})
+}
+
+func deliveredResult(t *testing.T, server *describertest.Server) *golang.DeliverResultRequest {
+	t.Helper()
+
+	calls := server.Recorder.Calls()
+	last := calls[len(calls)-1]
+	if last.Method != describertest.MethodDeliverResult {
+		t.Fatalf("last call is %s, want %s", last.Method, describertest.MethodDeliverResult)
+	}
+	return last.Request.(*golang.DeliverResultRequest)
+}
+
+func TestDescribeHandlerDeliversFailure(t *testing.T) {
+	cases := []struct {
+		name      string
+		job       describe.DescribeJob
+		wantError string
+	}{
+		{
+			name: "unsupported source type",
+			job: describe.DescribeJob{
+				JobID:        7,
+				ResourceType: "AWS::EC2::Volume",
+				AccountID:    "123456789012",
+				SourceType:   source.CloudAzure,
+			},
+			wantError: "unsupported source type Azure",
+		},
+		{
+			name: "undecryptable config",
+			job: describe.DescribeJob{
+				JobID:        8,
+				ResourceType: "AWS::EC2::Volume",
+				AccountID:    "123456789012",
+				SourceType:   source.CloudAWS,
+				CipherText:   "not json",
+			},
+			wantError: "decrypt error",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := describertest.StartBufconn(describertest.NewRecorder(nil))
+			defer server.Stop()
+
+			if err := runDescribeHandler(t, server, c.job); err != nil {
+				t.Fatalf("DescribeHandler() = %v", err)
+			}
+
+			want := []string{describertest.MethodSetInProgress, describertest.MethodDeliverResult}
+			if got := server.Recorder.Methods(); !reflect.DeepEqual(got, want) {
+				t.Fatalf("calls = %v, want %v", got, want)
+			}
+			if got := server.Recorder.Calls()[0].Request.(*golang.SetInProgressRequest).JobId; got != uint32(c.job.JobID) {
+				t.Errorf("SetInProgress job id = %d, want %d", got, c.job.JobID)
+			}
+
+			result := deliveredResult(t, server)
+			if result.JobId != uint32(c.job.JobID) || result.DescribeJob.GetJobId() != uint32(c.job.JobID) {
+				t.Errorf("delivered job id = %d, %d, want %d", result.JobId, result.DescribeJob.GetJobId(), c.job.JobID)
+			}
+			if result.Status != DescribeResourceJobFailed {
+				t.Errorf("status = %s, want %s", result.Status, DescribeResourceJobFailed)
+			}
+			if !strings.Contains(result.Error, c.wantError) {
+				t.Errorf("error = %q, want it to contain %q", result.Error, c.wantError)
+			}
+			if result.ErrorCode != "" {
+				t.Errorf("error code = %q, want none", result.ErrorCode)
+			}
+			if len(result.DescribedResourceIds) != 0 {
+				t.Errorf("described resource ids = %v, want none", result.DescribedResourceIds)
+			}
+		})
+	}
+}
+
+// TestDescribeHandlerDeliversSuccess runs a job describing the EC2 volumes of
+// testdata/aws_ec2_volume.http.json, the calls of the describer fixture and of the tag enricher,
+// and checks the resources are ingested and delivered as the result of the job.
+func TestDescribeHandlerDeliversSuccess(t *testing.T) {
+	if awsfixture.Recording() {
+		t.Skip("replays the fixture of the describer test")
+	}
+
+	server := describertest.StartBufconn(describertest.NewRecorder(nil))
+	defer server.Stop()
+
+	config, err := describertest.PlaintextVault{}.Encrypt(context.Background(), map[string]any{
+		"accountId": awsfixture.AccountID,
+		"regions":   []string{awsfixture.Region},
+		"accessKey": "AKIAFIXTURE",
+		"secretKey": "fixture",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := describe.DescribeJob{
+		JobID:        11,
+		ResourceType: "AWS::EC2::Volume",
+		SourceID:     "source-1",
+		AccountID:    awsfixture.AccountID,
+		SourceType:   source.CloudAWS,
+		CipherText:   config,
+	}
+	ctx := WithAWSHTTPClient(context.Background(), awsfixture.Config(t, "testdata/aws_ec2_volume.http.json").HTTPClient)
+	if err := runDescribeHandlerWithContext(t, ctx, server, job); err != nil {
+		t.Fatalf("DescribeHandler() = %v", err)
+	}
+
+	want := []string{
+		"arn:aws:ec2:us-east-1:123456789012:volume/vol-0a1b2c3d4e5f60001",
+		"arn:aws:ec2:us-east-1:123456789012:volume/vol-0a1b2c3d4e5f60002",
+	}
+
+	methods := server.Recorder.Methods()
+	if len(methods) < 3 || methods[0] != describertest.MethodSetInProgress || methods[len(methods)-1] != describertest.MethodDeliverResult {
+		t.Fatalf("calls = %v, want SetInProgress, Ingest batches and DeliverResult", methods)
+	}
+	var resources, lookups []string
+	tags := map[string]map[string]string{}
+	for _, call := range server.Recorder.Calls()[1 : len(methods)-1] {
+		if call.Method != describertest.MethodIngest || call.Error != "" {
+			t.Fatalf("calls = %v, want only Ingest batches between SetInProgress and DeliverResult", methods)
+		}
+		request := call.Request.(describertest.IngestRequest)
+		if request.ResourceJobID != strconv.Itoa(int(job.JobID)) {
+			t.Errorf("ingested with resource job id %q, want %d", request.ResourceJobID, job.JobID)
+		}
+		for _, raw := range request.Docs {
+			// resources are ingested with their lookup document
+			var doc struct {
+				ID            string `json:"id"`
+				ResourceID    string `json:"resource_id"`
+				SourceID      string `json:"source_id"`
+				CanonicalTags []struct {
+					Key   string `json:"key"`
+					Value string `json:"value"`
+				} `json:"canonical_tags"`
+			}
+			if err := json.Unmarshal(raw, &doc); err != nil {
+				t.Fatal(err)
+			}
+			if doc.SourceID != job.SourceID {
+				t.Errorf("%s ingested with source id %q, want %q", raw, doc.SourceID, job.SourceID)
+			}
+			if doc.ResourceID != "" {
+				lookups = append(lookups, doc.ResourceID)
+				continue
+			}
+			resources = append(resources, doc.ID)
+			tags[doc.ID] = map[string]string{}
+			for _, tag := range doc.CanonicalTags {
+				tags[doc.ID][tag.Key] = tag.Value
+			}
+		}
+	}
+	sort.Strings(resources)
+	sort.Strings(lookups)
+	if !reflect.DeepEqual(resources, want) || !reflect.DeepEqual(lookups, want) {
+		t.Errorf("ingested resources %v and lookups %v, want %v", resources, lookups, want)
+	}
+	if got := tags[want[1]]; !reflect.DeepEqual(got, map[string]string{"team": "storage"}) {
+		t.Errorf("tags of the untagged volume = %v, want the tags of the tagging api", got)
+	}
+
+	result := deliveredResult(t, server)
+	if result.JobId != uint32(job.JobID) {
+		t.Errorf("delivered job id = %d, want %d", result.JobId, job.JobID)
+	}
+	if result.Status != DescribeResourceJobSucceeded || result.Error != "" {
+		t.Errorf("status = %s, error = %q, want %s", result.Status, result.Error, DescribeResourceJobSucceeded)
+	}
+	ids := append([]string(nil), result.DescribedResourceIds...)
+	sort.Strings(ids)
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("described resource ids = %v, want %v", ids, want)
+	}
+}
+
+func TestDescribeHandlerRetries(t *testing.T) {
+	server := describertest.StartBufconn(describertest.NewRecorder(nil))
+	defer server.Stop()
+	server.Fail(describertest.MethodSetInProgress, 2, codes.Unavailable)
+	server.Fail(describertest.MethodDeliverResult, 1, codes.Unavailable)
+
+	err := runDescribeHandler(t, server, describe.DescribeJob{JobID: 9, SourceType: source.CloudAzure})
+	if err != nil {
+		t.Fatalf("DescribeHandler() = %v", err)
+	}
+
+	want := []string{
+		describertest.MethodSetInProgress + "!",
+		describertest.MethodSetInProgress + "!",
+		describertest.MethodSetInProgress,
+		describertest.MethodDeliverResult + "!",
+		describertest.MethodDeliverResult,
+	}
+	if got := server.Recorder.Methods(); !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
+
+func TestDescribeHandlerGivesUpSetInProgress(t *testing.T) {
+	if testing.Short() {
+		t.Skip("retries take several seconds")
+	}
+
+	server := describertest.StartBufconn(describertest.NewRecorder(nil))
+	defer server.Stop()
+	server.Fail(describertest.MethodSetInProgress, 5, codes.Unavailable)
+
+	if err := runDescribeHandler(t, server, describe.DescribeJob{JobID: 10, SourceType: source.CloudAzure}); err == nil {
+		t.Fatalf("DescribeHandler() succeeded, want an error")
+	}
+
+	want := []string{"SetInProgress!", "SetInProgress!", "SetInProgress!", "SetInProgress!", "SetInProgress!"}
+	if got := server.Recorder.Methods(); !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+}
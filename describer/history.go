@@ -0,0 +1,85 @@
+package describer
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/opengovern/og-util/pkg/describe"
+	"github.com/opengovern/og-util/pkg/es"
+)
+
+const (
+	// HistoryIndexSuffix is appended to a resource index to get the index of its snapshots.
+	HistoryIndexSuffix = "_history"
+
+	// KeepHistoryEnv enables snapshot documents when set to true. Snapshots are kept until the
+	// index lifecycle policy of the history indices drops them.
+	KeepHistoryEnv = "DESCRIBE_KEEP_HISTORY"
+)
+
+// HistoricalResource is the copy of a resource as described by a single describe job. Unlike
+// the resource document, which every describe job overwrites, a snapshot is keyed by the job
+// too so the state of past jobs stays queryable.
+type HistoricalResource struct {
+	es.Resource
+}
+
+func (r HistoricalResource) KeysAndIndex() ([]string, string) {
+	keys, idx := r.Resource.KeysAndIndex()
+	keys = append(keys, strconv.FormatUint(uint64(r.ResourceJobID), 10))
+	return keys, idx + HistoryIndexSuffix
+}
+
+// NewHistoricalResource returns the snapshot of resource with its es id and index set.
+func NewHistoricalResource(resource es.Resource) HistoricalResource {
+	h := HistoricalResource{Resource: resource}
+	keys, idx := h.KeysAndIndex()
+	h.EsID = es.HashOf(keys...)
+	h.EsIndex = idx
+	return h
+}
+
+// HistoryJobMarker is written to the history index when a describe job completes. A job that
+// found no resources has no snapshots, the marker keeps it the last job of its account so as_of
+// queries return nothing for it rather than the resources of the job before.
+type HistoryJobMarker struct {
+	EsID    string `json:"es_id"`
+	EsIndex string `json:"es_index"`
+
+	SourceID      string `json:"source_id"`
+	ResourceType  string `json:"resource_type"`
+	ResourceJobID uint   `json:"resource_job_id"`
+	CreatedAt     int64  `json:"created_at"`
+	// HistoryJobMarker tells markers apart from snapshots, queries on the history index exclude
+	// the documents where it is true.
+	HistoryJobMarker bool `json:"history_job_marker"`
+}
+
+func (m HistoryJobMarker) KeysAndIndex() ([]string, string) {
+	return []string{
+		"history_job_marker",
+		m.SourceID,
+		strconv.FormatUint(uint64(m.ResourceJobID), 10),
+	}, es.ResourceTypeToESIndex(m.ResourceType) + HistoryIndexSuffix
+}
+
+// NewHistoryJobMarker returns the marker of job with its es id and index set.
+func NewHistoryJobMarker(job describe.DescribeJob) HistoryJobMarker {
+	m := HistoryJobMarker{
+		SourceID:         job.SourceID,
+		ResourceType:     strings.ToLower(job.ResourceType),
+		ResourceJobID:    job.JobID,
+		CreatedAt:        job.DescribedAt,
+		HistoryJobMarker: true,
+	}
+	keys, idx := m.KeysAndIndex()
+	m.EsID = es.HashOf(keys...)
+	m.EsIndex = idx
+	return m
+}
+
+func keepHistoryEnabled() bool {
+	keep, _ := strconv.ParseBool(os.Getenv(KeepHistoryEnv))
+	return keep
+}
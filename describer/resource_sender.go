@@ -9,6 +9,8 @@ import (
 	"fmt"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/opengovern/og-aws-describer/aws"
+	"github.com/opengovern/og-util/pkg/describe"
 	"github.com/opengovern/og-util/pkg/es"
 	"github.com/opengovern/og-util/pkg/source"
 	"google.golang.org/grpc/credentials/insecure"
@@ -50,9 +52,17 @@ type ResourceSender struct {
 
 	sendBuffer    []*golang.AWSResource
 	useOpenSearch bool
+
+	changeTracker *ChangeTracker
+	keepHistory   bool
+	historyMarker *HistoryJobMarker
+
+	dialOptions []grpc.DialOption
 }
 
-func NewResourceSender(grpcEndpoint, ingestionPipelineEndpoint string, describeToken string, jobID uint, useOpenSearch bool, logger *zap.Logger) (*ResourceSender, error) {
+// NewResourceSender connects to the sink at grpcEndpoint, dialOptions are added to the options
+// it is dialed with.
+func NewResourceSender(grpcEndpoint, ingestionPipelineEndpoint string, describeToken string, jobID uint, useOpenSearch bool, logger *zap.Logger, dialOptions ...grpc.DialOption) (*ResourceSender, error) {
 	rs := ResourceSender{
 		authToken:                 describeToken,
 		logger:                    logger,
@@ -66,6 +76,8 @@ func NewResourceSender(grpcEndpoint, ingestionPipelineEndpoint string, describeT
 		useOpenSearch:             useOpenSearch,
 
 		httpClient: &http.Client{Timeout: 10 * time.Second},
+
+		dialOptions: dialOptions,
 	}
 	if err := rs.Connect(); err != nil {
 		return nil, err
@@ -75,6 +87,17 @@ func NewResourceSender(grpcEndpoint, ingestionPipelineEndpoint string, describeT
 	return &rs, nil
 }
 
+// SetChangeTracker enables change records for the resources sent after the call.
+func (s *ResourceSender) SetChangeTracker(tracker *ChangeTracker) {
+	s.changeTracker = tracker
+}
+
+// SetKeepHistory enables snapshot documents, see HistoricalResource, for the resources sent
+// after the call.
+func (s *ResourceSender) SetKeepHistory(keep bool) {
+	s.keepHistory = keep
+}
+
 func (s *ResourceSender) Connect() error {
 	var opts []grpc.DialOption
 	if s.authToken != "" {
@@ -87,6 +110,7 @@ func (s *ResourceSender) Connect() error {
 	} else {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
+	opts = append(opts, s.dialOptions...)
 
 	conn, err := grpc.NewClient(
 		s.grpcEndpoint,
@@ -111,6 +135,9 @@ func (s *ResourceSender) ResourceHandler() {
 		case resource := <-s.resourceChannel:
 			if resource == nil {
 				s.flushBuffer(true)
+				if s.historyMarker != nil {
+					s.sendToBackend([]es.Doc{*s.historyMarker})
+				}
 				s.doneChannel <- struct{}{}
 				return
 			}
@@ -227,6 +254,16 @@ func (s *ResourceSender) flushBuffer(force bool) {
 		return
 	}
 
+	if s.changeTracker != nil {
+		ids := make([]string, 0, len(s.sendBuffer))
+		for _, resource := range s.sendBuffer {
+			ids = append(ids, resource.UniqueId)
+		}
+		if err := s.changeTracker.Prefetch(context.Background(), ids); err != nil {
+			s.logger.Error("failed to prefetch resource snapshots", zap.Error(err))
+		}
+	}
+
 	resourcesToSend := make([]es.Doc, 0, 2*len(s.sendBuffer))
 	for _, resource := range s.sendBuffer {
 		var description any
@@ -236,14 +273,7 @@ func (s *ResourceSender) flushBuffer(force bool) {
 			continue
 		}
 
-		tags := make([]es.Tag, 0, len(resource.Tags))
-		for k, v := range resource.Tags {
-			tags = append(tags, es.Tag{
-				// tags should be case-insensitive
-				Key:   strings.ToLower(k),
-				Value: strings.ToLower(v),
-			})
-		}
+		tags := aws.CanonicalTags(resource.Tags)
 
 		kafkaResource := es.Resource{
 			ID:            resource.UniqueId,
@@ -265,6 +295,8 @@ func (s *ResourceSender) flushBuffer(force bool) {
 		kafkaResource.EsID = es.HashOf(keys...)
 		kafkaResource.EsIndex = idx
 
+		change := s.fingerprint(&kafkaResource)
+
 		lookupResource := es.LookupResource{
 			ResourceID:    resource.UniqueId,
 			Name:          resource.Name,
@@ -284,18 +316,63 @@ func (s *ResourceSender) flushBuffer(force bool) {
 
 		resourcesToSend = append(resourcesToSend, kafkaResource)
 		resourcesToSend = append(resourcesToSend, lookupResource)
+		if s.keepHistory {
+			resourcesToSend = append(resourcesToSend, NewHistoricalResource(kafkaResource))
+		}
+		if change != nil {
+			resourcesToSend = append(resourcesToSend, *change)
+		}
 	}
 
 	s.sendToBackend(resourcesToSend)
 	s.sendBuffer = nil
 }
 
+// fingerprint adds the description fingerprint to the resource metadata and, if change tracking
+// is enabled, returns the change record of the resource.
+func (s *ResourceSender) fingerprint(resource *es.Resource) *ResourceChange {
+	canonical, err := CanonicalDescription(resource.ResourceType, resource.Description)
+	if err != nil {
+		s.logger.Error("failed to canonicalize resource description", zap.Error(err), zap.String("resourceID", resource.ID))
+		return nil
+	}
+	fingerprint, err := fingerprintOf(canonical)
+	if err != nil {
+		s.logger.Error("failed to fingerprint resource description", zap.Error(err), zap.String("resourceID", resource.ID))
+		return nil
+	}
+	if resource.Metadata == nil {
+		resource.Metadata = map[string]string{}
+	}
+	resource.Metadata["Fingerprint"] = fingerprint
+
+	if s.changeTracker == nil {
+		return nil
+	}
+	change, err := s.changeTracker.Track(context.Background(), *resource, fingerprint, canonical)
+	if err != nil {
+		s.logger.Error("failed to track resource change", zap.Error(err), zap.String("resourceID", resource.ID))
+		return nil
+	}
+	return change
+}
+
 func (s *ResourceSender) Finish() {
 	s.resourceChannel <- nil
 	_ = <-s.doneChannel
 	s.conn.Close()
 }
 
+// FinishJob is Finish for a describe job that completed. When history is kept, the marker of job,
+// see HistoryJobMarker, is written after its resources.
+func (s *ResourceSender) FinishJob(job describe.DescribeJob) {
+	if s.keepHistory {
+		marker := NewHistoryJobMarker(job)
+		s.historyMarker = &marker
+	}
+	s.Finish()
+}
+
 func (s *ResourceSender) GetResourceIDs() []string {
 	return s.resourceIDs
 }
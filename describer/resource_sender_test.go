@@ -0,0 +1,278 @@
+package describer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/opengovern/og-aws-describer/describer/describertest"
+	"github.com/opengovern/og-util/pkg/describe"
+	"github.com/opengovern/og-util/pkg/es"
+	"github.com/opengovern/og-util/proto/src/golang"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+)
+
+func newTestResourceSender(t *testing.T, server *describertest.Server, jobID uint) *ResourceSender {
+	t.Helper()
+
+	rs, err := NewResourceSender(server.Addr(), "", "", jobID, false, zap.NewNop(), server.DialOptions()...)
+	if err != nil {
+		t.Fatalf("NewResourceSender() = %v", err)
+	}
+	return rs
+}
+
+// sendTestResources sends count volumes, numbered from first, and returns their unique ids.
+func sendTestResources(rs *ResourceSender, jobID uint, first, count int) []string {
+	var ids []string
+	for i := first; i < first+count; i++ {
+		id := fmt.Sprintf("vol-%04d", i)
+		uniqueID := "aws|us-east-1|123456789012|aws::ec2::volume|" + id
+		rs.Send(&golang.AWSResource{
+			UniqueId:        uniqueID,
+			Arn:             "arn:aws:ec2:us-east-1:123456789012:volume/" + id,
+			Id:              id,
+			Name:            id,
+			Account:         "123456789012",
+			Region:          "us-east-1",
+			Partition:       "aws",
+			Type:            "AWS::EC2::Volume",
+			DescriptionJson: fmt.Sprintf(`{"Volume":{"VolumeId":%q,"Size":8}}`, id),
+			Metadata:        map[string]string{"Name": id},
+			Tags:            map[string]string{"Env": "Prod"},
+			Job: &golang.DescribeJob{
+				JobId:        uint32(jobID),
+				ResourceType: "AWS::EC2::Volume",
+				AccountId:    "123456789012",
+				SourceType:   "AWS",
+			},
+		})
+		ids = append(ids, uniqueID)
+	}
+	return ids
+}
+
+func ingestBatches(t *testing.T, server *describertest.Server) []describertest.IngestRequest {
+	t.Helper()
+
+	var batches []describertest.IngestRequest
+	for _, call := range server.Recorder.Calls() {
+		if call.Method == describertest.MethodIngest && call.Error == "" {
+			batches = append(batches, call.Request.(describertest.IngestRequest))
+		}
+	}
+	return batches
+}
+
+func TestResourceSenderIngest(t *testing.T) {
+	server := describertest.StartBufconn(describertest.NewRecorder(nil))
+	defer server.Stop()
+
+	rs := newTestResourceSender(t, server, 42)
+	ids := sendTestResources(rs, 42, 0, MaxBufferSize+50)
+	rs.Finish()
+
+	if got := rs.GetResourceIDs(); !reflect.DeepEqual(got, ids) {
+		t.Errorf("resource ids = %d ids, want %d", len(got), len(ids))
+	}
+
+	// A batch is sent once the buffer holds more than MaxBufferSize resources, the rest on
+	// Finish. Each resource is indexed as a resource and a lookup document.
+	batches := ingestBatches(t, server)
+	if len(batches) != 2 {
+		t.Fatalf("ingested %d batches, want 2", len(batches))
+	}
+	for i, want := range []int{2 * (MaxBufferSize + 1), 2 * 49} {
+		if got := len(batches[i].Docs); got != want {
+			t.Errorf("batch %d has %d docs, want %d", i, got, want)
+		}
+		if batches[i].ResourceJobID != "42" {
+			t.Errorf("batch %d resource job id = %s, want 42", i, batches[i].ResourceJobID)
+		}
+	}
+
+	var doc struct {
+		ID            string            `json:"id"`
+		ResourceType  string            `json:"resource_type"`
+		ResourceJobID uint              `json:"resource_job_id"`
+		Metadata      map[string]string `json:"metadata"`
+		CanonicalTags []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"canonical_tags"`
+	}
+	if err := json.Unmarshal(batches[0].Docs[0], &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.ID != ids[0] || doc.ResourceType != "aws::ec2::volume" || doc.ResourceJobID != 42 {
+		t.Errorf("first doc = %+v", doc)
+	}
+	if doc.Metadata["Fingerprint"] == "" {
+		t.Errorf("first doc has no fingerprint")
+	}
+	if len(doc.CanonicalTags) != 1 || doc.CanonicalTags[0].Key != "env" || doc.CanonicalTags[0].Value != "prod" {
+		t.Errorf("canonical tags = %+v, want env=prod", doc.CanonicalTags)
+	}
+}
+
+func TestResourceSenderIngestFailure(t *testing.T) {
+	server := describertest.StartBufconn(describertest.NewRecorder(nil))
+	defer server.Stop()
+	server.Fail(describertest.MethodIngest, 1, codes.Unavailable)
+
+	rs := newTestResourceSender(t, server, 43)
+	ids := sendTestResources(rs, 43, 0, MaxBufferSize+1)
+	ids = append(ids, sendTestResources(rs, 43, MaxBufferSize+1, 10)...)
+	rs.Finish()
+
+	// A failed batch is not retried, its resources are still reported as described.
+	want := []string{describertest.MethodIngest + "!", describertest.MethodIngest}
+	if got := server.Recorder.Methods(); !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %v, want %v", got, want)
+	}
+	if got := rs.GetResourceIDs(); !reflect.DeepEqual(got, ids) {
+		t.Errorf("resource ids = %d ids, want %d", len(got), len(ids))
+	}
+}
+
+func TestResourceSenderReconnect(t *testing.T) {
+	server := describertest.StartBufconn(describertest.NewRecorder(nil))
+	defer server.Stop()
+
+	rs := newTestResourceSender(t, server, 44)
+	sendTestResources(rs, 44, 0, MaxBufferSize+1)
+	waitForBatches(t, server, 1)
+
+	if err := server.Restart(); err != nil {
+		t.Fatal(err)
+	}
+	waitForReady(t, rs)
+
+	sendTestResources(rs, 44, MaxBufferSize+1, MaxBufferSize+1)
+	rs.Finish()
+
+	if got := len(ingestBatches(t, server)); got != 2 {
+		t.Errorf("ingested %d batches, want 2", got)
+	}
+}
+
+func waitForBatches(t *testing.T, server *describertest.Server, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(ingestBatches(t, server)) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d batches", n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// waitForReady waits for the connection of the sender to the sink to be established again.
+func waitForReady(t *testing.T, rs *ResourceSender) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for state := rs.conn.GetState(); state != connectivity.Ready; state = rs.conn.GetState() {
+		rs.conn.Connect()
+		if !rs.conn.WaitForStateChange(ctx, state) {
+			t.Fatalf("timed out reconnecting, connection is %s", state)
+		}
+	}
+}
+
+func TestResourceSenderChangeRecords(t *testing.T) {
+	server := describertest.StartBufconn(describertest.NewRecorder(nil))
+	defer server.Stop()
+
+	// vol-0000 is indexed as described, vol-0001 with another size and vol-0002 is new.
+	volume := func(id string, size int) string {
+		uniqueID := "aws|us-east-1|123456789012|aws::ec2::volume|" + id
+		return fmt.Sprintf(`{"id":%q,"description":{"Volume":{"VolumeId":%q,"Size":%d}}}`, uniqueID, id, size)
+	}
+	searcher := &fakeSearcher{
+		index: "aws_ec2_volume",
+		docs: map[string]string{
+			es.HashOf("aws|us-east-1|123456789012|aws::ec2::volume|vol-0000", ""): volume("vol-0000", 8),
+			es.HashOf("aws|us-east-1|123456789012|aws::ec2::volume|vol-0001", ""): volume("vol-0001", 4),
+		},
+	}
+
+	rs := newTestResourceSender(t, server, 45)
+	rs.SetChangeTracker(NewChangeTracker(NewOpenSearchSnapshotStore(searcher, "AWS::EC2::Volume", "")))
+	sendTestResources(rs, 45, 0, 3)
+	rs.Finish()
+
+	changes := map[string]ResourceChange{}
+	for _, batch := range ingestBatches(t, server) {
+		for _, doc := range batch.Docs {
+			var change ResourceChange
+			if err := json.Unmarshal(doc, &change); err != nil {
+				t.Fatal(err)
+			}
+			if change.ChangeType != "" {
+				changes[change.ResourceID] = change
+			}
+		}
+	}
+	if len(changes) != 2 {
+		t.Fatalf("got %d change records, want 2: %+v", len(changes), changes)
+	}
+	modified := changes["aws|us-east-1|123456789012|aws::ec2::volume|vol-0001"]
+	want := []PatchOperation{{Op: "replace", Path: "/Volume/Size", Value: float64(8)}}
+	if modified.ChangeType != ChangeTypeModified || !reflect.DeepEqual(modified.Patch, want) {
+		t.Errorf("got %+v, want the size change", modified)
+	}
+	if created := changes["aws|us-east-1|123456789012|aws::ec2::volume|vol-0002"]; created.ChangeType != ChangeTypeCreated {
+		t.Errorf("got %+v, want a created change", created)
+	}
+	if len(searcher.queries) != 1 {
+		t.Errorf("got %d snapshot queries, want 1 per batch", len(searcher.queries))
+	}
+}
+
+func TestResourceSenderHistoryJobMarker(t *testing.T) {
+	server := describertest.StartBufconn(describertest.NewRecorder(nil))
+	defer server.Stop()
+
+	// a job that found nothing still writes its marker.
+	rs := newTestResourceSender(t, server, 46)
+	rs.SetKeepHistory(true)
+	rs.FinishJob(describe.DescribeJob{JobID: 46, SourceID: "source", ResourceType: "AWS::EC2::Volume", DescribedAt: 1000})
+
+	batches := ingestBatches(t, server)
+	if len(batches) != 1 || len(batches[0].Docs) != 1 {
+		t.Fatalf("got %d batches, want the marker alone", len(batches))
+	}
+	var marker HistoryJobMarker
+	if err := json.Unmarshal(batches[0].Docs[0], &marker); err != nil {
+		t.Fatal(err)
+	}
+	want := HistoryJobMarker{
+		EsID:             es.HashOf("history_job_marker", "source", "46"),
+		EsIndex:          "aws_ec2_volume" + HistoryIndexSuffix,
+		SourceID:         "source",
+		ResourceType:     "aws::ec2::volume",
+		ResourceJobID:    46,
+		CreatedAt:        1000,
+		HistoryJobMarker: true,
+	}
+	if marker != want {
+		t.Errorf("got marker %+v, want %+v", marker, want)
+	}
+
+	// without history there is nothing to mark.
+	server = describertest.StartBufconn(describertest.NewRecorder(nil))
+	defer server.Stop()
+	rs = newTestResourceSender(t, server, 47)
+	rs.FinishJob(describe.DescribeJob{JobID: 47, SourceID: "source", ResourceType: "AWS::EC2::Volume"})
+	if batches := ingestBatches(t, server); len(batches) != 0 {
+		t.Errorf("got %d batches without history", len(batches))
+	}
+}
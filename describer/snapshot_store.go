@@ -0,0 +1,138 @@
+package describer
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/opengovern/og-util/pkg/es"
+)
+
+// TrackChangesEnv enables change records when set to true. The previous snapshots are read from
+// the resource indices, with the ELASTICSEARCH_* settings of the opengovernance es client.
+const TrackChangesEnv = "DESCRIBE_TRACK_CHANGES"
+
+// Searcher is the part of the opengovernance es client the snapshot store queries.
+type Searcher interface {
+	Search(ctx context.Context, index string, query string, response any) error
+}
+
+// OpenSearchSnapshotStore is a SnapshotStore reading the last snapshot of a resource from its
+// document in the resource index, which holds the description and the fingerprint of the last
+// describe. Put only updates the snapshots of the job, the document the sink indexes next is the
+// new snapshot. A store serves the resources of one resource type and source, a single job.
+type OpenSearchSnapshotStore struct {
+	client       Searcher
+	resourceType string
+	sourceID     string
+
+	mu        sync.Mutex
+	snapshots map[string]*DescriptionSnapshot
+}
+
+func NewOpenSearchSnapshotStore(client Searcher, resourceType, sourceID string) *OpenSearchSnapshotStore {
+	return &OpenSearchSnapshotStore{
+		client:       client,
+		resourceType: resourceType,
+		sourceID:     sourceID,
+		snapshots:    make(map[string]*DescriptionSnapshot),
+	}
+}
+
+type snapshotSearchResponse struct {
+	Hits struct {
+		Hits []struct {
+			Source struct {
+				ID          string            `json:"id"`
+				Description any               `json:"description"`
+				Metadata    map[string]string `json:"metadata"`
+			} `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// Prefetch loads the snapshots of resourceIDs with a single query, resources without a
+// document are remembered as new.
+func (s *OpenSearchSnapshotStore) Prefetch(ctx context.Context, resourceIDs []string) error {
+	s.mu.Lock()
+	var missing []string
+	esIDs := map[string]string{}
+	for _, id := range resourceIDs {
+		if _, ok := s.snapshots[id]; ok {
+			continue
+		}
+		if _, ok := esIDs[id]; ok {
+			continue
+		}
+		esIDs[id] = es.HashOf(id, s.sourceID)
+		missing = append(missing, esIDs[id])
+	}
+	s.mu.Unlock()
+	if len(missing) == 0 {
+		return nil
+	}
+
+	query, err := json.Marshal(map[string]any{
+		"size":    len(missing),
+		"_source": []string{"id", "description", "metadata.Fingerprint"},
+		"query": map[string]any{
+			"ids": map[string]any{"values": missing},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	var response snapshotSearchResponse
+	if err := s.client.Search(ctx, es.ResourceTypeToESIndex(s.resourceType), string(query), &response); err != nil {
+		return err
+	}
+
+	found := map[string]*DescriptionSnapshot{}
+	for _, hit := range response.Hits.Hits {
+		canonical, err := CanonicalDescription(s.resourceType, hit.Source.Description)
+		if err != nil {
+			return err
+		}
+		// documents indexed before fingerprints were added have none, it is computed the way
+		// the resource sender does.
+		fingerprint := hit.Source.Metadata["Fingerprint"]
+		if fingerprint == "" {
+			if fingerprint, err = fingerprintOf(canonical); err != nil {
+				return err
+			}
+		}
+		found[hit.Source.ID] = &DescriptionSnapshot{Fingerprint: fingerprint, Description: canonical}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id := range esIDs {
+		if _, ok := s.snapshots[id]; !ok {
+			s.snapshots[id] = found[id]
+		}
+	}
+	return nil
+}
+
+func (s *OpenSearchSnapshotStore) Get(ctx context.Context, resourceID string) (*DescriptionSnapshot, error) {
+	if err := s.Prefetch(ctx, []string{resourceID}); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.snapshots[resourceID], nil
+}
+
+func (s *OpenSearchSnapshotStore) Put(_ context.Context, resourceID string, snapshot DescriptionSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshots[resourceID] = &snapshot
+	return nil
+}
+
+func trackChangesEnabled() bool {
+	track, _ := strconv.ParseBool(os.Getenv(TrackChangesEnv))
+	return track
+}
@@ -6,13 +6,14 @@ import (
 	"fmt"
 	"strings"
 
-	awsmodel "github.com/opengovern/og-aws-describer/aws/model"
 	"github.com/opengovern/og-aws-describer/pkg/steampipe"
 
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/go-errors/errors"
 	"github.com/opengovern/og-aws-describer/aws"
 	"github.com/opengovern/og-aws-describer/aws/describer"
 	"github.com/opengovern/og-util/pkg/describe"
+	essdk "github.com/opengovern/og-util/pkg/opengovernance-es-sdk"
 	"github.com/opengovern/og-util/pkg/source"
 	"github.com/opengovern/og-util/pkg/vault"
 	"github.com/opengovern/og-util/proto/src/golang"
@@ -58,19 +59,63 @@ func Do(ctx context.Context,
 
 func doDescribeAWS(ctx context.Context, logger *zap.Logger, job describe.DescribeJob, config map[string]any, grpcEndpoint, ingestionPipelineEndpoint string, describeToken string, useOpenSearch bool) ([]string, error) {
 	logger.Info("Making New Resource Sender")
-	rs, err := NewResourceSender(grpcEndpoint, ingestionPipelineEndpoint, describeToken, job.JobID, useOpenSearch, logger)
+	rs, err := NewResourceSender(grpcEndpoint, ingestionPipelineEndpoint, describeToken, job.JobID, useOpenSearch, logger, dialOptions(ctx)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to resource sender: %w", err)
 	}
+	rs.SetKeepHistory(keepHistoryEnabled())
+	if trackChangesEnabled() {
+		esClient, err := essdk.NewClient(essdk.ClientConfig{})
+		if err != nil {
+			logger.Error("change tracking disabled, failed to create es client", zap.Error(err))
+		} else {
+			rs.SetChangeTracker(NewChangeTracker(NewOpenSearchSnapshotStore(esClient, job.ResourceType, job.SourceID)))
+		}
+	}
 
-	logger.Info("Connect to steampipe plugin")
-	plg := steampipe.Plugin()
 	logger.Info("Account Config From Map")
 	creds, err := aws.AccountConfigFromMap(config)
 	if err != nil {
 		return nil, fmt.Errorf("aws account credentials: %w", err)
 	}
 
+	err = DescribeAWS(ctx, logger, job, creds, rs.Send)
+	if _, ok := err.(KaytuError); err != nil && !ok {
+		return nil, err
+	}
+	// failing regions don't stop the job, what was described is its snapshot
+	rs.FinishJob(job)
+
+	return rs.GetResourceIDs(), err
+}
+
+type awsHTTPClientKey struct{}
+
+// WithAWSHTTPClient returns a context making the AWS calls of the describe jobs with client, to
+// replay recorded calls for instance.
+func WithAWSHTTPClient(ctx context.Context, client awssdk.HTTPClient) context.Context {
+	return context.WithValue(ctx, awsHTTPClientKey{}, client)
+}
+
+// DescribeAWS describes the resources of job with the credentials of creds and passes them to
+// send as they are found, with their metadata and tags set as the sink expects them. Failing
+// regions are reported with a KaytuError once the other regions are described.
+func DescribeAWS(ctx context.Context, logger *zap.Logger, job describe.DescribeJob, creds aws.AccountConfig, send func(resource *golang.AWSResource)) error {
+	logger.Info("Connect to steampipe plugin")
+	plg := steampipe.Plugin()
+
+	// the config is built once, for the describer and the tag enricher, so the role of the
+	// account is assumed once per job.
+	cfg, err := aws.GetDescribeConfig(ctx, job.ResourceType, job.AccountID,
+		creds.AccountID, creds.AccessKey, creds.SecretKey, creds.SessionToken, creds.AssumeRoleName, creds.AssumeAdminRoleName, creds.ExternalID, creds.Endpoints)
+	if err != nil {
+		return fmt.Errorf("AWS: %w", err)
+	}
+	if client, ok := ctx.Value(awsHTTPClientKey{}).(awssdk.HTTPClient); ok {
+		cfg.HTTPClient = client
+	}
+	tagEnricher := aws.NewTagEnricher(cfg, logger)
+
 	f := func(resource describer.Resource) error {
 		logger.Info("got a new resource", zap.String("resourceID", resource.ID))
 		if resource.Description == nil {
@@ -80,31 +125,9 @@ func doDescribeAWS(ctx context.Context, logger *zap.Logger, job describe.Describ
 		if err != nil {
 			return err
 		}
-		partition, _ := aws.PartitionOf(resource.Region)
-		if partition == "" {
-			partition = "aws"
-		}
-		resource.Account = job.AccountID
-		resource.Type = strings.ToLower(job.ResourceType)
-		resource.Partition = partition
-		awsMetadata := awsmodel.Metadata{
-			Name:         resource.Name,
-			AccountID:    job.AccountID,
-			SourceID:     job.SourceID,
-			Region:       resource.Region,
-			Partition:    partition,
-			ResourceType: strings.ToLower(job.ResourceType),
-		}
-
-		awsMetadataBytes, err := json.Marshal(awsMetadata)
-		if err != nil {
-			return fmt.Errorf("marshal metadata: %v", err.Error())
-		}
-
-		metadata := make(map[string]string)
-		err = json.Unmarshal(awsMetadataBytes, &metadata)
+		metadata, err := aws.ResourceMetadata(&resource, job.ResourceType, job.AccountID, job.SourceID)
 		if err != nil {
-			return fmt.Errorf("unmarshal metadata: %v", err.Error())
+			return err
 		}
 
 		kafkaResource := Resource{
@@ -129,15 +152,19 @@ func doDescribeAWS(ctx context.Context, logger *zap.Logger, job describe.Describ
 		if len(name) > 0 {
 			kafkaResource.Metadata["name"] = name
 		}
+		tags, tagsSource := tagEnricher.Enrich(ctx, resource.Region, resource.ARN, tags)
+		if tagsSource != "" {
+			metadata["TagsSource"] = tagsSource
+		}
 
-		rs.Send(&golang.AWSResource{
+		send(&golang.AWSResource{
 			UniqueId:        resource.UniqueID(),
 			Arn:             resource.ARN,
 			Id:              resource.ID,
 			Name:            resource.Name,
 			Account:         job.AccountID,
 			Region:          resource.Region,
-			Partition:       partition,
+			Partition:       resource.Partition,
 			Type:            job.ResourceType,
 			DescriptionJson: string(descriptionJSON),
 			Metadata:        metadata,
@@ -160,19 +187,16 @@ func doDescribeAWS(ctx context.Context, logger *zap.Logger, job describe.Describ
 
 	logger.Info("Created Client Stream")
 
-	output, err := aws.GetResources(
-		ctx, logger,
+	output, err := aws.GetResourcesWithConfig(
+		ctx, logger, cfg,
 		job.ResourceType, job.TriggerType,
-		job.AccountID,
-		creds.Regions, creds.AccountID, creds.AccessKey, creds.SecretKey, creds.SessionToken, creds.AssumeRoleName, creds.AssumeAdminRoleName, creds.ExternalID,
+		job.AccountID, creds.Regions,
 		false, clientStream)
 	if err != nil {
-		return nil, fmt.Errorf("AWS: %w", err)
+		return fmt.Errorf("AWS: %w", err)
 	}
 	logger.Info("Finished getting resources", zap.Any("output", output))
 
-	rs.Finish()
-
 	var errs []string
 	for region, err := range output.Errors {
 		if err != "" {
@@ -189,13 +213,11 @@ func doDescribeAWS(ctx context.Context, logger *zap.Logger, job describe.Describ
 		err = nil
 	}
 
-	var kerr error
 	if err != nil {
-		kerr = KaytuError{
+		return KaytuError{
 			ErrCode: output.ErrorCode,
 			error:   err,
 		}
 	}
-
-	return rs.GetResourceIDs(), kerr
+	return nil
 }
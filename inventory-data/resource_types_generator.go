@@ -5,8 +5,13 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"os"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 )
@@ -45,6 +50,9 @@ var (
 	provider = flag.String("provider", "", "")
 	output   = flag.String("output", "", "")
 	indexMap = flag.String("index-map", "", "")
+
+	describerDir     = flag.String("describer-dir", "", "Directory of the describer package, scanned for the IAM actions of every describer")
+	describersOutput = flag.String("describers-output", "", "Location of the generated describer info file, skipped if empty")
 )
 
 func main() {
@@ -180,4 +188,269 @@ var %[1]sReverseMap = map[string]string{
 	if err != nil {
 		panic(err)
 	}
+
+	if *describersOutput != "" {
+		generateDescriberInfos(resourceTypes, *provider, *describerDir, *describersOutput)
+	}
+}
+
+// iamServicePrefixes maps the sdk service packages whose IAM service prefix is not the package
+// name.
+var iamServicePrefixes = map[string]string{
+	"accessanalyzer":                  "access-analyzer",
+	"acmpca":                          "acm-pca",
+	"amp":                             "aps",
+	"apigatewayv2":                    "apigateway",
+	"applicationautoscaling":          "application-autoscaling",
+	"cloudcontrol":                    "cloudformation",
+	"cloudwatchlogs":                  "logs",
+	"configservice":                   "config",
+	"costexplorer":                    "ce",
+	"databasemigrationservice":        "dms",
+	"directoryservice":                "ds",
+	"docdb":                           "rds",
+	"dynamodbstreams":                 "dynamodb",
+	"ecrpublic":                       "ecr-public",
+	"efs":                             "elasticfilesystem",
+	"elasticloadbalancingv2":          "elasticloadbalancing",
+	"elasticsearchservice":            "es",
+	"emr":                             "elasticmapreduce",
+	"eventbridge":                     "events",
+	"keyspaces":                       "cassandra",
+	"kinesisanalyticsv2":              "kinesisanalytics",
+	"mwaa":                            "airflow",
+	"neptune":                         "rds",
+	"networkfirewall":                 "network-firewall",
+	"opensearch":                      "es",
+	"opensearchserverless":            "aoss",
+	"opsworkscm":                      "opsworks-cm",
+	"pinpoint":                        "mobiletargeting",
+	"redshiftserverless":              "redshift-serverless",
+	"resourceexplorer2":               "resource-explorer-2",
+	"resourcegroups":                  "resource-groups",
+	"resourcegroupstaggingapi":        "tag",
+	"s3control":                       "s3",
+	"serverlessapplicationrepository": "serverlessrepo",
+	"sesv2":                           "ses",
+	"sfn":                             "states",
+	"ssoadmin":                        "sso",
+	"timestreamwrite":                 "timestream",
+	"wafregional":                     "waf-regional",
+}
+
+// iamActionNames maps the sdk operations, by service package, whose IAM action is not named
+// after the operation.
+var iamActionNames = map[string]string{
+	"keyspaces:GetKeyspace":                         "cassandra:Select",
+	"keyspaces:GetTable":                            "cassandra:Select",
+	"keyspaces:ListKeyspaces":                       "cassandra:Select",
+	"keyspaces:ListTables":                          "cassandra:Select",
+	"keyspaces:ListTagsForResource":                 "cassandra:Select",
+	"s3:GetBucketAccelerateConfiguration":           "s3:GetAccelerateConfiguration",
+	"s3:GetBucketEncryption":                        "s3:GetEncryptionConfiguration",
+	"s3:GetBucketLifecycleConfiguration":            "s3:GetLifecycleConfiguration",
+	"s3:GetBucketNotificationConfiguration":         "s3:GetBucketNotification",
+	"s3:GetBucketReplication":                       "s3:GetReplicationConfiguration",
+	"s3:GetObjectLockConfiguration":                 "s3:GetBucketObjectLockConfiguration",
+	"s3:GetPublicAccessBlock":                       "s3:GetBucketPublicAccessBlock",
+	"s3:ListBucketAnalyticsConfigurations":          "s3:GetAnalyticsConfiguration",
+	"s3:ListBucketIntelligentTieringConfigurations": "s3:GetIntelligentTieringConfiguration",
+	"s3:ListBucketInventoryConfigurations":          "s3:GetInventoryConfiguration",
+	"s3:ListBuckets":                                "s3:ListAllMyBuckets",
+	"s3:ListObjectsV2":                              "s3:ListBucket",
+	"s3control:GetPublicAccessBlock":                "s3:GetAccountPublicAccessBlock",
+}
+
+// apiGatewayMethods maps the verb of the API Gateway operations to the HTTP method IAM
+// authorizes them by, apigateway:GET for every Get operation.
+var apiGatewayMethods = map[string]string{
+	"Create": "POST",
+	"Delete": "DELETE",
+	"Get":    "GET",
+	"Import": "PUT",
+	"Put":    "PUT",
+	"Update": "PATCH",
+}
+
+var describerFuncRe = regexp.MustCompile(`describer\.(\w+)`)
+
+// generateDescriberInfos writes, for every resource type, its list and get describer functions
+// and the IAM actions of the sdk calls they make, found by walking the describer sources.
+func generateDescriberInfos(resourceTypes []ResourceType, provider, dir, output string) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		panic(err)
+	}
+
+	scanner := describerScanner{funcs: map[string]*ast.FuncDecl{}, imports: map[*ast.FuncDecl]map[string]string{}}
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			imports := map[string]string{}
+			for _, spec := range f.Imports {
+				path, _ := strconv.Unquote(spec.Path.Value)
+				name := path[strings.LastIndex(path, "/")+1:]
+				if spec.Name != nil {
+					imports[spec.Name.Name] = name
+				} else {
+					imports[name] = name
+				}
+			}
+			for _, decl := range f.Decls {
+				if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+					scanner.funcs[fn.Name.Name] = fn
+					scanner.imports[fn] = imports
+				}
+			}
+		}
+	}
+
+	b := &strings.Builder{}
+	b.WriteString(fmt.Sprintf(`// Code is generated by go generate. DO NOT EDIT.
+
+package %s
+
+var describerInfos = map[string]DescriberInfo{
+`, provider))
+	for _, resourceType := range resourceTypes {
+		if resourceType.Discovery == DiscoveryStatus_DISABLED {
+			continue
+		}
+		var listFunc, getFunc string
+		if m := describerFuncRe.FindStringSubmatch(resourceType.ListDescriber); m != nil {
+			listFunc = m[1]
+		}
+		if m := describerFuncRe.FindStringSubmatch(resourceType.GetDescriber); m != nil {
+			getFunc = m[1]
+		}
+
+		actions := map[string]bool{}
+		for _, name := range []string{listFunc, getFunc} {
+			if name != "" {
+				scanner.actions(name, actions, map[string]bool{})
+			}
+		}
+		var sorted []string
+		for action := range actions {
+			sorted = append(sorted, strconv.Quote(action))
+		}
+		sort.Strings(sorted)
+
+		b.WriteString(fmt.Sprintf("\t%q: {\n\t\tListDescriber: %q,\n\t\tGetDescriber: %q,\n\t\tActions: []string{%s},\n\t},\n",
+			resourceType.ResourceName, listFunc, getFunc, strings.Join(sorted, ", ")))
+	}
+	b.WriteString("}\n")
+
+	err = os.WriteFile(output, []byte(b.String()), os.ModePerm)
+	if err != nil {
+		panic(err)
+	}
+}
+
+type describerScanner struct {
+	funcs   map[string]*ast.FuncDecl
+	imports map[*ast.FuncDecl]map[string]string
+}
+
+// actions adds the IAM actions of the sdk calls of the function, and of the describer package
+// functions it calls, to actions.
+func (s describerScanner) actions(name string, actions, visited map[string]bool) {
+	fn, ok := s.funcs[name]
+	if !ok || visited[name] || fn.Body == nil {
+		return
+	}
+	visited[name] = true
+	imports := s.imports[fn]
+
+	// clients are the variables holding an sdk client, by the service package of the client.
+	clients := map[string]string{}
+	for _, field := range fn.Type.Params.List {
+		if service := clientType(field.Type, imports); service != "" {
+			for _, n := range field.Names {
+				clients[n.Name] = service
+			}
+		}
+	}
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for i, rhs := range n.Rhs {
+				call, ok := rhs.(*ast.CallExpr)
+				if !ok || i >= len(n.Lhs) {
+					continue
+				}
+				sel, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok || sel.Sel.Name != "NewFromConfig" {
+					continue
+				}
+				if pkg, ok := sel.X.(*ast.Ident); ok && imports[pkg.Name] != "" {
+					if lhs, ok := n.Lhs[i].(*ast.Ident); ok {
+						clients[lhs.Name] = imports[pkg.Name]
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			if service := clientType(n.Type, imports); service != "" {
+				for _, name := range n.Names {
+					clients[name.Name] = service
+				}
+			}
+		case *ast.CallExpr:
+			switch fun := n.Fun.(type) {
+			case *ast.Ident:
+				s.actions(fun.Name, actions, visited)
+			case *ast.SelectorExpr:
+				x, ok := fun.X.(*ast.Ident)
+				if !ok {
+					return true
+				}
+				if service, ok := clients[x.Name]; ok {
+					if fun.Sel.Name != "Options" {
+						actions[iamAction(service, fun.Sel.Name)] = true
+					}
+				} else if service := imports[x.Name]; service != "" && strings.HasPrefix(fun.Sel.Name, "New") && strings.HasSuffix(fun.Sel.Name, "Paginator") {
+					op := strings.TrimSuffix(strings.TrimPrefix(fun.Sel.Name, "New"), "Paginator")
+					actions[iamAction(service, op)] = true
+				}
+			}
+		}
+		return true
+	})
+}
+
+// clientType returns the service package of expr when it is an sdk client type, *service.Client.
+func clientType(expr ast.Expr, imports map[string]string) string {
+	star, ok := expr.(*ast.StarExpr)
+	if !ok {
+		return ""
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Client" {
+		return ""
+	}
+	if pkg, ok := sel.X.(*ast.Ident); ok {
+		return imports[pkg.Name]
+	}
+	return ""
+}
+
+func iamAction(service, operation string) string {
+	if a, ok := iamActionNames[service+":"+operation]; ok {
+		return a
+	}
+	prefix := service
+	if p, ok := iamServicePrefixes[service]; ok {
+		prefix = p
+	}
+	if prefix == "apigateway" {
+		for verb, method := range apiGatewayMethods {
+			if strings.HasPrefix(operation, verb) {
+				return prefix + ":" + method
+			}
+		}
+	}
+	return prefix + ":" + operation
 }
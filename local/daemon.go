@@ -0,0 +1,370 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/opengovern/og-aws-describer/describer"
+	"github.com/opengovern/og-util/pkg/describe"
+	"github.com/opengovern/og-util/pkg/describe/enums"
+	"github.com/opengovern/og-util/pkg/source"
+	"github.com/opengovern/og-util/proto/src/golang"
+	"github.com/robfig/cron/v3"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// DaemonCommand runs the describe jobs of a config file on its schedules, without the
+// scheduler, the job queue or the vault of a full deployment.
+func DaemonCommand() *cobra.Command {
+	var configPath string
+	var once bool
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Describe the accounts of a config file on its cron schedules",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			cmd.SilenceUsage = true
+			logger, err := zap.NewProduction()
+			if err != nil {
+				return err
+			}
+
+			cnf, err := LoadDaemonConfig(configPath)
+			if err != nil {
+				return err
+			}
+			d, err := NewDaemon(ctx, cnf, logger)
+			if err != nil {
+				return err
+			}
+			defer d.Close()
+
+			if once {
+				return d.RunOnce(ctx)
+			}
+			return d.Run(ctx)
+		},
+	}
+	cmd.Flags().StringVar(&configPath, "config", "", "Path of the daemon config file")
+	cmd.Flags().BoolVar(&once, "once", false, "Run every schedule once, wait for the jobs and exit")
+	cmd.MarkFlagRequired("config")
+
+	return cmd
+}
+
+type Daemon struct {
+	config    DaemonConfig
+	logger    *zap.Logger
+	store     *Store
+	sinks     []Sink
+	schedules []*daemonSchedule
+	accounts  map[string]DaemonAccount
+
+	// slots limits the jobs run at the same time across schedules.
+	slots chan struct{}
+	wg    sync.WaitGroup
+
+	mu sync.Mutex
+	// running are the account and resource type pairs with a job in progress, a schedule
+	// firing while the previous job of a pair is still running skips the pair.
+	running map[string]bool
+}
+
+type daemonSchedule struct {
+	DaemonSchedule
+	cron          cron.Schedule
+	resourceTypes []string
+	// slots limits the jobs of the schedule run at the same time, nil without a limit.
+	slots chan struct{}
+}
+
+func NewDaemon(ctx context.Context, config DaemonConfig, logger *zap.Logger) (*Daemon, error) {
+	d := &Daemon{
+		config:   config,
+		logger:   logger,
+		accounts: map[string]DaemonAccount{},
+		slots:    make(chan struct{}, config.Concurrency),
+		running:  map[string]bool{},
+	}
+	for _, account := range config.Accounts {
+		d.accounts[account.AccountID] = account
+	}
+
+	for _, schedule := range config.Schedules {
+		sched, err := cron.ParseStandard(schedule.Cron)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %s: %w", schedule.Name, err)
+		}
+		types, err := ScheduleResourceTypes(schedule)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %s: %w", schedule.Name, err)
+		}
+		if len(schedule.Accounts) == 0 {
+			for _, account := range config.Accounts {
+				schedule.Accounts = append(schedule.Accounts, account.AccountID)
+			}
+		}
+		s := &daemonSchedule{
+			DaemonSchedule: schedule,
+			cron:           sched,
+			resourceTypes:  types,
+		}
+		if schedule.Concurrency > 0 {
+			s.slots = make(chan struct{}, schedule.Concurrency)
+		}
+		d.schedules = append(d.schedules, s)
+	}
+
+	for _, sinkConfig := range config.Sinks {
+		sink, err := NewSink(sinkConfig, logger)
+		if err != nil {
+			return nil, err
+		}
+		d.sinks = append(d.sinks, sink)
+	}
+
+	store, err := OpenStore(ctx, config.Store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store %s: %w", config.Store, err)
+	}
+	d.store = store
+
+	aborted, err := store.AbortRunningJobs(ctx, "daemon stopped before the job finished", time.Now())
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+	if aborted > 0 {
+		logger.Warn("failed jobs left in progress", zap.Int64("jobs", aborted))
+	}
+	return d, nil
+}
+
+func (d *Daemon) Close() error {
+	return d.store.Close()
+}
+
+// Run triggers the schedules until ctx is done, then waits for the running jobs. A schedule
+// that was due while the daemon was stopped is triggered once when it starts.
+func (d *Daemon) Run(ctx context.Context) error {
+	next := map[*daemonSchedule]time.Time{}
+	for _, s := range d.schedules {
+		last, ok, err := d.store.LastRun(ctx, s.Name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			last = time.Now()
+		}
+		next[s] = s.cron.Next(last)
+		d.logger.Info("scheduled", zap.String("schedule", s.Name), zap.Time("next", next[s]))
+	}
+
+	for {
+		var s *daemonSchedule
+		for _, candidate := range d.schedules {
+			if s == nil || next[candidate].Before(next[s]) {
+				s = candidate
+			}
+		}
+
+		timer := time.NewTimer(time.Until(next[s]))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			d.logger.Info("stopping, waiting for the running jobs")
+			d.wg.Wait()
+			return nil
+		case <-timer.C:
+		}
+
+		if err := d.trigger(ctx, s, time.Now()); err != nil {
+			d.logger.Error("failed to trigger schedule", zap.String("schedule", s.Name), zap.Error(err))
+		}
+		next[s] = s.cron.Next(time.Now())
+	}
+}
+
+// RunOnce triggers every schedule and waits for the jobs, it fails if any of them failed.
+func (d *Daemon) RunOnce(ctx context.Context) error {
+	var failed int
+	var mu sync.Mutex
+	for _, s := range d.schedules {
+		if err := d.triggerWith(ctx, s, time.Now(), func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			failed++
+		}); err != nil {
+			return err
+		}
+	}
+	d.wg.Wait()
+
+	if failed > 0 {
+		return fmt.Errorf("%d jobs failed", failed)
+	}
+	return nil
+}
+
+func (d *Daemon) trigger(ctx context.Context, s *daemonSchedule, at time.Time) error {
+	return d.triggerWith(ctx, s, at, func(error) {})
+}
+
+// triggerWith starts a job for every account and resource type of s, onFailure is called with
+// the error of the jobs that fail.
+func (d *Daemon) triggerWith(ctx context.Context, s *daemonSchedule, at time.Time, onFailure func(error)) error {
+	if err := d.store.SetLastRun(ctx, s.Name, at); err != nil {
+		return err
+	}
+	d.logger.Info("triggering schedule", zap.String("schedule", s.Name),
+		zap.Int("accounts", len(s.Accounts)), zap.Int("resourceTypes", len(s.resourceTypes)))
+
+	for _, accountID := range s.Accounts {
+		account := d.accounts[accountID]
+		for _, resourceType := range s.resourceTypes {
+			key := accountID + "/" + resourceType
+			d.mu.Lock()
+			if d.running[key] {
+				d.mu.Unlock()
+				d.logger.Warn("skipping job, the previous one is still running", zap.String("schedule", s.Name),
+					zap.String("account", accountID), zap.String("type", resourceType))
+				continue
+			}
+			d.running[key] = true
+			d.mu.Unlock()
+
+			d.wg.Add(1)
+			go func(resourceType string) {
+				defer d.wg.Done()
+				defer func() {
+					d.mu.Lock()
+					delete(d.running, key)
+					d.mu.Unlock()
+				}()
+
+				if err := d.runJob(ctx, s, account, resourceType); err != nil {
+					onFailure(err)
+				}
+			}(resourceType)
+		}
+	}
+	return nil
+}
+
+// runJob describes resourceType in account once a slot of s and of the daemon are free, and
+// records the job and the resources it found in the store.
+func (d *Daemon) runJob(ctx context.Context, s *daemonSchedule, account DaemonAccount, resourceType string) (err error) {
+	if s.slots != nil {
+		select {
+		case s.slots <- struct{}{}:
+			defer func() { <-s.slots }()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	select {
+	case d.slots <- struct{}{}:
+		defer func() { <-d.slots }()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	startedAt := time.Now()
+	jobID, err := d.store.StartJob(ctx, s.Name, account.AccountID, resourceType, startedAt)
+	if err != nil {
+		d.logger.Error("failed to record job", zap.Error(err))
+		return err
+	}
+	logger := d.logger.With(zap.Uint("jobID", jobID), zap.String("schedule", s.Name),
+		zap.String("account", account.AccountID), zap.String("type", resourceType))
+
+	job := describe.DescribeJob{
+		JobID:        jobID,
+		ResourceType: resourceType,
+		SourceID:     account.SourceID,
+		AccountID:    account.AccountID,
+		DescribedAt:  startedAt.UnixMilli(),
+		SourceType:   source.CloudAWS,
+		TriggerType:  enums.DescribeTriggerTypeScheduled,
+	}
+
+	var seen []SeenResource
+	var seenMu sync.Mutex
+	var writers []SinkWriter
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("paniced with error: %v", r)
+		}
+
+		var kerr describer.KaytuError
+		complete := err == nil || errors.As(err, &kerr)
+		for _, w := range writers {
+			if closeErr := w.Close(complete); closeErr != nil && err == nil {
+				err = fmt.Errorf("sink: %w", closeErr)
+			}
+		}
+		d.finishJob(logger, job, err, seen, complete)
+	}()
+
+	for _, sink := range d.sinks {
+		w, err := sink.Open(job)
+		if err != nil {
+			return fmt.Errorf("sink: %w", err)
+		}
+		writers = append(writers, w)
+	}
+
+	logger.Info("running job")
+	return describer.DescribeAWS(ctx, logger, job, account.AccountConfig, func(resource *golang.AWSResource) {
+		seenMu.Lock()
+		seen = append(seen, SeenResource{UniqueID: resource.UniqueId, Name: resource.Name, Region: resource.Region})
+		seenMu.Unlock()
+
+		for _, w := range writers {
+			w.Send(resource)
+		}
+	})
+}
+
+// finishJob records the outcome of job and, if it described every region it could, the
+// resources it found.
+func (d *Daemon) finishJob(logger *zap.Logger, job describe.DescribeJob, err error, seen []SeenResource, complete bool) {
+	// the job is recorded even when the daemon is stopping.
+	ctx := context.Background()
+	finishedAt := time.Now()
+
+	status, errMsg, errCode := JobSucceeded, "", ""
+	if err != nil {
+		status, errMsg = JobFailed, err.Error()
+		var kerr describer.KaytuError
+		if errors.As(err, &kerr) {
+			errCode = kerr.ErrCode
+		}
+		logger.Error("job failed", zap.Error(err))
+	}
+
+	if complete {
+		describedAt := time.UnixMilli(job.DescribedAt)
+		if storeErr := d.store.MarkSeen(ctx, job.JobID, job.AccountID, job.ResourceType, seen, describedAt); storeErr != nil {
+			logger.Error("failed to record seen resources", zap.Error(storeErr))
+		} else if err == nil {
+			// with failing regions, the resources of these regions are not found either.
+			unseen, storeErr := d.store.CountUnseen(ctx, job.JobID, job.AccountID, job.ResourceType)
+			if storeErr != nil {
+				logger.Error("failed to count unseen resources", zap.Error(storeErr))
+			} else if unseen > 0 {
+				logger.Info("resources no longer found", zap.Int("count", unseen))
+			}
+		}
+	}
+
+	if storeErr := d.store.FinishJob(ctx, job.JobID, status, errMsg, errCode, len(seen), finishedAt); storeErr != nil {
+		logger.Error("failed to record job outcome", zap.Error(storeErr))
+	}
+	logger.Info("job done", zap.String("status", status), zap.Int("resources", len(seen)),
+		zap.Duration("duration", finishedAt.Sub(time.UnixMilli(job.DescribedAt))))
+}
@@ -0,0 +1,179 @@
+package local
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/opengovern/og-aws-describer/aws"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	DefaultDaemonStore       = "og-aws-describer.db"
+	DefaultDaemonConcurrency = 4
+)
+
+// DaemonConfig is the config file of the daemon, in YAML or JSON.
+type DaemonConfig struct {
+	// Store is the path of the SQLite database the job history and the last seen state are
+	// kept in.
+	Store string `json:"store"`
+	// Concurrency is the number of jobs run at the same time, across schedules.
+	Concurrency int                `json:"concurrency"`
+	Accounts    []DaemonAccount    `json:"accounts"`
+	Schedules   []DaemonSchedule   `json:"schedules"`
+	Sinks       []DaemonSinkConfig `json:"sinks"`
+}
+
+// DaemonAccount is an account described by the daemon. Without an access key, the credentials
+// are loaded from the default AWS chain: environment, shared config (AWS_PROFILE) or instance
+// role.
+type DaemonAccount struct {
+	aws.AccountConfig
+	// SourceID identifies the account in the documents sent to the sinks, the account id when
+	// empty.
+	SourceID string `json:"sourceId"`
+}
+
+// DaemonSchedule describes resource types of accounts on a cron schedule.
+type DaemonSchedule struct {
+	Name string `json:"name"`
+	// Cron is a standard five field cron expression or a descriptor such as @hourly or
+	// @every 6h, optionally prefixed with CRON_TZ=<zone>.
+	Cron string `json:"cron"`
+	// Accounts are the ids of the accounts described, all of them when empty.
+	Accounts []string `json:"accounts"`
+	// ResourceTypes are described, all of them or the fast discovery ones with FastOnly when
+	// empty.
+	ResourceTypes []string `json:"resourceTypes"`
+	FastOnly      bool     `json:"fastOnly"`
+	// Concurrency is the number of jobs of the schedule run at the same time, only the daemon
+	// limit applies when zero.
+	Concurrency int `json:"concurrency"`
+}
+
+// DaemonSinkConfig is a destination of the described resources.
+type DaemonSinkConfig struct {
+	// Type is jsonl, essink or opensearch.
+	Type string `json:"type"`
+	// Directory the jsonl sink writes to, in the layout of describe-all.
+	Directory string `json:"directory"`
+	Gzip      bool   `json:"gzip"`
+	// Endpoint is the address of the gRPC es sink or the URL of the OpenSearch ingestion
+	// pipeline.
+	Endpoint string `json:"endpoint"`
+	// AuthToken authenticates to the es sink, which is dialed with TLS when it is set.
+	AuthToken string `json:"authToken"`
+	// KeepHistory sends snapshot documents along the resources, see describer.HistoricalResource.
+	KeepHistory bool `json:"keepHistory"`
+}
+
+// LoadDaemonConfig reads and validates the daemon config file at path.
+func LoadDaemonConfig(path string) (DaemonConfig, error) {
+	var cnf DaemonConfig
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return cnf, err
+	}
+	if err := yaml.Unmarshal(content, &cnf); err != nil {
+		return cnf, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := cnf.validate(); err != nil {
+		return cnf, fmt.Errorf("%s: %w", path, err)
+	}
+	return cnf, nil
+}
+
+// validate checks the config and sets the defaults of the fields left empty.
+func (c *DaemonConfig) validate() error {
+	if c.Store == "" {
+		c.Store = DefaultDaemonStore
+	}
+	if c.Concurrency < 1 {
+		c.Concurrency = DefaultDaemonConcurrency
+	}
+
+	if len(c.Accounts) == 0 {
+		return fmt.Errorf("no accounts")
+	}
+	accounts := map[string]bool{}
+	for i, account := range c.Accounts {
+		if account.AccountID == "" {
+			return fmt.Errorf("accounts[%d]: accountId is required", i)
+		}
+		if accounts[account.AccountID] {
+			return fmt.Errorf("accounts[%d]: duplicate account %s", i, account.AccountID)
+		}
+		accounts[account.AccountID] = true
+		if account.SourceID == "" {
+			c.Accounts[i].SourceID = account.AccountID
+		}
+	}
+
+	if len(c.Schedules) == 0 {
+		return fmt.Errorf("no schedules")
+	}
+	schedules := map[string]bool{}
+	for i, schedule := range c.Schedules {
+		if schedule.Name == "" {
+			return fmt.Errorf("schedules[%d]: name is required", i)
+		}
+		if schedules[schedule.Name] {
+			return fmt.Errorf("schedules[%d]: duplicate schedule %s", i, schedule.Name)
+		}
+		schedules[schedule.Name] = true
+		if _, err := cron.ParseStandard(schedule.Cron); err != nil {
+			return fmt.Errorf("schedule %s: invalid cron %q: %w", schedule.Name, schedule.Cron, err)
+		}
+		for _, account := range schedule.Accounts {
+			if !accounts[account] {
+				return fmt.Errorf("schedule %s: unknown account %s", schedule.Name, account)
+			}
+		}
+		for _, resourceType := range schedule.ResourceTypes {
+			if _, err := aws.GetResourceType(resourceType); err != nil {
+				return fmt.Errorf("schedule %s: %w", schedule.Name, err)
+			}
+		}
+	}
+
+	if len(c.Sinks) == 0 {
+		return fmt.Errorf("no sinks")
+	}
+	for i, sink := range c.Sinks {
+		switch sink.Type {
+		case SinkJSONL:
+			if sink.Directory == "" {
+				return fmt.Errorf("sinks[%d]: directory is required", i)
+			}
+		case SinkESSink, SinkOpenSearch:
+			if sink.Endpoint == "" {
+				return fmt.Errorf("sinks[%d]: endpoint is required", i)
+			}
+		default:
+			return fmt.Errorf("sinks[%d]: unknown type %q", i, sink.Type)
+		}
+	}
+	return nil
+}
+
+// ScheduleResourceTypes returns the resource types described by schedule.
+func ScheduleResourceTypes(schedule DaemonSchedule) ([]string, error) {
+	if len(schedule.ResourceTypes) == 0 {
+		if schedule.FastOnly {
+			return aws.ListFastDiscoveryResourceTypes(), nil
+		}
+		return aws.ListResourceTypes(), nil
+	}
+
+	var types []string
+	for _, name := range schedule.ResourceTypes {
+		rt, err := aws.GetResourceType(name)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, rt.ResourceName)
+	}
+	return types, nil
+}
@@ -0,0 +1,226 @@
+package local
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opengovern/og-util/pkg/describe"
+	"github.com/opengovern/og-util/proto/src/golang"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "daemon.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadDaemonConfig(t *testing.T) {
+	cnf, err := LoadDaemonConfig(writeConfig(t, `
+accounts:
+  - accountId: "123456789012"
+    regions: [us-east-1]
+    assumeRoleName: describer
+    endpoints:
+      url: http://localhost:4566
+schedules:
+  - name: fast
+    cron: "@every 30m"
+    fastOnly: true
+  - name: instances
+    cron: "0 2 * * *"
+    accounts: ["123456789012"]
+    resourceTypes: [aws::ec2::instance]
+sinks:
+  - type: jsonl
+    directory: inventory
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cnf.Store != DefaultDaemonStore || cnf.Concurrency != DefaultDaemonConcurrency {
+		t.Errorf("defaults not set, store %q concurrency %d", cnf.Store, cnf.Concurrency)
+	}
+	account := cnf.Accounts[0]
+	if account.AssumeRoleName != "describer" || account.SourceID != "123456789012" ||
+		account.Endpoints == nil || account.Endpoints.URL != "http://localhost:4566" {
+		t.Errorf("unexpected account %+v", account)
+	}
+	types, err := ScheduleResourceTypes(cnf.Schedules[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(types) != 1 || types[0] != "AWS::EC2::Instance" {
+		t.Errorf("got resource types %v", types)
+	}
+}
+
+func TestLoadDaemonConfigInvalid(t *testing.T) {
+	account := "accounts: [{accountId: \"123456789012\"}]\n"
+	schedule := "schedules: [{name: all, cron: \"@daily\"}]\n"
+	sink := "sinks: [{type: jsonl, directory: out}]\n"
+	tests := map[string]struct {
+		config string
+		want   string
+	}{
+		"no accounts":      {schedule + sink, "no accounts"},
+		"no sinks":         {account + schedule, "no sinks"},
+		"invalid cron":     {account + sink + "schedules: [{name: all, cron: \"* *\"}]\n", "invalid cron"},
+		"unknown account":  {account + sink + "schedules: [{name: all, cron: \"@daily\", accounts: [\"1\"]}]\n", "unknown account 1"},
+		"unknown type":     {account + sink + "schedules: [{name: all, cron: \"@daily\", resourceTypes: [AWS::Foo::Bar]}]\n", "aws::foo::bar not found"},
+		"unknown sink":     {account + schedule + "sinks: [{type: kafka}]\n", "unknown type \"kafka\""},
+		"duplicate sched":  {account + sink + "schedules: [{name: all, cron: \"@daily\"}, {name: all, cron: \"@hourly\"}]\n", "duplicate schedule all"},
+		"missing endpoint": {account + schedule + "sinks: [{type: essink}]\n", "endpoint is required"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadDaemonConfig(writeConfig(t, tt.config))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got error %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "state.db")
+	store, err := OpenStore(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok, err := store.LastRun(ctx, "nightly"); err != nil || ok {
+		t.Fatalf("got last run %v %v for a new store", ok, err)
+	}
+	at := time.UnixMilli(time.Now().UnixMilli())
+	if err := store.SetLastRun(ctx, "nightly", at); err != nil {
+		t.Fatal(err)
+	}
+	if last, ok, err := store.LastRun(ctx, "nightly"); err != nil || !ok || !last.Equal(at) {
+		t.Fatalf("got last run %v %v %v, want %v", last, ok, err, at)
+	}
+
+	first, err := store.StartJob(ctx, "nightly", "123456789012", "AWS::EC2::Instance", at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.MarkSeen(ctx, first, "123456789012", "AWS::EC2::Instance", []SeenResource{
+		{UniqueID: "i-1", Name: "one", Region: "us-east-1"},
+		{UniqueID: "i-2", Name: "two", Region: "us-east-1"},
+	}, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.FinishJob(ctx, first, JobSucceeded, "", "", 2, at); err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := store.StartJob(ctx, "nightly", "123456789012", "AWS::EC2::Instance", at.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = store.MarkSeen(ctx, second, "123456789012", "AWS::EC2::Instance", []SeenResource{
+		{UniqueID: "i-2", Name: "renamed", Region: "us-east-1"},
+	}, at.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unseen, err := store.CountUnseen(ctx, second, "123456789012", "AWS::EC2::Instance"); err != nil || unseen != 1 {
+		t.Errorf("got %d unseen resources, %v, want 1", unseen, err)
+	}
+	var name string
+	var firstSeen, lastSeen int64
+	err = store.db.QueryRow(`SELECT name, first_seen_at, last_seen_at FROM resources WHERE unique_id = 'i-2'`).
+		Scan(&name, &firstSeen, &lastSeen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "renamed" || firstSeen != at.UnixMilli() || lastSeen != at.Add(time.Hour).UnixMilli() {
+		t.Errorf("got i-2 %s seen from %d to %d", name, firstSeen, lastSeen)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the second job is left in progress, as if the daemon stopped, reopening fails it.
+	store, err = OpenStore(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if aborted, err := store.AbortRunningJobs(ctx, "stopped", at); err != nil || aborted != 1 {
+		t.Fatalf("aborted %d jobs, %v, want 1", aborted, err)
+	}
+	var status string
+	if err := store.db.QueryRow(`SELECT status FROM jobs WHERE id = ?`, second).Scan(&status); err != nil {
+		t.Fatal(err)
+	}
+	if status != JobFailed {
+		t.Errorf("got status %s, want %s", status, JobFailed)
+	}
+}
+
+func TestJSONLSink(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewSink(DaemonSinkConfig{Type: SinkJSONL, Directory: dir}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := describe.DescribeJob{JobID: 1, AccountID: "123456789012", ResourceType: "AWS::EC2::Instance"}
+	path := filepath.Join(dir, "123456789012", "aws_ec2_instance.jsonl")
+	resource := &golang.AWSResource{
+		Arn:             "arn:aws:ec2:us-east-1:123456789012:instance/i-1",
+		Id:              "i-1",
+		Account:         "123456789012",
+		Region:          "us-east-1",
+		Partition:       "aws",
+		Type:            "AWS::EC2::Instance",
+		DescriptionJson: `{"Instance":{"InstanceId":"i-1"}}`,
+	}
+
+	w, err := sink.Open(job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Send(resource)
+	if err := w.Close(true); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"ARN":"arn:aws:ec2:us-east-1:123456789012:instance/i-1","ID":"i-1","Description":{"Instance":{"InstanceId":"i-1"}},` +
+		`"Name":"","Account":"123456789012","Region":"us-east-1","Partition":"aws","Type":"aws::ec2::instance"}` + "\n"
+	if string(content) != want {
+		t.Errorf("got %s, want %s", content, want)
+	}
+
+	// an incomplete job keeps the file of the previous one.
+	w, err = sink.Open(job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(false); err != nil {
+		t.Fatal(err)
+	}
+	if after, err := os.ReadFile(path); err != nil || string(after) != want {
+		t.Errorf("got %s, %v after an incomplete job", after, err)
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("got %d files, want the temporary file removed", len(entries))
+	}
+}
@@ -28,7 +28,9 @@ func main() {
 		}
 	}()
 
-	if err := local.WorkerCommand().ExecuteContext(ctx); err != nil {
+	cmd := local.WorkerCommand()
+	cmd.AddCommand(local.DaemonCommand())
+	if err := cmd.ExecuteContext(ctx); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
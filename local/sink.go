@@ -0,0 +1,192 @@
+package local
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/opengovern/og-aws-describer/describer"
+	"github.com/opengovern/og-util/pkg/describe"
+	"github.com/opengovern/og-util/pkg/es"
+	"github.com/opengovern/og-util/proto/src/golang"
+	"go.uber.org/zap"
+)
+
+const (
+	SinkJSONL      = "jsonl"
+	SinkESSink     = "essink"
+	SinkOpenSearch = "opensearch"
+)
+
+// Sink is a destination of the resources described by the daemon.
+type Sink interface {
+	// Open returns the writer of the resources of job.
+	Open(job describe.DescribeJob) (SinkWriter, error)
+}
+
+// SinkWriter receives the resources of a single job. Send is called from the goroutine of
+// every described region.
+type SinkWriter interface {
+	Send(resource *golang.AWSResource)
+	// Close is called once the job is done, complete is false if the job failed before the
+	// resources of all the regions were sent.
+	Close(complete bool) error
+}
+
+// NewSink returns the sink of cnf.
+func NewSink(cnf DaemonSinkConfig, logger *zap.Logger) (Sink, error) {
+	switch cnf.Type {
+	case SinkJSONL:
+		return jsonlSink{directory: cnf.Directory, gzip: cnf.Gzip}, nil
+	case SinkESSink:
+		return resourceSenderSink{grpcEndpoint: cnf.Endpoint, authToken: cnf.AuthToken, keepHistory: cnf.KeepHistory, logger: logger}, nil
+	case SinkOpenSearch:
+		return resourceSenderSink{ingestionPipelineEndpoint: cnf.Endpoint, useOpenSearch: true, keepHistory: cnf.KeepHistory, logger: logger}, nil
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", cnf.Type)
+	}
+}
+
+// resourceSenderSink sends the resources to the es sink or to the OpenSearch ingestion
+// pipeline, as the worker does.
+type resourceSenderSink struct {
+	grpcEndpoint              string
+	ingestionPipelineEndpoint string
+	authToken                 string
+	useOpenSearch             bool
+	keepHistory               bool
+	logger                    *zap.Logger
+}
+
+func (s resourceSenderSink) Open(job describe.DescribeJob) (SinkWriter, error) {
+	rs, err := describer.NewResourceSender(s.grpcEndpoint, s.ingestionPipelineEndpoint, s.authToken, job.JobID, s.useOpenSearch, s.logger)
+	if err != nil {
+		return nil, err
+	}
+	rs.SetKeepHistory(s.keepHistory)
+	return resourceSenderWriter{ResourceSender: rs, job: job}, nil
+}
+
+type resourceSenderWriter struct {
+	*describer.ResourceSender
+	job describe.DescribeJob
+}
+
+func (w resourceSenderWriter) Close(complete bool) error {
+	if complete {
+		w.FinishJob(w.job)
+	} else {
+		w.Finish()
+	}
+	return nil
+}
+
+// jsonlSink writes the resources of every account and resource type to a JSONL file, in the
+// layout of describe-all so the diff and query commands read the directory as an export. The
+// file of a job replaces the file of the previous job once it is complete.
+type jsonlSink struct {
+	directory string
+	gzip      bool
+}
+
+// jsonlResource is a line of the JSONL files, a describer.Resource with its description kept
+// as described.
+type jsonlResource struct {
+	ARN         string
+	ID          string
+	Description json.RawMessage
+
+	Name      string
+	Account   string
+	Region    string
+	Partition string
+	Type      string
+}
+
+func (s jsonlSink) Open(job describe.DescribeJob) (SinkWriter, error) {
+	path := filepath.Join(s.directory, job.AccountID, es.ResourceTypeToESIndex(job.ResourceType)+".jsonl")
+	if s.gzip {
+		path += ".gz"
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Chmod(0o644); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+
+	w := &jsonlWriter{path: path, file: file}
+	var out io.Writer = file
+	if s.gzip {
+		w.gz = gzip.NewWriter(file)
+		out = w.gz
+	}
+	w.buf = bufio.NewWriter(out)
+	w.enc = json.NewEncoder(w.buf)
+	return w, nil
+}
+
+type jsonlWriter struct {
+	path string
+
+	mu   sync.Mutex
+	file *os.File
+	gz   *gzip.Writer
+	buf  *bufio.Writer
+	enc  *json.Encoder
+	err  error
+}
+
+func (w *jsonlWriter) Send(resource *golang.AWSResource) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err != nil {
+		return
+	}
+	w.err = w.enc.Encode(jsonlResource{
+		ARN:         resource.Arn,
+		ID:          resource.Id,
+		Description: json.RawMessage(resource.DescriptionJson),
+		Name:        resource.Name,
+		Account:     resource.Account,
+		Region:      resource.Region,
+		Partition:   resource.Partition,
+		Type:        strings.ToLower(resource.Type),
+	})
+}
+
+func (w *jsonlWriter) Close(complete bool) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	err := w.err
+	if flushErr := w.buf.Flush(); err == nil {
+		err = flushErr
+	}
+	if w.gz != nil {
+		if gzErr := w.gz.Close(); err == nil {
+			err = gzErr
+		}
+	}
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil || !complete {
+		os.Remove(w.file.Name())
+		return err
+	}
+	return os.Rename(w.file.Name(), w.path)
+}
@@ -0,0 +1,171 @@
+package local
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/opengovern/og-aws-describer/describer"
+	_ "modernc.org/sqlite"
+)
+
+const (
+	JobInProgress = "IN_PROGRESS"
+	JobSucceeded  = describer.DescribeResourceJobSucceeded
+	JobFailed     = describer.DescribeResourceJobFailed
+)
+
+const storeSchema = `
+CREATE TABLE IF NOT EXISTS jobs (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	schedule TEXT NOT NULL,
+	account_id TEXT NOT NULL,
+	resource_type TEXT NOT NULL,
+	status TEXT NOT NULL,
+	error TEXT NOT NULL DEFAULT '',
+	error_code TEXT NOT NULL DEFAULT '',
+	resource_count INTEGER NOT NULL DEFAULT 0,
+	started_at INTEGER NOT NULL,
+	finished_at INTEGER
+);
+CREATE INDEX IF NOT EXISTS jobs_account_type ON jobs (account_id, resource_type, started_at);
+CREATE TABLE IF NOT EXISTS schedule_runs (
+	schedule TEXT PRIMARY KEY,
+	last_run_at INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS resources (
+	account_id TEXT NOT NULL,
+	resource_type TEXT NOT NULL,
+	unique_id TEXT NOT NULL,
+	name TEXT NOT NULL,
+	region TEXT NOT NULL,
+	first_seen_at INTEGER NOT NULL,
+	last_seen_at INTEGER NOT NULL,
+	last_job_id INTEGER NOT NULL,
+	PRIMARY KEY (account_id, resource_type, unique_id)
+);
+`
+
+// Store keeps the job history and the last seen state of the daemon in a SQLite database.
+// Times are stored as unix milliseconds.
+type Store struct {
+	db *sql.DB
+}
+
+// SeenResource is a resource found by a job.
+type SeenResource struct {
+	UniqueID string
+	Name     string
+	Region   string
+}
+
+// OpenStore opens the store at path, creating it if needed.
+func OpenStore(ctx context.Context, path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// jobs finish from several goroutines, a single connection serializes the writes instead of
+	// failing them on a locked database.
+	db.SetMaxOpenConns(1)
+	if _, err := db.ExecContext(ctx, storeSchema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// StartJob records a job in progress and returns its id.
+func (s *Store) StartJob(ctx context.Context, schedule, accountID, resourceType string, startedAt time.Time) (uint, error) {
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO jobs (schedule, account_id, resource_type, status, started_at) VALUES (?, ?, ?, ?, ?)`,
+		schedule, accountID, resourceType, JobInProgress, startedAt.UnixMilli())
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	return uint(id), err
+}
+
+// FinishJob records the outcome of job id.
+func (s *Store) FinishJob(ctx context.Context, id uint, status, errMsg, errCode string, resourceCount int, finishedAt time.Time) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE jobs SET status = ?, error = ?, error_code = ?, resource_count = ?, finished_at = ? WHERE id = ?`,
+		status, errMsg, errCode, resourceCount, finishedAt.UnixMilli(), id)
+	return err
+}
+
+// AbortRunningJobs fails the jobs left in progress by a daemon that stopped before they
+// finished, and returns how many there were.
+func (s *Store) AbortRunningJobs(ctx context.Context, reason string, at time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE jobs SET status = ?, error = ?, finished_at = ? WHERE status = ?`,
+		JobFailed, reason, at.UnixMilli(), JobInProgress)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// LastRun returns when schedule was last triggered, false if it never was.
+func (s *Store) LastRun(ctx context.Context, schedule string) (time.Time, bool, error) {
+	var at int64
+	err := s.db.QueryRowContext(ctx, `SELECT last_run_at FROM schedule_runs WHERE schedule = ?`, schedule).Scan(&at)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return time.UnixMilli(at), true, nil
+}
+
+// SetLastRun records that schedule was triggered at at.
+func (s *Store) SetLastRun(ctx context.Context, schedule string, at time.Time) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO schedule_runs (schedule, last_run_at) VALUES (?, ?)
+		ON CONFLICT (schedule) DO UPDATE SET last_run_at = excluded.last_run_at`,
+		schedule, at.UnixMilli())
+	return err
+}
+
+// MarkSeen records that job jobID found resources of resourceType in accountID at at.
+func (s *Store) MarkSeen(ctx context.Context, jobID uint, accountID, resourceType string, resources []SeenResource, at time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx,
+		`INSERT INTO resources (account_id, resource_type, unique_id, name, region, first_seen_at, last_seen_at, last_job_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (account_id, resource_type, unique_id) DO UPDATE SET
+			name = excluded.name, region = excluded.region, last_seen_at = excluded.last_seen_at, last_job_id = excluded.last_job_id`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, r := range resources {
+		if _, err := stmt.ExecContext(ctx, accountID, resourceType, r.UniqueID, r.Name, r.Region, at.UnixMilli(), at.UnixMilli(), jobID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// CountUnseen returns the number of resources of resourceType in accountID that job jobID did
+// not find, the resources removed since they were last seen.
+func (s *Store) CountUnseen(ctx context.Context, jobID uint, accountID, resourceType string) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM resources WHERE account_id = ? AND resource_type = ? AND last_job_id != ?`,
+		accountID, resourceType, jobID).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,367 @@
+// Package awsfixture records the AWS API calls of a test once and replays them afterwards, so
+// describers can be tested without an AWS account.
+//
+// Fixtures are replayed by default. With AWS_FIXTURES=record, the calls are sent to AWS with the
+// default credentials and written to the fixture, with the credentials, the signatures and the
+// account id of the caller removed and the sensitive values found by the scrubbers, see
+// DefaultScrubbers, replaced. With AWS_FIXTURES=golden, fixtures are replayed and the
+// golden files of the tests are rewritten.
+package awsfixture
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+const (
+	// AccountID replaces the account id of the recording caller.
+	AccountID = "123456789012"
+	// Region is the region fixtures are recorded in.
+	Region = "us-east-1"
+)
+
+// recordedResponseHeaders are the response headers kept in fixtures, the ones the SDK reads to
+// deserialize responses and errors.
+var recordedResponseHeaders = []string{
+	"Content-Type",
+	"X-Amz-Bucket-Region",
+	"X-Amzn-ErrorType",
+	"X-Amzn-Query-Error",
+}
+
+// Fixture is the recorded calls of a test.
+type Fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a request and the response AWS sent to it.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is the part of a request its response is matched on.
+type Request struct {
+	Method string `json:"method"`
+	Host   string `json:"host"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	// Target is the X-Amz-Target header of the JSON protocols, the operation called.
+	Target string `json:"target,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is a response without the headers the SDK does not read.
+type Response struct {
+	StatusCode int               `json:"statusCode"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+// Recording reports whether fixtures are recorded rather than replayed.
+func Recording() bool {
+	return os.Getenv("AWS_FIXTURES") == "record"
+}
+
+// UpdateGolden reports whether tests write their golden files rather than compare with them,
+// when recording or with AWS_FIXTURES=golden.
+func UpdateGolden() bool {
+	return Recording() || os.Getenv("AWS_FIXTURES") == "golden"
+}
+
+// Config returns the config of a test calling AWS through the fixture at path. Replayed, the
+// config has static credentials, no retries and fails the test on calls missing from the
+// fixture. Recorded, the fixture is written when the test finishes, scrubbed with scrubbers, or
+// DefaultScrubbers when none are given.
+func Config(t testing.TB, path string, scrubbers ...Scrubber) aws.Config {
+	t.Helper()
+
+	if Recording() {
+		cfg, err := config.LoadDefaultConfig(context.Background(), config.WithRegion(Region))
+		if err != nil {
+			t.Fatalf("failed to load AWS config: %v", err)
+		}
+		identity, err := sts.NewFromConfig(cfg).GetCallerIdentity(context.Background(), &sts.GetCallerIdentityInput{})
+		if err != nil {
+			t.Fatalf("failed to get caller identity: %v", err)
+		}
+
+		client := cfg.HTTPClient
+		if client == nil {
+			client = awshttp.NewBuildableClient()
+		}
+		if len(scrubbers) == 0 {
+			scrubbers = DefaultScrubbers()
+		}
+		transport := &Transport{
+			t:         t,
+			recording: true,
+			client:    client,
+			accountID: aws.ToString(identity.Account),
+			scrubber:  newScrubber(aws.ToString(identity.Account), scrubbers),
+		}
+		t.Cleanup(func() {
+			if err := transport.Save(path); err != nil {
+				t.Errorf("failed to save fixture %s: %v", path, err)
+			}
+		})
+		cfg.HTTPClient = transport
+		return cfg
+	}
+
+	fixture, err := Load(path)
+	if err != nil {
+		t.Fatalf("failed to load fixture %s: %v", path, err)
+	}
+	return aws.Config{
+		Region:      Region,
+		Credentials: credentials.NewStaticCredentialsProvider("AKIAFIXTURE", "fixture", ""),
+		HTTPClient:  NewReplayTransport(t, fixture),
+		Retryer: func() aws.Retryer {
+			return aws.NopRetryer{}
+		},
+	}
+}
+
+// CallerAccountID returns the account id to describe with cfg, the account of the caller when
+// recording, AccountID otherwise.
+func CallerAccountID(cfg aws.Config) string {
+	if tr, ok := cfg.HTTPClient.(*Transport); ok && tr.recording {
+		return tr.accountID
+	}
+	return AccountID
+}
+
+// Sanitize replaces the account id of the caller and scrubs s when recording with cfg, the way
+// the fixture is, so what tests write next to the fixture is sanitized as well.
+func Sanitize(cfg aws.Config, s string) string {
+	if tr, ok := cfg.HTTPClient.(*Transport); ok && tr.recording {
+		return tr.scrubber.scrub(s)
+	}
+	return s
+}
+
+// Load reads the fixture at path.
+func Load(path string) (Fixture, error) {
+	var fixture Fixture
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fixture, err
+	}
+	err = json.Unmarshal(content, &fixture)
+	return fixture, err
+}
+
+// Transport is the HTTP client of a config, either recording the calls it sends to AWS or
+// replaying the calls of a fixture.
+type Transport struct {
+	t         testing.TB
+	recording bool
+	client    aws.HTTPClient
+	accountID string
+	scrubber  *scrubber
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayTransport returns a transport answering the requests of the fixture with their
+// recorded responses. Requests recorded several times are answered in order, the last response
+// is repeated once they are all used.
+func NewReplayTransport(t testing.TB, fixture Fixture) *Transport {
+	return &Transport{
+		t:            t,
+		interactions: fixture.Interactions,
+		used:         make([]bool, len(fixture.Interactions)),
+	}
+}
+
+func (tr *Transport) Do(req *http.Request) (*http.Response, error) {
+	request, err := readRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if tr.recording {
+		return tr.record(req, request)
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	last := -1
+	for i, interaction := range tr.interactions {
+		if !interaction.Request.matches(request) {
+			continue
+		}
+		last = i
+		if !tr.used[i] {
+			break
+		}
+	}
+	if last < 0 {
+		tr.t.Errorf("awsfixture: no recorded response for %s", request)
+		return nil, fmt.Errorf("awsfixture: no recorded response for %s", request)
+	}
+	tr.used[last] = true
+	return tr.interactions[last].Response.httpResponse(req), nil
+}
+
+func (tr *Transport) record(req *http.Request, request Request) (*http.Response, error) {
+	resp, err := tr.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	response := Response{
+		StatusCode: resp.StatusCode,
+		Headers:    map[string]string{},
+		Body:       tr.scrubber.scrub(string(body)),
+	}
+	for _, header := range recordedResponseHeaders {
+		if v := resp.Header.Get(header); v != "" {
+			response.Headers[header] = v
+		}
+	}
+	request.Host = tr.scrubber.scrub(request.Host)
+	request.Path = tr.scrubber.scrub(request.Path)
+	request.Query = tr.scrubber.scrub(request.Query)
+	request.Body = tr.scrubber.scrub(request.Body)
+
+	tr.mu.Lock()
+	tr.interactions = append(tr.interactions, Interaction{Request: request, Response: response})
+	tr.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// Save writes the recorded calls to path.
+func (tr *Transport) Save(path string) error {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	content, err := json.MarshalIndent(Fixture{Interactions: tr.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(content, '\n'), 0o644)
+}
+
+// readRequest reads the parts of req a response is matched on, leaving its body readable.
+func readRequest(req *http.Request) (Request, error) {
+	request := Request{
+		Method: req.Method,
+		Host:   req.URL.Host,
+		Path:   req.URL.Path,
+		Query:  req.URL.RawQuery,
+		Target: req.Header.Get("X-Amz-Target"),
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		return request, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return request, err
+	}
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	request.Body = string(body)
+	return request, nil
+}
+
+func (r Request) String() string {
+	s := r.Method + " " + r.Host + r.Path
+	if r.Query != "" {
+		s += "?" + r.Query
+	}
+	if r.Target != "" {
+		s += " " + r.Target
+	}
+	if r.Body != "" {
+		s += " " + r.Body
+	}
+	return s
+}
+
+// matches reports whether o is the same call as r. Queries and form bodies match whatever
+// the order of their parameters, JSON bodies whatever the order of their fields.
+func (r Request) matches(o Request) bool {
+	return r.Method == o.Method &&
+		r.Host == o.Host &&
+		r.Path == o.Path &&
+		r.Target == o.Target &&
+		sameForm(r.Query, o.Query) &&
+		sameBody(r.Body, o.Body)
+}
+
+func sameForm(a, b string) bool {
+	if a == b {
+		return true
+	}
+	av, err := url.ParseQuery(a)
+	if err != nil {
+		return false
+	}
+	bv, err := url.ParseQuery(b)
+	if err != nil {
+		return false
+	}
+	return av.Encode() == bv.Encode()
+}
+
+func sameBody(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var aj, bj any
+	if json.Unmarshal([]byte(a), &aj) == nil && json.Unmarshal([]byte(b), &bj) == nil {
+		return reflect.DeepEqual(aj, bj)
+	}
+	return sameForm(a, b)
+}
+
+func (r Response) httpResponse(req *http.Request) *http.Response {
+	header := http.Header{}
+	for k, v := range r.Headers {
+		header.Set(k, v)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
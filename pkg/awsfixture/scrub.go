@@ -0,0 +1,131 @@
+package awsfixture
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Scrubber replaces the sensitive values its pattern matches in what is recorded, the calls
+// written to fixtures and what Sanitize is given. When the pattern has a group, only the group
+// is replaced.
+type Scrubber struct {
+	Pattern *regexp.Regexp
+	// Replace returns the replacement of value, the n-th distinct value matched from 1, or
+	// value itself to keep it.
+	Replace func(value string, n int) string
+}
+
+var (
+	accessKeyIDPattern = regexp.MustCompile(`\b(?:AKIA|ASIA)[A-Z0-9]{16}\b`)
+	secretPattern      = regexp.MustCompile(`"(?:SecretAccessKey|SessionToken|SecretString|SecretBinary|Password|MasterUserPassword)"\s*:\s*"([^"]*)"` +
+		`|<(?:SecretAccessKey|SessionToken|Password)>([^<]*)<`)
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`)
+	ipv4Pattern  = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	ipv6Pattern  = regexp.MustCompile(`(?i)\b[0-9a-f]{1,4}(?::[0-9a-f]{0,4}){2,7}`)
+)
+
+// DefaultScrubbers returns the scrubbers of access key ids, secrets, email addresses and public
+// IP addresses. Private and reserved addresses are kept, the describers read CIDRs like
+// 0.0.0.0/0.
+func DefaultScrubbers() []Scrubber {
+	return []Scrubber{
+		{Pattern: accessKeyIDPattern, Replace: func(value string, n int) string {
+			return fmt.Sprintf("%sFIXTURE%09d", value[:4], n)
+		}},
+		{Pattern: secretPattern, Replace: func(value string, n int) string {
+			if value == "" {
+				return value
+			}
+			return fmt.Sprintf("fixture-secret-%d", n)
+		}},
+		{Pattern: emailPattern, Replace: func(value string, n int) string {
+			return fmt.Sprintf("user%d@example.com", n)
+		}},
+		{Pattern: ipv4Pattern, Replace: func(value string, n int) string {
+			if !isPublicIP(value) {
+				return value
+			}
+			// 198.18.0.0/15 is reserved for benchmarks, it holds more addresses than the
+			// documentation ranges.
+			return fmt.Sprintf("198.18.%d.%d", n/256%256, n%256)
+		}},
+		{Pattern: ipv6Pattern, Replace: func(value string, n int) string {
+			if !isPublicIP(value) {
+				return value
+			}
+			return fmt.Sprintf("2001:db8::%x", n)
+		}},
+	}
+}
+
+func isPublicIP(s string) bool {
+	ip := net.ParseIP(s)
+	return ip != nil && ip.IsGlobalUnicast() && !ip.IsPrivate()
+}
+
+// scrubber applies scrubbers consistently, a value is given the same replacement wherever it
+// is found, so the requests made from a scrubbed response match the scrubbed requests.
+type scrubber struct {
+	account   *strings.Replacer
+	scrubbers []Scrubber
+
+	mu     sync.Mutex
+	values map[string]string
+	counts []int
+}
+
+func newScrubber(accountID string, scrubbers []Scrubber) *scrubber {
+	return &scrubber{
+		account:   strings.NewReplacer(accountID, AccountID),
+		scrubbers: scrubbers,
+		values:    map[string]string{},
+		counts:    make([]int, len(scrubbers)),
+	}
+}
+
+// scrub replaces the account id of the caller and the values matched by the scrubbers in s.
+func (s *scrubber) scrub(text string) string {
+	text = s.account.Replace(text)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, scrubber := range s.scrubbers {
+		matches := scrubber.Pattern.FindAllStringSubmatchIndex(text, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		b := &strings.Builder{}
+		last := 0
+		for _, m := range matches {
+			start, end := m[0], m[1]
+			// the first group that matched, if the pattern has any.
+			for g := 2; g < len(m); g += 2 {
+				if m[g] >= 0 {
+					start, end = m[g], m[g+1]
+					break
+				}
+			}
+			b.WriteString(text[last:start])
+			b.WriteString(s.replace(i, text[start:end]))
+			last = end
+		}
+		b.WriteString(text[last:])
+		text = b.String()
+	}
+	return text
+}
+
+func (s *scrubber) replace(i int, value string) string {
+	if r, ok := s.values[value]; ok {
+		return r
+	}
+	s.counts[i]++
+	r := s.scrubbers[i].Replace(value, s.counts[i])
+	s.values[value] = r
+	// replacements are kept as they are when scrubbed again.
+	s.values[r] = r
+	return r
+}
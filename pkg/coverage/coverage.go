@@ -0,0 +1,94 @@
+// Package coverage works out which AWS Backup selections assign a resource to their backup plan.
+package coverage
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/backup/types"
+	"github.com/opengovern/og-aws-describer/pkg/policy"
+)
+
+const resourceTagPrefix = "aws:ResourceTag/"
+
+// Selection is a backup selection, the resources a backup plan backs up.
+type Selection struct {
+	BackupPlanId  string
+	SelectionId   string
+	SelectionName string
+	// Resources are the ARNs, with * wildcards, of the resources assigned to the plan.
+	Resources []string
+	// NotResources are the ARNs, with * wildcards, of the resources excluded from the selection.
+	NotResources []string
+	// ListOfTags assigns every resource with one of the tags to the plan.
+	ListOfTags []types.Condition
+	// Conditions are the tag conditions every assigned resource has to meet.
+	Conditions *types.Conditions
+}
+
+// Covers reports whether the selection assigns the resource to its plan: the resource matches
+// one of Resources or has one of the tags of ListOfTags, meets every one of Conditions, and
+// doesn't match any of NotResources.
+func (s Selection) Covers(arn string, tags map[string]string) bool {
+	if !matchesAny(s.Resources, arn) && !hasAnyTag(s.ListOfTags, tags) {
+		return false
+	}
+	if !meetsConditions(s.Conditions, tags) {
+		return false
+	}
+	return !matchesAny(s.NotResources, arn)
+}
+
+func matchesAny(patterns []string, arn string) bool {
+	for _, pattern := range patterns {
+		if policy.WildcardMatch(pattern, arn) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnyTag(conditions []types.Condition, tags map[string]string) bool {
+	for _, condition := range conditions {
+		if condition.ConditionType != types.ConditionTypeStringequals {
+			continue
+		}
+		key := strings.TrimPrefix(deref(condition.ConditionKey), resourceTagPrefix)
+		if value, ok := tags[key]; ok && value == deref(condition.ConditionValue) {
+			return true
+		}
+	}
+	return false
+}
+
+func meetsConditions(conditions *types.Conditions, tags map[string]string) bool {
+	if conditions == nil {
+		return true
+	}
+	check := func(parameters []types.ConditionParameter, match func(value string, ok bool, want string) bool) bool {
+		for _, parameter := range parameters {
+			key := strings.TrimPrefix(deref(parameter.ConditionKey), resourceTagPrefix)
+			value, ok := tags[key]
+			if !match(value, ok, deref(parameter.ConditionValue)) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return check(conditions.StringEquals, func(value string, ok bool, want string) bool {
+		return ok && value == want
+	}) && check(conditions.StringNotEquals, func(value string, ok bool, want string) bool {
+		return !ok || value != want
+	}) && check(conditions.StringLike, func(value string, ok bool, want string) bool {
+		return ok && policy.WildcardMatch(want, value)
+	}) && check(conditions.StringNotLike, func(value string, ok bool, want string) bool {
+		return !ok || !policy.WildcardMatch(want, value)
+	})
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,103 @@
+package coverage
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/backup/types"
+)
+
+func TestSelectionCovers(t *testing.T) {
+	const volume = "arn:aws:ec2:us-east-1:111111111111:volume/vol-1"
+	const table = "arn:aws:dynamodb:us-east-1:111111111111:table/orders"
+
+	byTag := []types.Condition{{
+		ConditionType:  types.ConditionTypeStringequals,
+		ConditionKey:   aws.String("backup"),
+		ConditionValue: aws.String("daily"),
+	}}
+	prodOnly := &types.Conditions{StringEquals: []types.ConditionParameter{{
+		ConditionKey:   aws.String("aws:ResourceTag/env"),
+		ConditionValue: aws.String("prod"),
+	}}}
+	notScratch := &types.Conditions{StringNotLike: []types.ConditionParameter{{
+		ConditionKey:   aws.String("aws:ResourceTag/name"),
+		ConditionValue: aws.String("scratch-*"),
+	}}}
+
+	cases := []struct {
+		name      string
+		selection Selection
+		arn       string
+		tags      map[string]string
+		want      bool
+	}{
+		{
+			name:      "explicit arn",
+			selection: Selection{Resources: []string{volume}},
+			arn:       volume,
+			want:      true,
+		},
+		{
+			name:      "wildcard arn",
+			selection: Selection{Resources: []string{"arn:aws:ec2:*:*:volume/*"}},
+			arn:       volume,
+			want:      true,
+		},
+		{
+			name:      "other resource type",
+			selection: Selection{Resources: []string{"arn:aws:ec2:*:*:volume/*"}},
+			arn:       table,
+			want:      false,
+		},
+		{
+			name:      "list of tags",
+			selection: Selection{ListOfTags: byTag},
+			arn:       table,
+			tags:      map[string]string{"backup": "daily"},
+			want:      true,
+		},
+		{
+			name:      "list of tags with another value",
+			selection: Selection{ListOfTags: byTag},
+			arn:       table,
+			tags:      map[string]string{"backup": "weekly"},
+			want:      false,
+		},
+		{
+			name:      "conditions narrow every resource",
+			selection: Selection{Resources: []string{"*"}, Conditions: prodOnly},
+			arn:       table,
+			tags:      map[string]string{"env": "dev"},
+			want:      false,
+		},
+		{
+			name:      "conditions met",
+			selection: Selection{Resources: []string{"*"}, Conditions: prodOnly},
+			arn:       table,
+			tags:      map[string]string{"env": "prod"},
+			want:      true,
+		},
+		{
+			name:      "not like condition",
+			selection: Selection{Resources: []string{"*"}, Conditions: notScratch},
+			arn:       volume,
+			tags:      map[string]string{"name": "scratch-1"},
+			want:      false,
+		},
+		{
+			name:      "not resources exclude",
+			selection: Selection{Resources: []string{"*"}, NotResources: []string{"arn:aws:dynamodb:*"}},
+			arn:       table,
+			want:      false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.selection.Covers(c.arn, c.tags); got != c.want {
+				t.Errorf("Covers() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,465 @@
+// Package exposure works out which ports of a network interface or a load balancer can be
+// reached from the internet, from the route tables, network ACLs and security groups of its VPC.
+package exposure
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// PortRange is a range of ports of a protocol open to the internet, with the source CIDRs
+// allowed to reach it. ICMP has no ports and is reported with FromPort and ToPort -1.
+type PortRange struct {
+	Protocol    string
+	FromPort    int32
+	ToPort      int32
+	SourceCidrs []string
+}
+
+// Listener is a port a load balancer accepts traffic on, Protocol is tcp or udp.
+type Listener struct {
+	Protocol string
+	Port     int32
+}
+
+// Network holds the route tables and network ACLs of the VPCs of a region.
+type Network struct {
+	RouteTables []types.RouteTable
+	NetworkAcls []types.NetworkAcl
+}
+
+const (
+	protocolAll  = -1
+	protocolICMP = 1
+	protocolTCP  = 6
+	protocolUDP  = 17
+)
+
+var protocolNames = map[int]string{
+	protocolICMP: "icmp",
+	protocolTCP:  "tcp",
+	protocolUDP:  "udp",
+	58:           "icmpv6",
+}
+
+// privateNetworks are the ranges that aren't routed on the internet, a source inside them
+// can't be an internet client.
+var privateNetworks = mustParseCIDRs(
+	"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "127.0.0.0/8", "169.254.0.0/16",
+	"fc00::/7", "fe80::/10", "::1/128",
+)
+
+// AddressFamily is a set of IP address families.
+type AddressFamily int
+
+const (
+	IPv4 AddressFamily = 1 << iota
+	IPv6
+)
+
+// InternetGateway returns the internet gateway the subnet routes the default route of families
+// to, through the route table associated with it or else the main route table of the VPC, and
+// the families routed to it. The gateway is empty when none of the families has an active
+// 0.0.0.0/0 or ::/0 route to one.
+func (n Network) InternetGateway(vpcId, subnetId string, families AddressFamily) (string, AddressFamily) {
+	table := n.routeTable(vpcId, subnetId)
+	if table == nil {
+		return "", 0
+	}
+	var gateway string
+	var routed AddressFamily
+	for _, route := range table.Routes {
+		if route.State == types.RouteStateBlackhole {
+			continue
+		}
+		id := deref(route.GatewayId)
+		if !strings.HasPrefix(id, "igw-") {
+			continue
+		}
+		var family AddressFamily
+		switch {
+		case deref(route.DestinationCidrBlock) == "0.0.0.0/0":
+			family = IPv4
+		case deref(route.DestinationIpv6CidrBlock) == "::/0":
+			family = IPv6
+		}
+		if family&families == 0 {
+			continue
+		}
+		gateway = id
+		routed |= family
+	}
+	return gateway, routed
+}
+
+func (n Network) routeTable(vpcId, subnetId string) *types.RouteTable {
+	var main *types.RouteTable
+	for i, table := range n.RouteTables {
+		if deref(table.VpcId) != vpcId {
+			continue
+		}
+		for _, association := range table.Associations {
+			if subnetId != "" && deref(association.SubnetId) == subnetId {
+				return &n.RouteTables[i]
+			}
+			if association.Main != nil && *association.Main {
+				main = &n.RouteTables[i]
+			}
+		}
+	}
+	return main
+}
+
+// NetworkAcl returns the network ACL associated with the subnet, or the default one of the VPC.
+func (n Network) NetworkAcl(vpcId, subnetId string) *types.NetworkAcl {
+	var fallback *types.NetworkAcl
+	for i, acl := range n.NetworkAcls {
+		if deref(acl.VpcId) != vpcId {
+			continue
+		}
+		for _, association := range acl.Associations {
+			if deref(association.SubnetId) == subnetId {
+				return &n.NetworkAcls[i]
+			}
+		}
+		if acl.IsDefault != nil && *acl.IsDefault {
+			fallback = &n.NetworkAcls[i]
+		}
+	}
+	return fallback
+}
+
+// InterfaceExposure returns the ports of a network interface open to the internet: the ranges its
+// security groups allow from internet sources, narrowed down to what the network ACL of its
+// subnet lets in. acl is nil when the subnet has none, it then doesn't filter anything. families
+// are the address families the interface is reachable on, those it has a public address of and
+// its subnet routes to an internet gateway, sources of other families are left out.
+func InterfaceExposure(groups []types.SecurityGroup, acl *types.NetworkAcl, families AddressFamily) []PortRange {
+	return merge(filterACL(filterFamilies(securityGroupCandidates(groups), families), acl))
+}
+
+// LoadBalancerExposure returns the listener ports of a load balancer open to the internet. A
+// load balancer without security groups, a network load balancer, accepts its listener ports
+// from anywhere. acls are the network ACLs of the subnets of the load balancer that route to
+// an internet gateway, a port is exposed when one of them lets it in. families are the address
+// families of the load balancer routed to the internet, as for InterfaceExposure.
+func LoadBalancerExposure(groups []types.SecurityGroup, listeners []Listener, acls []*types.NetworkAcl, families AddressFamily) []PortRange {
+	var allowed []candidate
+	if len(groups) > 0 {
+		allowed = securityGroupCandidates(groups)
+	} else {
+		for _, listener := range listeners {
+			protocol := protocolNumber(listener.Protocol)
+			allowed = append(allowed,
+				candidate{protocol: protocol, from: listener.Port, to: listener.Port, cidr: "0.0.0.0/0"},
+				candidate{protocol: protocol, from: listener.Port, to: listener.Port, cidr: "::/0"})
+		}
+	}
+
+	var listening []candidate
+	for _, c := range filterFamilies(allowed, families) {
+		for _, listener := range listeners {
+			protocol := protocolNumber(listener.Protocol)
+			if c.protocol != protocol || listener.Port < c.from || listener.Port > c.to {
+				continue
+			}
+			listening = append(listening, candidate{protocol: protocol, from: listener.Port, to: listener.Port, cidr: c.cidr})
+		}
+	}
+
+	var exposed []candidate
+	for _, acl := range acls {
+		exposed = append(exposed, filterACL(listening, acl)...)
+	}
+	return merge(exposed)
+}
+
+// candidate is a port range of a protocol reachable from a source CIDR.
+type candidate struct {
+	protocol int
+	from, to int32
+	cidr     string
+}
+
+func securityGroupCandidates(groups []types.SecurityGroup) []candidate {
+	var candidates []candidate
+	for _, group := range groups {
+		for _, permission := range group.IpPermissions {
+			var cidrs []string
+			for _, r := range permission.IpRanges {
+				cidrs = append(cidrs, deref(r.CidrIp))
+			}
+			for _, r := range permission.Ipv6Ranges {
+				cidrs = append(cidrs, deref(r.CidrIpv6))
+			}
+
+			for _, cidr := range cidrs {
+				if !isInternet(cidr) {
+					continue
+				}
+				protocol := protocolNumber(deref(permission.IpProtocol))
+				switch protocol {
+				case protocolAll:
+					for _, p := range []int{protocolTCP, protocolUDP} {
+						candidates = append(candidates, candidate{protocol: p, from: 0, to: 65535, cidr: cidr})
+					}
+					candidates = append(candidates, candidate{protocol: protocolICMP, from: -1, to: -1, cidr: cidr})
+				case protocolTCP, protocolUDP:
+					from, to := int32(0), int32(65535)
+					if permission.FromPort != nil && *permission.FromPort >= 0 {
+						from = *permission.FromPort
+					}
+					if permission.ToPort != nil && *permission.ToPort >= 0 {
+						to = *permission.ToPort
+					}
+					candidates = append(candidates, candidate{protocol: protocol, from: from, to: to, cidr: cidr})
+				default:
+					candidates = append(candidates, candidate{protocol: protocol, from: -1, to: -1, cidr: cidr})
+				}
+			}
+		}
+	}
+	return candidates
+}
+
+// filterFamilies returns the candidates with a source CIDR of one of families.
+func filterFamilies(candidates []candidate, families AddressFamily) []candidate {
+	var result []candidate
+	for _, c := range candidates {
+		if familyOf(c.cidr)&families != 0 {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// filterACL narrows the candidates down to what the inbound rules of the network ACL allow. Rules
+// are evaluated in rule number order and the first rule matching a port decides it. A rule only
+// decides for a source when its CIDR holds the whole source, an allow rule covering part of the
+// source still exposes that part. Return traffic isn't checked against the outbound rules.
+func filterACL(candidates []candidate, acl *types.NetworkAcl) []candidate {
+	if acl == nil {
+		return candidates
+	}
+	entries := make([]types.NetworkAclEntry, 0, len(acl.Entries))
+	for _, entry := range acl.Entries {
+		if entry.Egress == nil || !*entry.Egress {
+			entries = append(entries, entry)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return deref(entries[i].RuleNumber) < deref(entries[j].RuleNumber)
+	})
+
+	var result []candidate
+	for _, c := range candidates {
+		remaining := []portInterval{{c.from, c.to}}
+		for _, entry := range entries {
+			if len(remaining) == 0 {
+				break
+			}
+			protocol := protocolNumber(deref(entry.Protocol))
+			if protocol != protocolAll && protocol != c.protocol {
+				continue
+			}
+			ruleCidr := deref(entry.CidrBlock)
+			if ruleCidr == "" {
+				ruleCidr = deref(entry.Ipv6CidrBlock)
+			}
+			narrower, overlaps := overlap(ruleCidr, c.cidr)
+			if !overlaps {
+				continue
+			}
+
+			rulePorts := portInterval{c.from, c.to}
+			if entry.PortRange != nil && (c.protocol == protocolTCP || c.protocol == protocolUDP) && protocol != protocolAll {
+				rulePorts = portInterval{deref(entry.PortRange.From), deref(entry.PortRange.To)}
+			}
+			matched := intersect(remaining, rulePorts)
+			if len(matched) == 0 {
+				continue
+			}
+
+			if entry.RuleAction == types.RuleActionAllow {
+				for _, ports := range matched {
+					result = append(result, candidate{protocol: c.protocol, from: ports.from, to: ports.to, cidr: narrower})
+				}
+			}
+			if narrower == c.cidr {
+				remaining = subtract(remaining, rulePorts)
+			}
+		}
+	}
+	return result
+}
+
+// merge groups the candidates by protocol and port range, sorted.
+func merge(candidates []candidate) []PortRange {
+	type key struct {
+		protocol int
+		from, to int32
+	}
+	sources := map[key]map[string]bool{}
+	for _, c := range candidates {
+		k := key{c.protocol, c.from, c.to}
+		if sources[k] == nil {
+			sources[k] = map[string]bool{}
+		}
+		sources[k][c.cidr] = true
+	}
+
+	var ranges []PortRange
+	for k, cidrs := range sources {
+		r := PortRange{Protocol: protocolName(k.protocol), FromPort: k.from, ToPort: k.to}
+		for cidr := range cidrs {
+			r.SourceCidrs = append(r.SourceCidrs, cidr)
+		}
+		sort.Strings(r.SourceCidrs)
+		ranges = append(ranges, r)
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		if ranges[i].Protocol != ranges[j].Protocol {
+			return ranges[i].Protocol < ranges[j].Protocol
+		}
+		if ranges[i].FromPort != ranges[j].FromPort {
+			return ranges[i].FromPort < ranges[j].FromPort
+		}
+		return ranges[i].ToPort < ranges[j].ToPort
+	})
+	return ranges
+}
+
+type portInterval struct {
+	from, to int32
+}
+
+func intersect(intervals []portInterval, other portInterval) []portInterval {
+	var result []portInterval
+	for _, i := range intervals {
+		from, to := max(i.from, other.from), min(i.to, other.to)
+		if from <= to {
+			result = append(result, portInterval{from, to})
+		}
+	}
+	return result
+}
+
+func subtract(intervals []portInterval, other portInterval) []portInterval {
+	var result []portInterval
+	for _, i := range intervals {
+		if other.to < i.from || other.from > i.to {
+			result = append(result, i)
+			continue
+		}
+		if i.from < other.from {
+			result = append(result, portInterval{i.from, other.from - 1})
+		}
+		if i.to > other.to {
+			result = append(result, portInterval{other.to + 1, i.to})
+		}
+	}
+	return result
+}
+
+// overlap returns the narrower of two CIDRs when one holds the other, CIDRs only ever nest or
+// are disjoint.
+func overlap(a, b string) (string, bool) {
+	_, na, err := net.ParseCIDR(a)
+	if err != nil {
+		return "", false
+	}
+	_, nb, err := net.ParseCIDR(b)
+	if err != nil {
+		return "", false
+	}
+	onesA, bitsA := na.Mask.Size()
+	onesB, bitsB := nb.Mask.Size()
+	if bitsA != bitsB {
+		return "", false
+	}
+	if onesA <= onesB && na.Contains(nb.IP) {
+		return b, true
+	}
+	if onesB < onesA && nb.Contains(na.IP) {
+		return a, true
+	}
+	return "", false
+}
+
+// familyOf returns the address family of the CIDR, none when it doesn't parse.
+func familyOf(cidr string) AddressFamily {
+	ip, _, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return 0
+	}
+	if ip.To4() != nil {
+		return IPv4
+	}
+	return IPv6
+}
+
+// isInternet reports whether the CIDR holds addresses routed on the internet.
+func isInternet(cidr string) bool {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	ones, bits := network.Mask.Size()
+	for _, private := range privateNetworks {
+		privateOnes, privateBits := private.Mask.Size()
+		if bits == privateBits && privateOnes <= ones && private.Contains(network.IP) {
+			return false
+		}
+	}
+	return true
+}
+
+func protocolNumber(protocol string) int {
+	switch strings.ToLower(protocol) {
+	case "-1", "all", "":
+		return protocolAll
+	case "tcp", "http", "https", "tls":
+		return protocolTCP
+	case "udp":
+		return protocolUDP
+	case "icmp":
+		return protocolICMP
+	case "icmpv6":
+		return 58
+	}
+	if n, err := strconv.Atoi(protocol); err == nil {
+		return n
+	}
+	return protocolAll
+}
+
+func protocolName(protocol int) string {
+	if name, ok := protocolNames[protocol]; ok {
+		return name
+	}
+	return strconv.Itoa(protocol)
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+func deref[T any](v *T) T {
+	var zero T
+	if v == nil {
+		return zero
+	}
+	return *v
+}
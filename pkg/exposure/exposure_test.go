@@ -0,0 +1,212 @@
+package exposure
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+func ingress(protocol string, from, to int32, cidrs ...string) types.IpPermission {
+	permission := types.IpPermission{IpProtocol: aws.String(protocol), FromPort: aws.Int32(from), ToPort: aws.Int32(to)}
+	for _, cidr := range cidrs {
+		permission.IpRanges = append(permission.IpRanges, types.IpRange{CidrIp: aws.String(cidr)})
+	}
+	return permission
+}
+
+func aclEntry(number int32, action types.RuleAction, protocol, cidr string, ports *types.PortRange) types.NetworkAclEntry {
+	return types.NetworkAclEntry{
+		RuleNumber: aws.Int32(number),
+		RuleAction: action,
+		Protocol:   aws.String(protocol),
+		CidrBlock:  aws.String(cidr),
+		PortRange:  ports,
+		Egress:     aws.Bool(false),
+	}
+}
+
+func TestInterfaceExposure(t *testing.T) {
+	web := types.SecurityGroup{IpPermissions: []types.IpPermission{
+		ingress("tcp", 443, 443, "0.0.0.0/0"),
+		ingress("tcp", 22, 22, "10.0.0.0/8", "203.0.113.0/24"),
+		ingress("tcp", 5432, 5432, "172.16.0.0/16"),
+	}}
+	all := types.SecurityGroup{IpPermissions: []types.IpPermission{ingress("-1", -1, -1, "0.0.0.0/0")}}
+	dualStack := types.SecurityGroup{IpPermissions: []types.IpPermission{{
+		IpProtocol: aws.String("tcp"), FromPort: aws.Int32(443), ToPort: aws.Int32(443),
+		IpRanges:   []types.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+		Ipv6Ranges: []types.Ipv6Range{{CidrIpv6: aws.String("::/0")}},
+	}}}
+
+	allowAll := &types.NetworkAcl{Entries: []types.NetworkAclEntry{
+		aclEntry(100, types.RuleActionAllow, "-1", "0.0.0.0/0", nil),
+		aclEntry(32767, types.RuleActionDeny, "-1", "0.0.0.0/0", nil),
+	}}
+	denySSHFirst := &types.NetworkAcl{Entries: []types.NetworkAclEntry{
+		aclEntry(200, types.RuleActionAllow, "-1", "0.0.0.0/0", nil),
+		aclEntry(100, types.RuleActionDeny, "6", "0.0.0.0/0", &types.PortRange{From: aws.Int32(22), To: aws.Int32(22)}),
+	}}
+	onlyWeb := &types.NetworkAcl{Entries: []types.NetworkAclEntry{
+		aclEntry(100, types.RuleActionAllow, "6", "0.0.0.0/0", &types.PortRange{From: aws.Int32(80), To: aws.Int32(443)}),
+		aclEntry(110, types.RuleActionAllow, "6", "198.51.100.0/24", &types.PortRange{From: aws.Int32(8080), To: aws.Int32(8080)}),
+		aclEntry(32767, types.RuleActionDeny, "-1", "0.0.0.0/0", nil),
+	}}
+
+	cases := []struct {
+		name     string
+		groups   []types.SecurityGroup
+		acl      *types.NetworkAcl
+		families AddressFamily
+		want     []PortRange
+	}{
+		{
+			name:   "private sources are not exposed",
+			groups: []types.SecurityGroup{web},
+			acl:    allowAll,
+			want: []PortRange{
+				{Protocol: "tcp", FromPort: 22, ToPort: 22, SourceCidrs: []string{"203.0.113.0/24"}},
+				{Protocol: "tcp", FromPort: 443, ToPort: 443, SourceCidrs: []string{"0.0.0.0/0"}},
+			},
+		},
+		{
+			name:   "deny with a lower rule number wins",
+			groups: []types.SecurityGroup{web},
+			acl:    denySSHFirst,
+			want: []PortRange{
+				{Protocol: "tcp", FromPort: 443, ToPort: 443, SourceCidrs: []string{"0.0.0.0/0"}},
+			},
+		},
+		{
+			name:   "acl narrows ports and sources",
+			groups: []types.SecurityGroup{all},
+			acl:    onlyWeb,
+			want: []PortRange{
+				{Protocol: "tcp", FromPort: 80, ToPort: 443, SourceCidrs: []string{"0.0.0.0/0"}},
+				{Protocol: "tcp", FromPort: 8080, ToPort: 8080, SourceCidrs: []string{"198.51.100.0/24"}},
+			},
+		},
+		{
+			name:   "no acl",
+			groups: []types.SecurityGroup{all},
+			want: []PortRange{
+				{Protocol: "icmp", FromPort: -1, ToPort: -1, SourceCidrs: []string{"0.0.0.0/0"}},
+				{Protocol: "tcp", FromPort: 0, ToPort: 65535, SourceCidrs: []string{"0.0.0.0/0"}},
+				{Protocol: "udp", FromPort: 0, ToPort: 65535, SourceCidrs: []string{"0.0.0.0/0"}},
+			},
+		},
+		{
+			name:   "ipv6 sources are not exposed on ipv4 only interfaces",
+			groups: []types.SecurityGroup{dualStack},
+			want: []PortRange{
+				{Protocol: "tcp", FromPort: 443, ToPort: 443, SourceCidrs: []string{"0.0.0.0/0"}},
+			},
+		},
+		{
+			name:     "dual stack interface",
+			groups:   []types.SecurityGroup{dualStack},
+			families: IPv4 | IPv6,
+			want: []PortRange{
+				{Protocol: "tcp", FromPort: 443, ToPort: 443, SourceCidrs: []string{"0.0.0.0/0", "::/0"}},
+			},
+		},
+		{
+			name:     "ipv6 only interface",
+			groups:   []types.SecurityGroup{dualStack},
+			families: IPv6,
+			want: []PortRange{
+				{Protocol: "tcp", FromPort: 443, ToPort: 443, SourceCidrs: []string{"::/0"}},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			families := c.families
+			if families == 0 {
+				families = IPv4
+			}
+			got := InterfaceExposure(c.groups, c.acl, families)
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("InterfaceExposure() = %+v, want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestLoadBalancerExposure(t *testing.T) {
+	listeners := []Listener{{Protocol: "tcp", Port: 443}, {Protocol: "tcp", Port: 8443}}
+	https := types.SecurityGroup{IpPermissions: []types.IpPermission{ingress("tcp", 443, 443, "0.0.0.0/0")}}
+	acl := &types.NetworkAcl{Entries: []types.NetworkAclEntry{
+		aclEntry(100, types.RuleActionAllow, "-1", "0.0.0.0/0", nil),
+	}}
+
+	got := LoadBalancerExposure([]types.SecurityGroup{https}, listeners, []*types.NetworkAcl{acl}, IPv4)
+	want := []PortRange{{Protocol: "tcp", FromPort: 443, ToPort: 443, SourceCidrs: []string{"0.0.0.0/0"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("with security groups = %+v, want %+v", got, want)
+	}
+
+	got = LoadBalancerExposure(nil, listeners, []*types.NetworkAcl{acl}, IPv4)
+	want = []PortRange{
+		{Protocol: "tcp", FromPort: 443, ToPort: 443, SourceCidrs: []string{"0.0.0.0/0"}},
+		{Protocol: "tcp", FromPort: 8443, ToPort: 8443, SourceCidrs: []string{"0.0.0.0/0"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("without security groups = %+v, want %+v", got, want)
+	}
+
+	if got := LoadBalancerExposure(nil, listeners, nil, IPv4); len(got) != 0 {
+		t.Errorf("load balancer without internet routed subnets exposes %+v", got)
+	}
+}
+
+func TestNetwork(t *testing.T) {
+	network := Network{
+		RouteTables: []types.RouteTable{
+			{
+				VpcId:        aws.String("vpc-1"),
+				Associations: []types.RouteTableAssociation{{Main: aws.Bool(true)}},
+				Routes:       []types.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1")}},
+			},
+			{
+				VpcId:        aws.String("vpc-1"),
+				Associations: []types.RouteTableAssociation{{SubnetId: aws.String("subnet-private")}},
+				Routes:       []types.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1")}},
+			},
+			{
+				VpcId:        aws.String("vpc-2"),
+				Associations: []types.RouteTableAssociation{{Main: aws.Bool(true)}},
+				Routes: []types.Route{
+					{DestinationCidrBlock: aws.String("198.51.100.0/24"), GatewayId: aws.String("igw-2")},
+					{DestinationIpv6CidrBlock: aws.String("::/0"), GatewayId: aws.String("igw-2")},
+				},
+			},
+		},
+		NetworkAcls: []types.NetworkAcl{
+			{VpcId: aws.String("vpc-1"), IsDefault: aws.Bool(true), NetworkAclId: aws.String("acl-default")},
+			{VpcId: aws.String("vpc-1"), NetworkAclId: aws.String("acl-private"), Associations: []types.NetworkAclAssociation{{SubnetId: aws.String("subnet-private")}}},
+		},
+	}
+
+	if got, routed := network.InternetGateway("vpc-1", "subnet-public", IPv4|IPv6); got != "igw-1" || routed != IPv4 {
+		t.Errorf("subnet on the main route table routes %v to %q, want ipv4 to igw-1", routed, got)
+	}
+	if got, _ := network.InternetGateway("vpc-1", "subnet-private", IPv4|IPv6); got != "" {
+		t.Errorf("subnet behind a nat gateway routes to %q", got)
+	}
+	// only default routes make a subnet reachable from the internet.
+	if got, _ := network.InternetGateway("vpc-2", "subnet-1", IPv4); got != "" {
+		t.Errorf("subnet without an ipv4 default route routes ipv4 to %q", got)
+	}
+	if got, routed := network.InternetGateway("vpc-2", "subnet-1", IPv4|IPv6); got != "igw-2" || routed != IPv6 {
+		t.Errorf("dual stack subnet routes %v to %q, want ipv6 to igw-2", routed, got)
+	}
+	if got := aws.ToString(network.NetworkAcl("vpc-1", "subnet-private").NetworkAclId); got != "acl-private" {
+		t.Errorf("associated network acl = %s", got)
+	}
+	if got := aws.ToString(network.NetworkAcl("vpc-1", "subnet-public").NetworkAclId); got != "acl-default" {
+		t.Errorf("default network acl = %s", got)
+	}
+}
@@ -0,0 +1,222 @@
+package opengovernance
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	essdk "github.com/opengovern/og-util/pkg/opengovernance-es-sdk"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+const (
+	// aggregationPageSize is the number of composite buckets fetched per request
+	aggregationPageSize = 1000
+	// maxAggregationBuckets bounds the distinct values kept in memory, above it paging the
+	// documents is as cheap as the aggregation
+	maxAggregationBuckets = 10000
+)
+
+// commonAggregationColumns are the columns every table gets from the common column sets, they
+// are not part of the table filters since they aren't key columns.
+var commonAggregationColumns = map[string]string{
+	"account_id":        "metadata.AccountID",
+	"region":            "metadata.Region",
+	"partition":         "metadata.Partition",
+	"kaytu_account_id":  "metadata.SourceID",
+	"kaytu_resource_id": "id",
+	DescribeJobIDColumn: "resource_job_id",
+}
+
+type compositeAggregationResponse struct {
+	Aggregations struct {
+		Rows struct {
+			AfterKey map[string]any `json:"after_key"`
+			Buckets  []struct {
+				Key      map[string]any `json:"key"`
+				DocCount int64          `json:"doc_count"`
+			} `json:"buckets"`
+		} `json:"rows"`
+	} `json:"aggregations"`
+}
+
+type countResponse struct {
+	Hits struct {
+		Total essdk.SearchTotal `json:"total"`
+	} `json:"hits"`
+}
+
+// ListAggregated answers queries that only read filterable columns, e.g. count(*) or a group by
+// region, from an aggregation instead of paging every document of the index. Every distinct
+// combination of the requested columns is streamed once per document that has it, so postgres
+// sees the same rows as it would from the paginator without the documents leaving OpenSearch.
+//
+// It returns false, without streaming anything, when the query can't be pushed down and the
+// caller has to page the documents. Only a cancelled context is returned as an error.
+func ListAggregated[T any](ctx context.Context, d *plugin.QueryData, k Client, index string, columnFilters map[string]string, filters []essdk.BoolFilter) (bool, error) {
+	if d.QueryContext.Limit != nil {
+		return false, nil
+	}
+
+	paths := map[string]string{}
+	for _, column := range d.QueryContext.Columns {
+		path, ok := columnFilters[column]
+		if !ok {
+			path, ok = commonAggregationColumns[column]
+		}
+		if !ok {
+			return false, nil
+		}
+		paths[column] = path
+	}
+
+	query := map[string]any{"match_all": map[string]any{}}
+	if len(filters) > 0 {
+		query = map[string]any{"bool": map[string]any{"filter": filters}}
+	}
+
+	if len(paths) == 0 {
+		count, err := aggregatedCount(ctx, k, index, query)
+		if err != nil {
+			if ctx.Err() != nil {
+				return false, ctx.Err()
+			}
+			plugin.Logger(ctx).Warn("ListAggregated count failed, paging documents", "index", index, "error", err)
+			return false, nil
+		}
+		var item T
+		for i := int64(0); i < count; i++ {
+			d.StreamListItem(ctx, item)
+			if d.RowsRemaining(ctx) == 0 {
+				break
+			}
+		}
+		return true, nil
+	}
+
+	type row struct {
+		item  T
+		count int64
+	}
+	var rows []row
+
+	var after map[string]any
+	for {
+		response, err := compositeAggregation(ctx, k, index, query, paths, after)
+		if err != nil {
+			if ctx.Err() != nil {
+				return false, ctx.Err()
+			}
+			plugin.Logger(ctx).Warn("ListAggregated aggregation failed, paging documents", "index", index, "error", err)
+			return false, nil
+		}
+
+		for _, bucket := range response.Aggregations.Rows.Buckets {
+			doc := map[string]any{}
+			for column, value := range bucket.Key {
+				if value != nil {
+					setPath(doc, paths[column], value)
+				}
+			}
+			b, err := json.Marshal(doc)
+			if err != nil {
+				return false, nil
+			}
+			var item T
+			if err := json.Unmarshal(b, &item); err != nil {
+				// the aggregated value can't be decoded into the column, e.g. dates come back as epoch millis
+				plugin.Logger(ctx).Debug("ListAggregated bucket not decodable, paging documents", "index", index, "error", err)
+				return false, nil
+			}
+			rows = append(rows, row{item: item, count: bucket.DocCount})
+		}
+		if len(rows) > maxAggregationBuckets {
+			return false, nil
+		}
+
+		after = response.Aggregations.Rows.AfterKey
+		if after == nil || len(response.Aggregations.Rows.Buckets) < aggregationPageSize {
+			break
+		}
+	}
+
+	for _, r := range rows {
+		for i := int64(0); i < r.count; i++ {
+			d.StreamListItem(ctx, r.item)
+			if d.RowsRemaining(ctx) == 0 {
+				return true, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+func aggregatedCount(ctx context.Context, k Client, index string, query map[string]any) (int64, error) {
+	b, err := json.Marshal(map[string]any{
+		"size":  0,
+		"query": query,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var response countResponse
+	if err := k.SearchWithTrackTotalHits(ctx, index, string(b), nil, &response, true); err != nil {
+		return 0, err
+	}
+	return response.Hits.Total.Value, nil
+}
+
+func compositeAggregation(ctx context.Context, k Client, index string, query map[string]any, paths map[string]string, after map[string]any) (*compositeAggregationResponse, error) {
+	var sources []map[string]any
+	for column, path := range paths {
+		sources = append(sources, map[string]any{
+			column: map[string]any{
+				"terms": map[string]any{
+					"field":          path,
+					"missing_bucket": true,
+				},
+			},
+		})
+	}
+
+	composite := map[string]any{
+		"size":    aggregationPageSize,
+		"sources": sources,
+	}
+	if after != nil {
+		composite["after"] = after
+	}
+
+	b, err := json.Marshal(map[string]any{
+		"size":  0,
+		"query": query,
+		"aggs": map[string]any{
+			"rows": map[string]any{"composite": composite},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response compositeAggregationResponse
+	if err := k.Search(ctx, index, string(b), &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// setPath sets value at the dotted path of doc, creating the objects along the way.
+func setPath(doc map[string]any, path string, value any) {
+	parts := strings.Split(path, ".")
+	current := doc
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := current[part].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			current[part] = next
+		}
+		current = next
+	}
+	current[parts[len(parts)-1]] = value
+}
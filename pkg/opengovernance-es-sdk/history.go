@@ -0,0 +1,146 @@
+package opengovernance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	essdk "github.com/opengovern/og-util/pkg/opengovernance-es-sdk"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+const (
+	// AsOfColumn selects the resources as they were described by the last describe job of each
+	// account at or before the given time.
+	AsOfColumn = "as_of"
+	// DescribeJobIDColumn selects the resources as they were described by the given describe job.
+	DescribeJobIDColumn = "describe_job_id"
+
+	// HistoryIndexSuffix matches describer.HistoryIndexSuffix, the snapshots of the resources of
+	// an index are kept in the index with this suffix.
+	HistoryIndexSuffix = "_history"
+	// historyJobMarkerField matches the field of describer.HistoryJobMarker, the documents
+	// recording completed jobs in the history index.
+	historyJobMarkerField = "history_job_marker"
+
+	// maxHistorySources bounds the accounts an as_of query resolves describe jobs for
+	maxHistorySources = 10000
+)
+
+type historyJobsResponse struct {
+	Aggregations struct {
+		Sources struct {
+			Buckets []struct {
+				Key string `json:"key"`
+				Job struct {
+					Value *float64 `json:"value"`
+				} `json:"job"`
+			} `json:"buckets"`
+		} `json:"sources"`
+	} `json:"aggregations"`
+}
+
+// HistoryFilters resolves the as_of and describe_job_id qualifiers of the query. It returns the
+// index to read, which is the history index of index if any of them is set, and the filters
+// selecting the snapshots of the requested describe jobs.
+func HistoryFilters(ctx context.Context, d *plugin.QueryData, k Client, index string) (string, []essdk.BoolFilter, error) {
+	var jobIDs []string
+	historical := false
+
+	if q := d.EqualsQuals[DescribeJobIDColumn]; q != nil {
+		historical = true
+		values := []*proto.QualValue{q}
+		if list := q.GetListValue(); list != nil {
+			values = list.GetValues()
+		}
+		for _, v := range values {
+			jobIDs = append(jobIDs, strconv.FormatInt(v.GetInt64Value(), 10))
+		}
+	}
+
+	if q := d.EqualsQuals[AsOfColumn]; q != nil && q.GetTimestampValue() != nil {
+		asOfJobIDs, err := jobsAsOf(ctx, k, index+HistoryIndexSuffix, q.GetTimestampValue().AsTime().UnixMilli())
+		if err != nil {
+			return "", nil, err
+		}
+		if historical {
+			jobIDs = intersect(jobIDs, asOfJobIDs)
+		} else {
+			jobIDs = asOfJobIDs
+		}
+		historical = true
+	}
+
+	if !historical {
+		return index, nil, nil
+	}
+	if jobIDs == nil {
+		jobIDs = []string{}
+	}
+	// an empty terms filter matches nothing, which is the answer when no job qualifies
+	return index + HistoryIndexSuffix, []essdk.BoolFilter{
+		essdk.NewTermsFilter("resource_job_id", jobIDs),
+		essdk.NewBoolMustNotFilter(essdk.NewTermFilter(historyJobMarkerField, "true")),
+	}, nil
+}
+
+// jobsAsOf returns, for every account, the last describe job that finished at or before asOf.
+// Resources that were gone by that job don't have a snapshot for it, so they are left out. Every
+// completed job has a marker document, so a job that found no resources is selected too and the
+// account has no resources as of then.
+func jobsAsOf(ctx context.Context, k Client, historyIndex string, asOf int64) ([]string, error) {
+	query, err := json.Marshal(map[string]any{
+		"size": 0,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"filter": []any{
+					map[string]any{"range": map[string]any{"created_at": map[string]any{"lte": asOf}}},
+				},
+			},
+		},
+		"aggs": map[string]any{
+			"sources": map[string]any{
+				"terms": map[string]any{"field": "source_id", "size": maxHistorySources},
+				"aggs": map[string]any{
+					"job": map[string]any{"max": map[string]any{"field": "resource_job_id"}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response historyJobsResponse
+	if err := k.Search(ctx, historyIndex, string(query), &response); err != nil {
+		if essdk.IsIndexNotFoundErr(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("resolving describe jobs as of %d: %w", asOf, err)
+	}
+
+	var jobIDs []string
+	for _, bucket := range response.Aggregations.Sources.Buckets {
+		if bucket.Job.Value == nil {
+			continue
+		}
+		jobIDs = append(jobIDs, strconv.FormatInt(int64(*bucket.Job.Value), 10))
+	}
+	return jobIDs, nil
+}
+
+func intersect(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, v := range b {
+		in[v] = true
+	}
+	var result []string
+	for _, v := range a {
+		if in[v] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
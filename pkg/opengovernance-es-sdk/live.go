@@ -0,0 +1,300 @@
+package opengovernance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/opengovern/og-aws-describer/aws"
+	"github.com/opengovern/og-aws-describer/aws/describer"
+	"github.com/opengovern/og-util/pkg/describe/enums"
+	"github.com/opengovern/og-util/pkg/es"
+	essdk "github.com/opengovern/og-util/pkg/opengovernance-es-sdk"
+	"github.com/opengovern/og-util/pkg/source"
+	"github.com/opengovern/og-util/pkg/steampipe"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"go.uber.org/zap"
+)
+
+// ESConfigProvider is implemented by connection configs that carry more than the es client
+// config, e.g. the live fallback settings of the plugin.
+type ESConfigProvider interface {
+	ESConfig() essdk.ClientConfig
+}
+
+// LiveConfig is implemented by connection configs that allow tables to describe resources
+// directly when the index has no data for them or its data is stale.
+type LiveConfig interface {
+	// LiveFallbackSettings returns the age after which the indexed data is considered stale and
+	// whether the live fallback is enabled at all.
+	LiveFallbackSettings() (time.Duration, bool)
+	// LiveAWSConfig returns the aws config and the regions the resources are described in.
+	LiveAWSConfig(ctx context.Context) (awssdk.Config, []string, error)
+	// LiveSourceID returns the id in opengovernance of the account described, the SourceID of
+	// the indexed documents of the connection.
+	LiveSourceID() string
+}
+
+type latestDescribeResponse struct {
+	Aggregations struct {
+		Latest struct {
+			Value *float64 `json:"value"`
+		} `json:"latest"`
+	} `json:"aggregations"`
+}
+
+// GetConfig returns the es client config of the connection.
+func GetConfig(connection *plugin.Connection) essdk.ClientConfig {
+	if connection != nil {
+		if c, ok := connection.Config.(ESConfigProvider); ok {
+			return c.ESConfig()
+		}
+	}
+	return essdk.GetConfig(connection)
+}
+
+func liveConfig(d *plugin.QueryData) (LiveConfig, time.Duration, bool) {
+	if d.Connection == nil {
+		return nil, 0, false
+	}
+	c, ok := d.Connection.Config.(LiveConfig)
+	if !ok {
+		return nil, 0, false
+	}
+	staleness, enabled := c.LiveFallbackSettings()
+	return c, staleness, enabled
+}
+
+// LiveFallbackNeeded reports whether the query should be answered by describing the resources
+// directly: the live fallback is enabled and the documents matching filters are missing or
+// older than the configured staleness.
+func LiveFallbackNeeded(ctx context.Context, d *plugin.QueryData, k Client, index string, filters []essdk.BoolFilter) (bool, error) {
+	_, staleness, enabled := liveConfig(d)
+	if !enabled {
+		return false, nil
+	}
+
+	query := map[string]any{"match_all": map[string]any{}}
+	if len(filters) > 0 {
+		query = map[string]any{"bool": map[string]any{"filter": filters}}
+	}
+	b, err := json.Marshal(map[string]any{
+		"size":  0,
+		"query": query,
+		"aggs": map[string]any{
+			"latest": map[string]any{"max": map[string]any{"field": "created_at"}},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	var response latestDescribeResponse
+	if err := k.Search(ctx, index, string(b), &response); err != nil {
+		if essdk.IsIndexNotFoundErr(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	if response.Aggregations.Latest.Value == nil {
+		return true, nil
+	}
+	if staleness <= 0 {
+		return false, nil
+	}
+	// created_at is the unix millis of the describe job
+	latest := time.UnixMilli(int64(*response.Aggregations.Latest.Value))
+	return time.Since(latest) > staleness, nil
+}
+
+// ListLive describes resourceType directly with the credentials of the connection and streams
+// the resources as T, built from the same description model the describer indexes so the
+// columns are identical to the indexed rows. Qualifiers are rechecked by postgres.
+func ListLive[T any](ctx context.Context, d *plugin.QueryData, resourceType string) error {
+	live, err := liveDescribe(ctx, d, resourceType)
+	if err != nil {
+		return err
+	}
+	if live.resourceType.ListDescriber == nil {
+		return fmt.Errorf("resource type %s has no list describer", resourceType)
+	}
+
+	resources, err := live.resourceType.ListDescriber(ctx, live.cfg, live.account, live.regions, resourceType, enums.DescribeTriggerTypeManual, nil)
+	if err != nil {
+		return err
+	}
+	return streamLive[T](ctx, d, live, resourceType, resources)
+}
+
+// GetLive describes a single resource of resourceType directly. The describer fields are taken
+// from the string equality qualifiers, keyed by both the column name and its camel case form.
+func GetLive[T any](ctx context.Context, d *plugin.QueryData, resourceType string) (any, error) {
+	live, err := liveDescribe(ctx, d, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	if live.resourceType.GetDescriber == nil {
+		return nil, nil
+	}
+
+	fields := map[string]string{}
+	for column, q := range d.EqualsQuals {
+		v, ok := q.GetValue().(*proto.QualValue_StringValue)
+		if !ok {
+			continue
+		}
+		fields[column] = v.StringValue
+		fields[lowerCamel(column)] = v.StringValue
+	}
+
+	resources, err := live.resourceType.GetDescriber(ctx, live.cfg, live.account, live.regions, resourceType, fields, enums.DescribeTriggerTypeManual)
+	if err != nil {
+		return nil, err
+	}
+	for _, rs := range resources.Resources {
+		for _, r := range rs {
+			item, err := liveItem[T](ctx, d, live, resourceType, r)
+			if err != nil {
+				return nil, err
+			}
+			return item, nil
+		}
+	}
+	return nil, nil
+}
+
+// liveGetSupported reports whether GetLive can describe a single resource of resourceType.
+func liveGetSupported(resourceType string) bool {
+	rt, err := aws.GetResourceType(resourceType)
+	return err == nil && rt.GetDescriber != nil
+}
+
+// liveDescription is a describe of the resources of a query with the credentials of the
+// connection.
+type liveDescription struct {
+	resourceType *aws.ResourceType
+	cfg          awssdk.Config
+	account      string
+	sourceID     string
+	regions      []string
+	describedAt  int64
+	tags         *aws.TagEnricher
+}
+
+func liveDescribe(ctx context.Context, d *plugin.QueryData, resourceType string) (*liveDescription, error) {
+	c, _, _ := liveConfig(d)
+	if c == nil {
+		return nil, fmt.Errorf("connection has no live config")
+	}
+	rt, err := aws.GetResourceType(resourceType)
+	if err != nil {
+		return nil, err
+	}
+	cfg, regions, err := c.LiveAWSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	identity, err := sts.NewFromConfig(cfg).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, err
+	}
+	return &liveDescription{
+		resourceType: rt,
+		cfg:          cfg,
+		account:      *identity.Account,
+		sourceID:     c.LiveSourceID(),
+		regions:      regions,
+		describedAt:  time.Now().UnixMilli(),
+		tags:         aws.NewTagEnricher(cfg, zap.NewNop()),
+	}, nil
+}
+
+func streamLive[T any](ctx context.Context, d *plugin.QueryData, live *liveDescription, resourceType string, resources *aws.Resources) error {
+	if resources == nil {
+		return nil
+	}
+	for region, message := range resources.Errors {
+		plugin.Logger(ctx).Warn("ListLive describe failed", "resourceType", resourceType, "region", region, "error", message)
+	}
+	for _, rs := range resources.Resources {
+		for _, r := range rs {
+			item, err := liveItem[T](ctx, d, live, resourceType, r)
+			if err != nil {
+				return err
+			}
+			d.StreamListItem(ctx, item)
+			if d.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// liveItem builds the document the worker and the sink would have indexed for r, with its
+// metadata, name, enriched tags and es id, and decodes it as T. Only the description
+// fingerprint, which the sink adds for change tracking, is left out.
+func liveItem[T any](ctx context.Context, d *plugin.QueryData, live *liveDescription, resourceType string, r describer.Resource) (T, error) {
+	var item T
+
+	metadata, err := aws.ResourceMetadata(&r, resourceType, live.account, live.sourceID)
+	if err != nil {
+		return item, err
+	}
+	resource := es.Resource{
+		ID:           r.UniqueID(),
+		ARN:          r.ARN,
+		Name:         r.Name,
+		SourceType:   source.CloudAWS,
+		ResourceType: strings.ToLower(resourceType),
+		Location:     r.Region,
+		SourceID:     live.sourceID,
+		CreatedAt:    live.describedAt,
+		Description:  r.Description,
+		Metadata:     metadata,
+	}
+
+	// the tags and the name are read from the columns of the table, like the worker does.
+	if d.Table != nil && d.Table.Plugin != nil {
+		tags, name, err := steampipe.ExtractTagsAndNames(d.Table.Plugin, nil, d.Table.Name, resourceType, resource,
+			map[string]any{resourceType: item})
+		if err != nil {
+			return item, fmt.Errorf("failed to build tags for service: %v", err.Error())
+		}
+		if len(name) > 0 {
+			metadata["name"] = name
+		}
+		tags, tagsSource := live.tags.Enrich(ctx, r.Region, r.ARN, tags)
+		if tagsSource != "" {
+			metadata["TagsSource"] = tagsSource
+		}
+		resource.CanonicalTags = aws.CanonicalTags(tags)
+	}
+	keys, idx := resource.KeysAndIndex()
+	resource.EsID = es.HashOf(keys...)
+	resource.EsIndex = idx
+
+	b, err := json.Marshal(resource)
+	if err != nil {
+		return item, err
+	}
+	if err := json.Unmarshal(b, &item); err != nil {
+		return item, err
+	}
+	return item, nil
+}
+
+func lowerCamel(column string) string {
+	parts := strings.Split(column, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,134 @@
+package opengovernance
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	essdk "github.com/opengovern/og-util/pkg/opengovernance-es-sdk"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
+)
+
+// TagsColumn is the jsonb column holding the resource tags in every table that has tags.
+const TagsColumn = "tags"
+
+// TagQualOperators are the operators on the tags column that are pushed down to the
+// canonical_tags of the documents.
+var TagQualOperators = []string{
+	quals.QualOperatorEqual,
+	quals.QualOperatorJsonbContainsLeftRight,
+	quals.QualOperatorJsonbExistsOne,
+	quals.QualOperatorJsonbExistsAny,
+	quals.QualOperatorJsonbExistsAll,
+}
+
+// BuildTagFilters translates the qualifiers on the tags column into filters on canonical_tags:
+//
+//	tags ? 'env'               key exists
+//	tags ?| array['a', 'b']    any of the keys exists
+//	tags ?& array['a', 'b']    all of the keys exist
+//	tags @> '{"env": "prod"}'  key has the value
+//
+// Steampipe only passes qualifiers on plain columns to the plugin, so expressions on the column
+// such as tags ->> 'env' = 'prod' are not pushed down and filter the listed rows in postgres.
+// Write them as tags @> '{"env": "prod"}' to have them applied in the index.
+//
+// canonical_tags are lower cased when indexed, so the filters are case-insensitive and may
+// match more documents than the qualifier. Postgres rechecks every qualifier on the returned
+// rows, so the result is still exact.
+func BuildTagFilters(ctx context.Context, queryContext *plugin.QueryContext) []essdk.BoolFilter {
+	var filters []essdk.BoolFilter
+	for column, qs := range queryContext.UnsafeQuals {
+		if column != TagsColumn || qs == nil {
+			continue
+		}
+		for _, qual := range qs.GetQuals() {
+			if qual.GetFieldName() != TagsColumn {
+				continue
+			}
+			filter := tagFilter(qual)
+			if filter == nil {
+				plugin.Logger(ctx).Trace("BuildTagFilters qual not pushed down", "qual", qual.String())
+				continue
+			}
+			filters = append(filters, filter)
+		}
+	}
+	return filters
+}
+
+func tagFilter(qual *proto.Qual) essdk.BoolFilter {
+	value := qual.GetValue()
+	switch qual.GetStringValue() {
+	case quals.QualOperatorJsonbExistsOne:
+		if key, ok := value.GetValue().(*proto.QualValue_StringValue); ok {
+			return tagKeyFilter(key.StringValue)
+		}
+	case quals.QualOperatorJsonbExistsAny, quals.QualOperatorJsonbExistsAll:
+		keys := tagQualKeys(value)
+		if len(keys) == 0 {
+			return nil
+		}
+		filters := make([]essdk.BoolFilter, 0, len(keys))
+		for _, key := range keys {
+			filters = append(filters, tagKeyFilter(key))
+		}
+		if qual.GetStringValue() == quals.QualOperatorJsonbExistsAny {
+			return essdk.NewBoolShouldFilter(filters...)
+		}
+		return essdk.NewBoolMustFilter(filters...)
+	case quals.QualOperatorJsonbContainsLeftRight, quals.QualOperatorEqual:
+		// equality of the whole tag object implies containment
+		jsonb, ok := value.GetValue().(*proto.QualValue_JsonbValue)
+		if !ok {
+			return nil
+		}
+		var tags map[string]any
+		if err := json.Unmarshal([]byte(jsonb.JsonbValue), &tags); err != nil || len(tags) == 0 {
+			return nil
+		}
+		filters := make([]essdk.BoolFilter, 0, len(tags))
+		for k, v := range tags {
+			s, ok := v.(string)
+			if !ok {
+				return nil
+			}
+			filters = append(filters, tagValueFilter(k, s))
+		}
+		return essdk.NewBoolMustFilter(filters...)
+	}
+	return nil
+}
+
+func tagQualKeys(value *proto.QualValue) []string {
+	list := value.GetListValue()
+	if list == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(list.GetValues()))
+	for _, v := range list.GetValues() {
+		key, ok := v.GetValue().(*proto.QualValue_StringValue)
+		if !ok {
+			return nil
+		}
+		keys = append(keys, key.StringValue)
+	}
+	return keys
+}
+
+func tagKeyFilter(key string) essdk.BoolFilter {
+	return essdk.NewNestedFilter("canonical_tags",
+		essdk.NewTermFilter("canonical_tags.key", strings.ToLower(key)),
+	)
+}
+
+func tagValueFilter(key, value string) essdk.BoolFilter {
+	return essdk.NewNestedFilter("canonical_tags",
+		essdk.NewBoolMustFilter(
+			essdk.NewTermFilter("canonical_tags.key", strings.ToLower(key)),
+			essdk.NewTermFilter("canonical_tags.value", strings.ToLower(value)),
+		),
+	)
+}
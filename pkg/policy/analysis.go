@@ -0,0 +1,130 @@
+package policy
+
+import (
+	"sort"
+	"strings"
+)
+
+// restrictingConditionKeys are the condition keys that limit who can make a request, a
+// statement granting access to * with one of them is not public.
+var restrictingConditionKeys = map[string]bool{
+	"aws:principalaccount":  true,
+	"aws:principalarn":      true,
+	"aws:principalorgid":    true,
+	"aws:principalorgpaths": true,
+	"aws:userid":            true,
+	"aws:sourceaccount":     true,
+	"aws:sourcearn":         true,
+	"aws:sourceowner":       true,
+	"aws:sourceorgid":       true,
+	"aws:sourceorgpaths":    true,
+	"aws:sourceip":          true,
+	"aws:sourcevpc":         true,
+	"aws:sourcevpce":        true,
+	"kms:calleraccount":     true,
+}
+
+// restrictingOperators are the operators that narrow a restricting key down to given values.
+var restrictingOperators = map[string]bool{
+	"stringequals":           true,
+	"stringequalsignorecase": true,
+	"stringlike":             true,
+	"arnequals":              true,
+	"arnlike":                true,
+	"ipaddress":              true,
+}
+
+// IsPublic reports whether the resource policy grants access to anyone: an Allow statement
+// has a * principal, or a NotPrincipal, and none of its conditions restrict the caller to
+// given accounts, organizations, networks or source resources.
+func IsPublic(p Policy) bool {
+	for _, s := range p.Statements {
+		if !strings.EqualFold(s.Effect, "Allow") {
+			continue
+		}
+		if !grantsEveryone(s) {
+			continue
+		}
+		if !restrictsCaller(s.Condition) {
+			return true
+		}
+	}
+	return false
+}
+
+func grantsEveryone(s Statement) bool {
+	if len(s.NotPrincipal) > 0 {
+		return true
+	}
+	for principalType := range s.Principal {
+		if principalType != "AWS" && principalType != "*" {
+			continue
+		}
+		for _, value := range PrincipalValues(s.Principal, principalType) {
+			if value == "*" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func restrictsCaller(conditions map[string]interface{}) bool {
+	for operator, condition := range conditions {
+		op := strings.ToLower(operator)
+		op = strings.TrimPrefix(op, forAnyValuePrefix)
+		op = strings.TrimPrefix(op, forAllValuesPrefix)
+		if !restrictingOperators[op] {
+			continue
+		}
+		keys, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for key, values := range keys {
+			if !restrictingConditionKeys[strings.ToLower(key)] {
+				continue
+			}
+			for _, v := range conditionValues(values) {
+				if v != "*" && v != "0.0.0.0/0" && v != "::/0" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// CrossAccountPrincipals returns the AWS principals an Allow statement of the resource policy
+// grants access to that are outside account, sorted and without duplicates. A * principal is
+// returned as is, IsPublic tells whether its conditions restrict it to known callers.
+func CrossAccountPrincipals(p Policy, account string) []string {
+	seen := map[string]bool{}
+	for _, s := range p.Statements {
+		if !strings.EqualFold(s.Effect, "Allow") {
+			continue
+		}
+		for principalType := range s.Principal {
+			if principalType != "AWS" && principalType != "*" {
+				continue
+			}
+			for _, value := range PrincipalValues(s.Principal, principalType) {
+				if value == "*" {
+					seen[value] = true
+					continue
+				}
+				principalAccount := AccountOf(value)
+				if principalAccount != "" && principalAccount != account {
+					seen[value] = true
+				}
+			}
+		}
+	}
+
+	principals := make([]string, 0, len(seen))
+	for principal := range seen {
+		principals = append(principals, principal)
+	}
+	sort.Strings(principals)
+	return principals
+}
@@ -0,0 +1,119 @@
+// gen writes the action catalog of the policy package from the machine readable Service
+// Authorization Reference: actions.json with the actions of every service, and reference.json
+// with the number of actions the reference lists per service, which the catalog is tested
+// against.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// defaultReferenceURL is the index of the machine readable Service Authorization Reference.
+const defaultReferenceURL = "https://servicereference.us-east-1.amazonaws.com/"
+
+var (
+	referenceURL = flag.String("reference", defaultReferenceURL, "url of the Service Authorization Reference index")
+	outDir       = flag.String("out", "catalog", "directory actions.json and reference.json are written to")
+)
+
+// serviceEntry is an entry of the reference index.
+type serviceEntry struct {
+	Service string `json:"service"`
+	URL     string `json:"url"`
+}
+
+// serviceReference is the part of the reference of a service the catalog is built from.
+type serviceReference struct {
+	Name    string `json:"Name"`
+	Actions []struct {
+		Name string `json:"Name"`
+	} `json:"Actions"`
+}
+
+// Reference records where the catalog was generated from and the number of actions of each
+// service in it.
+type Reference struct {
+	Source   string         `json:"source"`
+	Services map[string]int `json:"services"`
+}
+
+func main() {
+	flag.Parse()
+
+	client := &http.Client{Timeout: time.Minute}
+	catalog, reference, err := fetchCatalog(client, *referenceURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := writeJSON(filepath.Join(*outDir, "actions.json"), catalog); err != nil {
+		log.Fatal(err)
+	}
+	if err := writeJSON(filepath.Join(*outDir, "reference.json"), reference); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// fetchCatalog reads the reference of every service in the index at indexURL and returns the
+// catalog, service prefix -> sorted action names, and the action count of each service.
+func fetchCatalog(client *http.Client, indexURL string) (map[string][]string, Reference, error) {
+	var index []serviceEntry
+	if err := getJSON(client, indexURL, &index); err != nil {
+		return nil, Reference{}, fmt.Errorf("reference index: %w", err)
+	}
+
+	catalog := map[string][]string{}
+	reference := Reference{Source: indexURL, Services: map[string]int{}}
+	for _, entry := range index {
+		var service serviceReference
+		if err := getJSON(client, entry.URL, &service); err != nil {
+			return nil, Reference{}, fmt.Errorf("service %s: %w", entry.Service, err)
+		}
+		prefix := service.Name
+		if prefix == "" {
+			prefix = entry.Service
+		}
+
+		seen := map[string]bool{}
+		for _, action := range service.Actions {
+			if action.Name == "" || seen[action.Name] {
+				continue
+			}
+			seen[action.Name] = true
+			catalog[prefix] = append(catalog[prefix], action.Name)
+		}
+		sort.Strings(catalog[prefix])
+		reference.Services[prefix] = len(catalog[prefix])
+	}
+	if len(catalog) == 0 {
+		return nil, Reference{}, fmt.Errorf("reference index %s lists no services", indexURL)
+	}
+	return catalog, reference, nil
+}
+
+func getJSON(client *http.Client, url string, v any) error {
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func writeJSON(path string, v any) error {
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(content, '\n'), 0644)
+}
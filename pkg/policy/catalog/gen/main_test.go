@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestFetchCatalog(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	serve := func(path string, v any) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(v)
+		})
+	}
+	serve("/", []serviceEntry{
+		{Service: "ecs", URL: server.URL + "/v1/ecs/ecs.json"},
+		{Service: "s3", URL: server.URL + "/v1/s3/s3.json"},
+	})
+	serve("/v1/ecs/ecs.json", map[string]any{
+		"Name":    "ecs",
+		"Actions": []map[string]any{{"Name": "RunTask"}, {"Name": "ListClusters"}, {"Name": "RunTask"}},
+	})
+	serve("/v1/s3/s3.json", map[string]any{
+		"Name":    "s3",
+		"Actions": []map[string]any{{"Name": "GetObject"}},
+	})
+
+	catalog, reference, err := fetchCatalog(server.Client(), server.URL+"/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantCatalog := map[string][]string{
+		"ecs": {"ListClusters", "RunTask"},
+		"s3":  {"GetObject"},
+	}
+	if !reflect.DeepEqual(catalog, wantCatalog) {
+		t.Errorf("catalog = %v, want %v", catalog, wantCatalog)
+	}
+	wantCounts := map[string]int{"ecs": 2, "s3": 1}
+	if !reflect.DeepEqual(reference.Services, wantCounts) {
+		t.Errorf("reference counts = %v, want %v", reference.Services, wantCounts)
+	}
+}
+
+func TestFetchCatalogFailingService(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]serviceEntry{{Service: "ecs", URL: server.URL + "/missing.json"}})
+	})
+	mux.HandleFunc("/missing.json", http.NotFound)
+
+	if _, _, err := fetchCatalog(server.Client(), server.URL+"/"); err == nil {
+		t.Error("a service the reference can't be read for must fail the generation")
+	}
+}
@@ -0,0 +1,253 @@
+package policy
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	forAnyValuePrefix  = "foranyvalue:"
+	forAllValuesPrefix = "forallvalues:"
+	ifExistsSuffix     = "ifexists"
+)
+
+// conditionsMatch reports whether every condition of a statement holds for the request context.
+// Conditions are in canonical form, operator -> lower cased key -> values. Operators that
+// aren't supported never hold, so an Allow with them doesn't allow and a Deny doesn't deny.
+func conditionsMatch(conditions map[string]interface{}, context map[string][]string) bool {
+	for operator, condition := range conditions {
+		keys, ok := condition.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, values := range keys {
+			if !conditionMatches(operator, strings.ToLower(key), conditionValues(values), context) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func conditionValues(values interface{}) []string {
+	switch v := values.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, value := range v {
+			if s, ok := value.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	case string:
+		return []string{v}
+	}
+	return nil
+}
+
+func conditionMatches(operator, key string, policyValues []string, context map[string][]string) bool {
+	op := strings.ToLower(operator)
+
+	forAny, forAll := false, false
+	switch {
+	case strings.HasPrefix(op, forAnyValuePrefix):
+		forAny = true
+		op = strings.TrimPrefix(op, forAnyValuePrefix)
+	case strings.HasPrefix(op, forAllValuesPrefix):
+		forAll = true
+		op = strings.TrimPrefix(op, forAllValuesPrefix)
+	}
+	ifExists := false
+	if op != "null" && strings.HasSuffix(op, ifExistsSuffix) {
+		ifExists = true
+		op = strings.TrimSuffix(op, ifExistsSuffix)
+	}
+
+	contextValues, present := context[key]
+	if op == "null" {
+		want := len(policyValues) > 0 && strings.EqualFold(policyValues[0], "true")
+		return want == !present
+	}
+
+	match, negated, ok := operatorFunc(op)
+	if !ok {
+		return false
+	}
+	if !present || len(contextValues) == 0 {
+		// a missing key satisfies IfExists, ForAllValues and the negated operators
+		return ifExists || forAll || (negated && !forAny)
+	}
+
+	matchesPolicy := func(contextValue string) bool {
+		for _, policyValue := range policyValues {
+			if match(substituteVariables(policyValue, context), contextValue) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case forAll:
+		for _, v := range contextValues {
+			if matchesPolicy(v) == negated {
+				return false
+			}
+		}
+		return true
+	case forAny:
+		for _, v := range contextValues {
+			if matchesPolicy(v) != negated {
+				return true
+			}
+		}
+		return false
+	case negated:
+		for _, v := range contextValues {
+			if matchesPolicy(v) {
+				return false
+			}
+		}
+		return true
+	default:
+		for _, v := range contextValues {
+			if matchesPolicy(v) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// operatorFunc returns the comparison of a condition operator, without its set prefix and
+// IfExists suffix, and whether the operator is the negation of the comparison.
+func operatorFunc(op string) (func(policyValue, contextValue string) bool, bool, bool) {
+	switch op {
+	case "stringequals":
+		return stringEquals, false, true
+	case "stringnotequals":
+		return stringEquals, true, true
+	case "stringequalsignorecase":
+		return strings.EqualFold, false, true
+	case "stringnotequalsignorecase":
+		return strings.EqualFold, true, true
+	case "stringlike":
+		return WildcardMatch, false, true
+	case "stringnotlike":
+		return WildcardMatch, true, true
+	case "arnequals", "arnlike":
+		return WildcardMatch, false, true
+	case "arnnotequals", "arnnotlike":
+		return WildcardMatch, true, true
+	case "bool":
+		return strings.EqualFold, false, true
+	case "ipaddress":
+		return ipMatches, false, true
+	case "notipaddress":
+		return ipMatches, true, true
+	case "numericequals":
+		return numericCompare(func(c int) bool { return c == 0 }), false, true
+	case "numericnotequals":
+		return numericCompare(func(c int) bool { return c == 0 }), true, true
+	case "numericlessthan":
+		return numericCompare(func(c int) bool { return c < 0 }), false, true
+	case "numericlessthanequals":
+		return numericCompare(func(c int) bool { return c <= 0 }), false, true
+	case "numericgreaterthan":
+		return numericCompare(func(c int) bool { return c > 0 }), false, true
+	case "numericgreaterthanequals":
+		return numericCompare(func(c int) bool { return c >= 0 }), false, true
+	case "dateequals":
+		return dateCompare(func(c int) bool { return c == 0 }), false, true
+	case "datenotequals":
+		return dateCompare(func(c int) bool { return c == 0 }), true, true
+	case "datelessthan":
+		return dateCompare(func(c int) bool { return c < 0 }), false, true
+	case "datelessthanequals":
+		return dateCompare(func(c int) bool { return c <= 0 }), false, true
+	case "dategreaterthan":
+		return dateCompare(func(c int) bool { return c > 0 }), false, true
+	case "dategreaterthanequals":
+		return dateCompare(func(c int) bool { return c >= 0 }), false, true
+	}
+	return nil, false, false
+}
+
+func stringEquals(policyValue, contextValue string) bool {
+	return policyValue == contextValue
+}
+
+// ipMatches matches an ip against a CIDR, or a single address, of the policy.
+func ipMatches(policyValue, contextValue string) bool {
+	ip := net.ParseIP(contextValue)
+	if ip == nil {
+		return false
+	}
+	if !strings.Contains(policyValue, "/") {
+		other := net.ParseIP(policyValue)
+		return other != nil && other.Equal(ip)
+	}
+	_, network, err := net.ParseCIDR(policyValue)
+	if err != nil {
+		return false
+	}
+	return network.Contains(ip)
+}
+
+// numericCompare compares the context value to the policy value, ok gets the sign of the
+// difference context - policy.
+func numericCompare(ok func(int) bool) func(string, string) bool {
+	return func(policyValue, contextValue string) bool {
+		p, err := strconv.ParseFloat(policyValue, 64)
+		if err != nil {
+			return false
+		}
+		c, err := strconv.ParseFloat(contextValue, 64)
+		if err != nil {
+			return false
+		}
+		return ok(compare(c, p))
+	}
+}
+
+func dateCompare(ok func(int) bool) func(string, string) bool {
+	return func(policyValue, contextValue string) bool {
+		p, err := parseDate(policyValue)
+		if err != nil {
+			return false
+		}
+		c, err := parseDate(contextValue)
+		if err != nil {
+			return false
+		}
+		return ok(compare(c.UnixNano(), p.UnixNano()))
+	}
+}
+
+// parseDate parses the date formats IAM accepts, ISO 8601 and epoch seconds.
+func parseDate(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04Z07:00", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	seconds, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(seconds, 0), nil
+}
+
+func compare[T int64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,376 @@
+package policy
+
+import (
+	"strings"
+)
+
+// Decision is the outcome of evaluating a request against the policies that apply to it.
+type Decision string
+
+const (
+	// Allowed means a policy allows the request and nothing denies it.
+	Allowed Decision = "Allow"
+	// ExplicitDeny means a Deny statement matches the request, nothing can override it.
+	ExplicitDeny Decision = "ExplicitDeny"
+	// ImplicitDeny means no policy allows the request, or a policy that bounds the permissions,
+	// a permissions boundary or an SCP, doesn't allow it.
+	ImplicitDeny Decision = "ImplicitDeny"
+)
+
+// Request is a single call of Principal performing Action on Resource.
+type Request struct {
+	// Principal is the ARN of the calling principal, e.g. arn:aws:iam::123456789012:role/admin,
+	// or the name of a service principal such as cloudtrail.amazonaws.com.
+	Principal string
+	// Action is the action in service:Action form, case insensitive.
+	Action string
+	// Resource is the ARN of the resource, or * for actions that don't take a resource.
+	Resource string
+	// ResourceAccount is the account owning the resource. It is taken from Resource when empty,
+	// and resources without an account in their ARN, like s3 buckets, are in the principal account.
+	ResourceAccount string
+	// Context holds the values of the condition keys, e.g. aws:SourceIp. Keys are case
+	// insensitive. aws:PrincipalArn and aws:PrincipalAccount are set from Principal if missing.
+	Context map[string][]string
+}
+
+// Policies are the policies that apply to a request.
+type Policies struct {
+	// Identity are the policies attached to the principal, directly or through its groups.
+	Identity []Policy
+	// Resource is the resource based policy of the resource, nil if it has none.
+	Resource *Policy
+	// PermissionsBoundary is the permissions boundary of the principal, nil if it has none.
+	PermissionsBoundary *Policy
+	// ServiceControlPolicies has one entry per level of the organization, from the root down to
+	// the account, with the SCPs attached at that level. Every level has to allow the request.
+	ServiceControlPolicies [][]Policy
+}
+
+// Evaluate decides whether the request is allowed, following the IAM policy evaluation logic:
+// an explicit deny in any policy wins, the SCPs and the permissions boundary have to allow the
+// request, and then the identity or the resource policy has to allow it. For cross account
+// requests both the identity and the resource policy have to allow it.
+func Evaluate(req Request, policies Policies) Decision {
+	req = req.normalized()
+
+	var all []Policy
+	all = append(all, policies.Identity...)
+	if policies.PermissionsBoundary != nil {
+		all = append(all, *policies.PermissionsBoundary)
+	}
+	for _, level := range policies.ServiceControlPolicies {
+		all = append(all, level...)
+	}
+	for _, p := range all {
+		if matchesAny(req, p, "Deny", false) {
+			return ExplicitDeny
+		}
+	}
+	if policies.Resource != nil && matchesAny(req, *policies.Resource, "Deny", true) {
+		return ExplicitDeny
+	}
+
+	for _, level := range policies.ServiceControlPolicies {
+		allowed := false
+		for _, p := range level {
+			if matchesAny(req, p, "Allow", false) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return ImplicitDeny
+		}
+	}
+
+	identityAllowed := false
+	for _, p := range policies.Identity {
+		if matchesAny(req, p, "Allow", false) {
+			identityAllowed = true
+			break
+		}
+	}
+	if identityAllowed && policies.PermissionsBoundary != nil {
+		identityAllowed = matchesAny(req, *policies.PermissionsBoundary, "Allow", false)
+	}
+	resourceAllowed := policies.Resource != nil && matchesAny(req, *policies.Resource, "Allow", true)
+
+	if req.crossAccount() {
+		if identityAllowed && resourceAllowed {
+			return Allowed
+		}
+		return ImplicitDeny
+	}
+	if identityAllowed || resourceAllowed {
+		return Allowed
+	}
+	return ImplicitDeny
+}
+
+// Allows reports whether the policy on its own allows the request, ignoring every other policy.
+// The principal is matched too, so this also answers whether a resource policy grants access.
+func Allows(req Request, p Policy) bool {
+	req = req.normalized()
+	return !matchesAny(req, p, "Deny", true) && matchesAny(req, p, "Allow", true)
+}
+
+func (req Request) normalized() Request {
+	context := make(map[string][]string, len(req.Context)+2)
+	for k, v := range req.Context {
+		context[strings.ToLower(k)] = v
+	}
+	if _, ok := context["aws:principalarn"]; !ok && strings.HasPrefix(req.Principal, "arn:") {
+		context["aws:principalarn"] = []string{req.Principal}
+	}
+	if _, ok := context["aws:principalaccount"]; !ok {
+		if account := AccountOf(req.Principal); account != "" {
+			context["aws:principalaccount"] = []string{account}
+		}
+	}
+	req.Context = context
+	if req.ResourceAccount == "" {
+		req.ResourceAccount = AccountOf(req.Resource)
+	}
+	return req
+}
+
+func (req Request) crossAccount() bool {
+	principalAccount := AccountOf(req.Principal)
+	if principalAccount == "" || req.ResourceAccount == "" {
+		return false
+	}
+	return principalAccount != req.ResourceAccount
+}
+
+// matchesAny reports whether a statement of p with the given effect applies to the request.
+// Principals are only matched for resource policies, identity policies have none.
+func matchesAny(req Request, p Policy, effect string, matchPrincipal bool) bool {
+	for _, s := range p.Statements {
+		if !strings.EqualFold(s.Effect, effect) {
+			continue
+		}
+		if matchPrincipal && !statementPrincipalMatches(s, req.Principal) {
+			continue
+		}
+		if !statementActionMatches(s, req.Action) {
+			continue
+		}
+		if !statementResourceMatches(s, req.Resource, req.Context) {
+			continue
+		}
+		if !conditionsMatch(s.Condition, req.Context) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func statementActionMatches(s Statement, action string) bool {
+	action = strings.ToLower(action)
+	if len(s.NotAction) > 0 {
+		for _, pattern := range s.NotAction {
+			if WildcardMatch(strings.ToLower(pattern), action) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, pattern := range s.Action {
+		if WildcardMatch(strings.ToLower(pattern), action) {
+			return true
+		}
+	}
+	return false
+}
+
+func statementResourceMatches(s Statement, resource string, context map[string][]string) bool {
+	if len(s.NotResource) > 0 {
+		for _, pattern := range s.NotResource {
+			if WildcardMatch(substituteVariables(pattern, context), resource) {
+				return false
+			}
+		}
+		return true
+	}
+	// trust policies and some resource policies leave the resource out, it is the policy's own
+	if len(s.Resource) == 0 {
+		return true
+	}
+	for _, pattern := range s.Resource {
+		if WildcardMatch(substituteVariables(pattern, context), resource) {
+			return true
+		}
+	}
+	return false
+}
+
+func statementPrincipalMatches(s Statement, principal string) bool {
+	if len(s.NotPrincipal) > 0 {
+		return !principalMatches(s.NotPrincipal, principal)
+	}
+	return principalMatches(s.Principal, principal)
+}
+
+func principalMatches(p Principal, principal string) bool {
+	for principalType := range p {
+		for _, value := range PrincipalValues(p, principalType) {
+			if value == "*" {
+				return true
+			}
+			switch principalType {
+			case "AWS":
+				if awsPrincipalMatches(value, principal) {
+					return true
+				}
+			default:
+				if strings.EqualFold(value, principal) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// awsPrincipalMatches matches an AWS principal element against the calling principal. An
+// account id or the account root ARN stands for every principal of the account, and a role
+// matches the sessions of the role.
+func awsPrincipalMatches(value, principal string) bool {
+	if value == principal {
+		return true
+	}
+	account := AccountOf(principal)
+	if account == "" {
+		return false
+	}
+	if value == account || (strings.HasPrefix(value, "arn:") && strings.HasSuffix(value, ":root") && AccountOf(value) == account) {
+		return true
+	}
+	if role := roleOfSession(principal); role != "" {
+		return strings.HasPrefix(value, "arn:") && strings.HasSuffix(value, ":role/"+role) && AccountOf(value) == account
+	}
+	return false
+}
+
+// roleOfSession returns the role name of an assumed role session ARN,
+// arn:aws:sts::123456789012:assumed-role/name/session.
+func roleOfSession(principal string) string {
+	parts := strings.SplitN(principal, ":", 6)
+	if len(parts) != 6 || parts[2] != "sts" || !strings.HasPrefix(parts[5], "assumed-role/") {
+		return ""
+	}
+	segments := strings.Split(strings.TrimPrefix(parts[5], "assumed-role/"), "/")
+	return segments[0]
+}
+
+// PrincipalValues returns the principals of the given type, e.g. AWS or Service. The values are
+// []string once parsed, and []interface{} when the policy was read back from json.
+func PrincipalValues(p Principal, principalType string) []string {
+	switch values := p[principalType].(type) {
+	case []string:
+		return values
+	case []interface{}:
+		result := make([]string, 0, len(values))
+		for _, v := range values {
+			if s, ok := v.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	case string:
+		return []string{values}
+	}
+	return nil
+}
+
+// AccountOf returns the account id of an ARN or of a bare account id, and an empty string when
+// there is none, e.g. for s3 bucket ARNs or service principals.
+func AccountOf(arnOrAccount string) string {
+	if isAccountID(arnOrAccount) {
+		return arnOrAccount
+	}
+	if !strings.HasPrefix(arnOrAccount, "arn:") {
+		return ""
+	}
+	parts := strings.SplitN(arnOrAccount, ":", 6)
+	if len(parts) < 5 || !isAccountID(parts[4]) {
+		return ""
+	}
+	return parts[4]
+}
+
+func isAccountID(s string) bool {
+	if len(s) != 12 {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// WildcardMatch matches value against an IAM pattern where * matches any sequence of
+// characters and ? any single character.
+func WildcardMatch(pattern, value string) bool {
+	p, v := 0, 0
+	star, match := -1, 0
+	for v < len(value) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == value[v]):
+			p++
+			v++
+		case p < len(pattern) && pattern[p] == '*':
+			star, match = p, v
+			p++
+		case star != -1:
+			p = star + 1
+			match++
+			v = match
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// substituteVariables replaces the policy variables, e.g. ${aws:username}, with their value
+// in the request context. Variables without a single value are left as they are, so they
+// don't match anything.
+func substituteVariables(s string, context map[string][]string) string {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start == -1 {
+			b.WriteString(s)
+			return b.String()
+		}
+		end := strings.Index(s[start:], "}")
+		if end == -1 {
+			b.WriteString(s)
+			return b.String()
+		}
+		b.WriteString(s[:start])
+		variable := s[start+2 : start+end]
+		switch variable {
+		case "*", "?", "$":
+			b.WriteString(variable)
+		default:
+			if values := context[strings.ToLower(variable)]; len(values) == 1 {
+				b.WriteString(values[0])
+			} else {
+				b.WriteString(s[start : start+end+1])
+			}
+		}
+		s = s[start+end+1:]
+	}
+}
@@ -0,0 +1,237 @@
+package policy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func mustParse(t *testing.T, src string) Policy {
+	t.Helper()
+	p, err := Parse(src)
+	if err != nil {
+		t.Fatalf("parsing policy: %v", err)
+	}
+	return p
+}
+
+func TestEvaluate(t *testing.T) {
+	const role = "arn:aws:iam::111111111111:role/app"
+	const bucket = "arn:aws:s3:::data/report.csv"
+
+	readAll := `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"s3:Get*","Resource":"*"}}`
+	denyFromOutside := `{"Version":"2012-10-17","Statement":{"Effect":"Deny","Action":"*","Resource":"*","Condition":{"NotIpAddress":{"aws:SourceIp":"10.0.0.0/8"}}}}`
+	boundary := `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"s3:GetObject","Resource":"arn:aws:s3:::data/*"}}`
+	scpAllowAll := `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"*","Resource":"*"}}`
+	scpOnlyEC2 := `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"ec2:*","Resource":"*"}}`
+	bucketPolicy := `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Principal":{"AWS":"222222222222"},"Action":"s3:GetObject","Resource":"arn:aws:s3:::data/*"}}`
+	orgPolicy := `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::data/*","Condition":{"StringEquals":{"aws:PrincipalOrgID":"o-abc"}}}}`
+
+	cases := []struct {
+		name     string
+		req      Request
+		policies func() Policies
+		want     Decision
+	}{
+		{
+			name:     "no policy",
+			req:      Request{Principal: role, Action: "s3:GetObject", Resource: bucket},
+			policies: func() Policies { return Policies{} },
+			want:     ImplicitDeny,
+		},
+		{
+			name: "identity allow with wildcard action",
+			req:  Request{Principal: role, Action: "S3:GetObject", Resource: bucket},
+			policies: func() Policies {
+				return Policies{Identity: []Policy{mustParse(t, readAll)}}
+			},
+			want: Allowed,
+		},
+		{
+			name: "explicit deny on source ip",
+			req:  Request{Principal: role, Action: "s3:GetObject", Resource: bucket, Context: map[string][]string{"aws:SourceIp": {"203.0.113.10"}}},
+			policies: func() Policies {
+				return Policies{Identity: []Policy{mustParse(t, readAll), mustParse(t, denyFromOutside)}}
+			},
+			want: ExplicitDeny,
+		},
+		{
+			name: "deny on source ip does not apply inside the network",
+			req:  Request{Principal: role, Action: "s3:GetObject", Resource: bucket, Context: map[string][]string{"aws:SourceIp": {"10.1.2.3"}}},
+			policies: func() Policies {
+				return Policies{Identity: []Policy{mustParse(t, readAll), mustParse(t, denyFromOutside)}}
+			},
+			want: Allowed,
+		},
+		{
+			name: "permissions boundary limits identity policy",
+			req:  Request{Principal: role, Action: "s3:GetBucketPolicy", Resource: "arn:aws:s3:::data"},
+			policies: func() Policies {
+				b := mustParse(t, boundary)
+				return Policies{Identity: []Policy{mustParse(t, readAll)}, PermissionsBoundary: &b}
+			},
+			want: ImplicitDeny,
+		},
+		{
+			name: "every scp level has to allow",
+			req:  Request{Principal: role, Action: "s3:GetObject", Resource: bucket},
+			policies: func() Policies {
+				return Policies{
+					Identity:               []Policy{mustParse(t, readAll)},
+					ServiceControlPolicies: [][]Policy{{mustParse(t, scpAllowAll)}, {mustParse(t, scpOnlyEC2)}},
+				}
+			},
+			want: ImplicitDeny,
+		},
+		{
+			name: "cross account needs identity and resource policy",
+			req:  Request{Principal: "arn:aws:iam::222222222222:user/bob", Action: "s3:GetObject", Resource: bucket, ResourceAccount: "111111111111"},
+			policies: func() Policies {
+				r := mustParse(t, bucketPolicy)
+				return Policies{Resource: &r}
+			},
+			want: ImplicitDeny,
+		},
+		{
+			name: "cross account allowed by both",
+			req:  Request{Principal: "arn:aws:iam::222222222222:user/bob", Action: "s3:GetObject", Resource: bucket, ResourceAccount: "111111111111"},
+			policies: func() Policies {
+				r := mustParse(t, bucketPolicy)
+				return Policies{Identity: []Policy{mustParse(t, readAll)}, Resource: &r}
+			},
+			want: Allowed,
+		},
+		{
+			name: "resource policy alone allows in the same account",
+			req:  Request{Principal: "arn:aws:iam::111111111111:user/alice", Action: "s3:GetObject", Resource: bucket, ResourceAccount: "111111111111", Context: map[string][]string{"aws:PrincipalOrgID": {"o-abc"}}},
+			policies: func() Policies {
+				r := mustParse(t, orgPolicy)
+				return Policies{Resource: &r}
+			},
+			want: Allowed,
+		},
+		{
+			name: "organization condition rejects other organizations",
+			req:  Request{Principal: "arn:aws:iam::111111111111:user/alice", Action: "s3:GetObject", Resource: bucket, ResourceAccount: "111111111111", Context: map[string][]string{"aws:PrincipalOrgID": {"o-other"}}},
+			policies: func() Policies {
+				r := mustParse(t, orgPolicy)
+				return Policies{Resource: &r}
+			},
+			want: ImplicitDeny,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Evaluate(c.req, c.policies()); got != c.want {
+				t.Errorf("Evaluate() = %s, want %s", got, c.want)
+			}
+		})
+	}
+}
+
+func TestAllowsPrincipals(t *testing.T) {
+	p := mustParse(t, `{"Version":"2012-10-17","Statement":[
+		{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::111111111111:role/app"},"Action":"sqs:SendMessage","Resource":"*"},
+		{"Effect":"Allow","Principal":{"Service":"sns.amazonaws.com"},"Action":"sqs:SendMessage","Resource":"*","Condition":{"ArnLike":{"aws:SourceArn":"arn:aws:sns:*:111111111111:*"}}}
+	]}`)
+
+	cases := []struct {
+		name string
+		req  Request
+		want bool
+	}{
+		{"role", Request{Principal: "arn:aws:iam::111111111111:role/app", Action: "sqs:SendMessage", Resource: "arn:aws:sqs:us-east-1:111111111111:q"}, true},
+		{"role session", Request{Principal: "arn:aws:sts::111111111111:assumed-role/app/session", Action: "sqs:SendMessage", Resource: "arn:aws:sqs:us-east-1:111111111111:q"}, true},
+		{"other role", Request{Principal: "arn:aws:iam::111111111111:role/other", Action: "sqs:SendMessage", Resource: "arn:aws:sqs:us-east-1:111111111111:q"}, false},
+		{"service with source", Request{Principal: "sns.amazonaws.com", Action: "sqs:SendMessage", Resource: "arn:aws:sqs:us-east-1:111111111111:q", Context: map[string][]string{"aws:SourceArn": {"arn:aws:sns:us-east-1:111111111111:topic"}}}, true},
+		{"service without source", Request{Principal: "sns.amazonaws.com", Action: "sqs:SendMessage", Resource: "arn:aws:sqs:us-east-1:111111111111:q"}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Allows(c.req, p); got != c.want {
+				t.Errorf("Allows() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestConditionOperators(t *testing.T) {
+	context := map[string][]string{
+		"aws:sourcevpce":      {"vpce-123"},
+		"aws:tagkeys":         {"env", "team"},
+		"aws:currenttime":     {"2024-06-01T00:00:00Z"},
+		"s3:max-keys":         {"10"},
+		"aws:securetransport": {"true"},
+	}
+
+	cases := []struct {
+		operator string
+		key      string
+		values   []string
+		want     bool
+	}{
+		{"StringEquals", "aws:sourcevpce", []string{"vpce-123"}, true},
+		{"StringNotEquals", "aws:sourcevpce", []string{"vpce-123"}, false},
+		{"StringNotEquals", "aws:missing", []string{"x"}, true},
+		{"StringEquals", "aws:missing", []string{"x"}, false},
+		{"StringEqualsIfExists", "aws:missing", []string{"x"}, true},
+		{"StringLike", "aws:sourcevpce", []string{"vpce-*"}, true},
+		{"ForAllValues:StringEquals", "aws:tagkeys", []string{"env", "team", "owner"}, true},
+		{"ForAllValues:StringEquals", "aws:tagkeys", []string{"env"}, false},
+		{"ForAnyValue:StringEquals", "aws:tagkeys", []string{"team"}, true},
+		{"ForAnyValue:StringEquals", "aws:missing", []string{"team"}, false},
+		{"NumericLessThanEquals", "s3:max-keys", []string{"10"}, true},
+		{"NumericGreaterThan", "s3:max-keys", []string{"10"}, false},
+		{"DateGreaterThan", "aws:currenttime", []string{"2024-01-01T00:00:00Z"}, true},
+		{"Bool", "aws:securetransport", []string{"false"}, false},
+		{"Null", "aws:sourcevpce", []string{"false"}, true},
+		{"Null", "aws:missing", []string{"true"}, true},
+		{"BinaryEquals", "aws:sourcevpce", []string{"dnBjZS0xMjM="}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.operator+" "+c.key, func(t *testing.T) {
+			conditions := map[string]interface{}{c.operator: map[string]interface{}{c.key: c.values}}
+			if got := conditionsMatch(conditions, context); got != c.want {
+				t.Errorf("conditionsMatch() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestIsPublic(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy string
+		want   bool
+	}{
+		{"wildcard principal", `{"Statement":{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*"}}`, true},
+		{"wildcard aws principal", `{"Statement":{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":"sqs:*","Resource":"*"}}`, true},
+		{"restricted to organization", `{"Statement":{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","Condition":{"StringEquals":{"aws:PrincipalOrgID":"o-abc"}}}}`, false},
+		{"restricted to vpc endpoint", `{"Statement":{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","Condition":{"StringEquals":{"aws:SourceVpce":"vpce-1"}}}}`, false},
+		{"condition on everyone", `{"Statement":{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","Condition":{"IpAddress":{"aws:SourceIp":"0.0.0.0/0"}}}}`, true},
+		{"deny everyone", `{"Statement":{"Effect":"Deny","Principal":"*","Action":"s3:*","Resource":"*"}}`, false},
+		{"single account", `{"Statement":{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::111111111111:root"},"Action":"s3:*","Resource":"*"}}`, false},
+		{"not principal", `{"Statement":{"Effect":"Allow","NotPrincipal":{"AWS":"arn:aws:iam::111111111111:root"},"Action":"s3:*","Resource":"*"}}`, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := IsPublic(mustParse(t, c.policy)); got != c.want {
+				t.Errorf("IsPublic() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestCrossAccountPrincipals(t *testing.T) {
+	p := mustParse(t, `{"Statement":[
+		{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::111111111111:role/own","arn:aws:iam::222222222222:root","333333333333"]},"Action":"kms:Decrypt","Resource":"*"},
+		{"Effect":"Allow","Principal":{"Service":"logs.amazonaws.com"},"Action":"kms:Decrypt","Resource":"*"},
+		{"Effect":"Deny","Principal":{"AWS":"444444444444"},"Action":"kms:*","Resource":"*"}
+	]}`)
+
+	got := CrossAccountPrincipals(p, "111111111111")
+	want := []string{"333333333333", "arn:aws:iam::222222222222:root"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CrossAccountPrincipals() = %v, want %v", got, want)
+	}
+}
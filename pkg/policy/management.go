@@ -0,0 +1,200 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Inheritance operators of the Organizations management policies, tag and backup policies.
+const (
+	assignOperator        = "@@assign"
+	appendOperator        = "@@append"
+	removeOperator        = "@@remove"
+	allowedChildOperators = "@@operators_allowed_for_child_policies"
+	allOperators          = "@@all"
+	noOperators           = "@@none"
+)
+
+// managementNode is a key of the effective management policy, with the value the policies
+// assigned it so far and the operators the policies further down the tree may still use on it.
+type managementNode struct {
+	value    interface{}
+	hasValue bool
+	// allowed are the operators allowed at this level, nil allows every operator
+	allowed map[string]bool
+	// pending are the operators allowed from the next level on, set by the current level
+	pending  map[string]bool
+	children map[string]*managementNode
+}
+
+func newManagementNode(allowed map[string]bool) *managementNode {
+	return &managementNode{allowed: allowed, children: map[string]*managementNode{}}
+}
+
+// MergeManagementPolicies computes the effective management policy, a tag or a backup policy,
+// of an account following the Organizations inheritance rules. levels has one entry per level of
+// the organization, from the root down to the account, with the policy documents attached at
+// that level. @@assign replaces the inherited value, @@append and @@remove add values to and
+// remove values from it, and @@operators_allowed_for_child_policies limits the operators the
+// levels below may use on a key and everything beneath it.
+//
+// The effective policy is returned the way Organizations reports it, with every value under
+// @@assign, or an empty string when no policy applies.
+func MergeManagementPolicies(levels [][]string) (string, error) {
+	root := newManagementNode(nil)
+	empty := true
+	for _, documents := range levels {
+		for _, document := range documents {
+			var doc map[string]interface{}
+			if err := json.Unmarshal([]byte(document), &doc); err != nil {
+				return "", fmt.Errorf("parsing management policy: %w", err)
+			}
+			if err := root.apply(doc); err != nil {
+				return "", err
+			}
+			empty = false
+		}
+		root.promote()
+	}
+	if empty {
+		return "", nil
+	}
+
+	effective, _ := root.effective().(map[string]interface{})
+	if effective == nil {
+		effective = map[string]interface{}{}
+	}
+	b, err := json.Marshal(effective)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (n *managementNode) apply(doc map[string]interface{}) error {
+	for key, value := range doc {
+		if !strings.HasPrefix(key, "@@") {
+			child, ok := n.children[key]
+			if !ok {
+				child = newManagementNode(n.allowed)
+				n.children[key] = child
+			}
+			childDoc, ok := value.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("management policy key %s is not an object", key)
+			}
+			if err := child.apply(childDoc); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch key {
+		case assignOperator:
+			if n.permits(key) {
+				n.value, n.hasValue = value, true
+			}
+		case appendOperator:
+			if n.permits(key) {
+				n.value, n.hasValue = appendValues(n.value, value), true
+			}
+		case removeOperator:
+			if n.permits(key) && n.hasValue {
+				n.value = removeValues(n.value, value)
+			}
+		case allowedChildOperators:
+			operators := map[string]bool{}
+			for _, op := range conditionValues(value) {
+				operators[strings.ToLower(op)] = true
+			}
+			if operators[allOperators] {
+				continue
+			}
+			if operators[noOperators] {
+				operators = map[string]bool{}
+			}
+			n.pending = intersectOperators(n.pending, operators)
+		}
+	}
+	return nil
+}
+
+func (n *managementNode) permits(operator string) bool {
+	return n.allowed == nil || n.allowed[operator]
+}
+
+// promote makes the operator restrictions set by a level apply to the levels below it, on the
+// key and on every key beneath it.
+func (n *managementNode) promote() {
+	n.promoteWith(nil)
+}
+
+func (n *managementNode) promoteWith(inherited map[string]bool) {
+	n.allowed = intersectOperators(intersectOperators(n.allowed, inherited), n.pending)
+	n.pending = nil
+	for _, child := range n.children {
+		child.promoteWith(n.allowed)
+	}
+}
+
+// effective returns the effective policy of the node, nil when neither the node nor any key
+// beneath it has a value.
+func (n *managementNode) effective() interface{} {
+	result := map[string]interface{}{}
+	if n.hasValue {
+		result[assignOperator] = n.value
+	}
+	for key, child := range n.children {
+		if value := child.effective(); value != nil {
+			result[key] = value
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// intersectOperators intersects two sets of allowed operators where nil allows every operator.
+func intersectOperators(a, b map[string]bool) map[string]bool {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	result := map[string]bool{}
+	for op := range a {
+		if b[op] {
+			result[op] = true
+		}
+	}
+	return result
+}
+
+func appendValues(current, values interface{}) interface{} {
+	var result []string
+	seen := map[string]bool{}
+	for _, v := range append(conditionValues(current), conditionValues(values)...) {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func removeValues(current, values interface{}) interface{} {
+	remove := map[string]bool{}
+	for _, v := range conditionValues(values) {
+		remove[v] = true
+	}
+	result := []string{}
+	for _, v := range conditionValues(current) {
+		if !remove[v] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
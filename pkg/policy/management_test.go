@@ -0,0 +1,88 @@
+package policy
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMergeManagementPolicies(t *testing.T) {
+	rootTags := `{"tags":{"costcenter":{"tag_key":{"@@assign":"CostCenter"},"tag_value":{"@@assign":["100","200"]}}}}`
+	appendValue := `{"tags":{"costcenter":{"tag_value":{"@@append":["300"]}}}}`
+	removeValue := `{"tags":{"costcenter":{"tag_value":{"@@remove":["100"]}}}}`
+	lockValues := `{"tags":{"costcenter":{"tag_value":{"@@operators_allowed_for_child_policies":["@@none"]}}}}`
+	onlyAppend := `{"tags":{"@@operators_allowed_for_child_policies":["@@append"]}}`
+	reassign := `{"tags":{"costcenter":{"tag_value":{"@@assign":["900"]}}}}`
+	newTag := `{"tags":{"project":{"tag_key":{"@@assign":"Project"}}}}`
+
+	cases := []struct {
+		name   string
+		levels [][]string
+		want   string
+	}{
+		{
+			name:   "no policy",
+			levels: [][]string{nil, nil},
+			want:   "",
+		},
+		{
+			name:   "inherited from the root",
+			levels: [][]string{{rootTags}, nil},
+			want:   `{"tags":{"costcenter":{"tag_key":{"@@assign":"CostCenter"},"tag_value":{"@@assign":["100","200"]}}}}`,
+		},
+		{
+			name:   "child appends and removes",
+			levels: [][]string{{rootTags}, {appendValue}, {removeValue}},
+			want:   `{"tags":{"costcenter":{"tag_key":{"@@assign":"CostCenter"},"tag_value":{"@@assign":["200","300"]}}}}`,
+		},
+		{
+			name:   "child assign replaces",
+			levels: [][]string{{rootTags}, {reassign, newTag}},
+			want:   `{"tags":{"costcenter":{"tag_key":{"@@assign":"CostCenter"},"tag_value":{"@@assign":["900"]}},"project":{"tag_key":{"@@assign":"Project"}}}}`,
+		},
+		{
+			name:   "locked value ignores the levels below",
+			levels: [][]string{{rootTags, lockValues}, {reassign}, {appendValue}},
+			want:   `{"tags":{"costcenter":{"tag_key":{"@@assign":"CostCenter"},"tag_value":{"@@assign":["100","200"]}}}}`,
+		},
+		{
+			name:   "restriction applies beneath the key",
+			levels: [][]string{{rootTags, onlyAppend}, {reassign, appendValue}},
+			want:   `{"tags":{"costcenter":{"tag_key":{"@@assign":"CostCenter"},"tag_value":{"@@assign":["100","200","300"]}}}}`,
+		},
+		{
+			name:   "restriction doesn't apply to the same level",
+			levels: [][]string{{lockValues, rootTags, reassign}},
+			want:   `{"tags":{"costcenter":{"tag_key":{"@@assign":"CostCenter"},"tag_value":{"@@assign":["900"]}}}}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := MergeManagementPolicies(c.levels)
+			if err != nil {
+				t.Fatalf("MergeManagementPolicies() error = %v", err)
+			}
+			if c.want == "" || got == "" {
+				if got != c.want {
+					t.Errorf("MergeManagementPolicies() = %q, want %q", got, c.want)
+				}
+				return
+			}
+			var gotDoc, wantDoc interface{}
+			if err := json.Unmarshal([]byte(got), &gotDoc); err != nil {
+				t.Fatalf("effective policy %s is not json: %v", got, err)
+			}
+			if err := json.Unmarshal([]byte(c.want), &wantDoc); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(gotDoc, wantDoc) {
+				t.Errorf("MergeManagementPolicies() = %s, want %s", got, c.want)
+			}
+		})
+	}
+
+	if _, err := MergeManagementPolicies([][]string{{"not json"}}); err == nil {
+		t.Errorf("invalid policy didn't fail")
+	}
+}
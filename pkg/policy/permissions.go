@@ -0,0 +1,212 @@
+package policy
+
+import (
+	_ "embed"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+//go:generate go run ./catalog/gen --out catalog
+
+// actionCatalogJSON lists the actions of every service, service -> action names, generated from
+// the Service Authorization Reference. Wildcards in policies are expanded against it, so an
+// action missing from it is never reported as allowed.
+//
+//go:embed catalog/actions.json
+var actionCatalogJSON []byte
+
+// actionCatalog is the sorted list of the catalog actions in service:Action form.
+var actionCatalog = loadActionCatalog()
+
+func loadActionCatalog() []string {
+	var services map[string][]string
+	if err := json.Unmarshal(actionCatalogJSON, &services); err != nil {
+		panic("policy: invalid action catalog: " + err.Error())
+	}
+	var actions []string
+	for service, names := range services {
+		for _, name := range names {
+			actions = append(actions, service+":"+name)
+		}
+	}
+	sort.Strings(actions)
+	return actions
+}
+
+// Actions returns every action of the bundled action catalog, sorted.
+func Actions() []string {
+	return append([]string(nil), actionCatalog...)
+}
+
+// ExpandActions returns the catalog actions matching any of the patterns, e.g. s3:Get* or *,
+// sorted. Patterns are case insensitive.
+func ExpandActions(patterns []string) []string {
+	var actions []string
+	for _, action := range actionCatalog {
+		for _, pattern := range patterns {
+			if WildcardMatch(strings.ToLower(pattern), strings.ToLower(action)) {
+				actions = append(actions, action)
+				break
+			}
+		}
+	}
+	return actions
+}
+
+// EscalationPath is a known way for a principal to raise its own privileges, it is open when
+// the principal is allowed every one of its actions.
+type EscalationPath struct {
+	Name    string
+	Actions []string
+}
+
+// escalationPaths are the well known IAM privilege escalation paths.
+var escalationPaths = []EscalationPath{
+	{Name: "CreatePolicyVersion", Actions: []string{"iam:CreatePolicyVersion"}},
+	{Name: "SetDefaultPolicyVersion", Actions: []string{"iam:SetDefaultPolicyVersion"}},
+	{Name: "CreateAccessKey", Actions: []string{"iam:CreateAccessKey"}},
+	{Name: "CreateLoginProfile", Actions: []string{"iam:CreateLoginProfile"}},
+	{Name: "UpdateLoginProfile", Actions: []string{"iam:UpdateLoginProfile"}},
+	{Name: "AttachUserPolicy", Actions: []string{"iam:AttachUserPolicy"}},
+	{Name: "AttachGroupPolicy", Actions: []string{"iam:AttachGroupPolicy"}},
+	{Name: "AttachRolePolicy", Actions: []string{"iam:AttachRolePolicy"}},
+	{Name: "PutUserPolicy", Actions: []string{"iam:PutUserPolicy"}},
+	{Name: "PutGroupPolicy", Actions: []string{"iam:PutGroupPolicy"}},
+	{Name: "PutRolePolicy", Actions: []string{"iam:PutRolePolicy"}},
+	{Name: "AddUserToGroup", Actions: []string{"iam:AddUserToGroup"}},
+	{Name: "UpdateAssumeRolePolicy", Actions: []string{"iam:UpdateAssumeRolePolicy", "sts:AssumeRole"}},
+	{Name: "DeleteRolePermissionsBoundary", Actions: []string{"iam:DeleteRolePermissionsBoundary"}},
+	{Name: "DeleteUserPermissionsBoundary", Actions: []string{"iam:DeleteUserPermissionsBoundary"}},
+	{Name: "PassRoleToEC2", Actions: []string{"iam:PassRole", "ec2:RunInstances"}},
+	{Name: "PassRoleToLambda", Actions: []string{"iam:PassRole", "lambda:CreateFunction", "lambda:InvokeFunction"}},
+	{Name: "PassRoleToLambdaEventSource", Actions: []string{"iam:PassRole", "lambda:CreateFunction", "lambda:CreateEventSourceMapping"}},
+	{Name: "UpdateLambdaFunctionCode", Actions: []string{"lambda:UpdateFunctionCode"}},
+	{Name: "PassRoleToGlueDevEndpoint", Actions: []string{"iam:PassRole", "glue:CreateDevEndpoint"}},
+	{Name: "UpdateGlueDevEndpoint", Actions: []string{"glue:UpdateDevEndpoint"}},
+	{Name: "PassRoleToCloudFormation", Actions: []string{"iam:PassRole", "cloudformation:CreateStack"}},
+	{Name: "PassRoleToDataPipeline", Actions: []string{"iam:PassRole", "datapipeline:CreatePipeline", "datapipeline:PutPipelineDefinition"}},
+	{Name: "PassRoleToCodeBuild", Actions: []string{"iam:PassRole", "codebuild:CreateProject", "codebuild:StartBuild"}},
+	{Name: "PassRoleToSageMakerNotebook", Actions: []string{"iam:PassRole", "sagemaker:CreateNotebookInstance", "sagemaker:CreatePresignedNotebookInstanceUrl"}},
+	{Name: "SSMSendCommand", Actions: []string{"ssm:SendCommand"}},
+	{Name: "SSMStartSession", Actions: []string{"ssm:StartSession"}},
+	{Name: "EC2InstanceConnect", Actions: []string{"ec2-instance-connect:SendSSHPublicKey"}},
+}
+
+// EffectivePermissions is what a principal can do, as far as its own policies tell.
+type EffectivePermissions struct {
+	// AllowedActions are the catalog actions the principal is allowed on at least some resource,
+	// sorted.
+	AllowedActions []string
+	// IsAdminEquivalent is set when the principal is allowed every action on every resource, or
+	// every IAM action, with which it can grant itself anything.
+	IsAdminEquivalent bool
+	// PrivilegeEscalationPaths are the escalation paths the allowed actions open.
+	PrivilegeEscalationPaths []EscalationPath
+}
+
+// Effective computes the effective permissions of a principal from its identity policies, the
+// inline and managed policies of the principal and of its groups, and its permissions boundary,
+// nil if it has none. An action is allowed when an identity policy allows it, the boundary
+// allows it too, and no Deny statement without conditions denies it on every resource.
+// Conditions of Allow statements aren't evaluated, the action is allowed in some context.
+func Effective(identity []Policy, boundary *Policy) EffectivePermissions {
+	var result EffectivePermissions
+
+	unrestricted := map[string]bool{}
+	for _, action := range actionCatalog {
+		if deniedEverywhere(identity, boundary, action) {
+			continue
+		}
+		if !allowsAction(identity, action, false) {
+			continue
+		}
+		if boundary != nil && !allowsAction([]Policy{*boundary}, action, false) {
+			continue
+		}
+		result.AllowedActions = append(result.AllowedActions, action)
+
+		if allowsAction(identity, action, true) && (boundary == nil || allowsAction([]Policy{*boundary}, action, true)) {
+			unrestricted[action] = true
+		}
+	}
+
+	allActions, allIAMActions := true, true
+	for _, action := range actionCatalog {
+		if unrestricted[action] {
+			continue
+		}
+		allActions = false
+		if strings.HasPrefix(action, "iam:") {
+			allIAMActions = false
+		}
+	}
+	result.IsAdminEquivalent = allActions || allIAMActions
+
+	allowed := make(map[string]bool, len(result.AllowedActions))
+	for _, action := range result.AllowedActions {
+		allowed[action] = true
+	}
+	for _, path := range escalationPaths {
+		open := true
+		for _, action := range path.Actions {
+			if !allowed[action] {
+				open = false
+				break
+			}
+		}
+		if open {
+			result.PrivilegeEscalationPaths = append(result.PrivilegeEscalationPaths, path)
+		}
+	}
+	return result
+}
+
+// allowsAction reports whether an Allow statement of the policies matches the action. With
+// unrestricted only the statements on every resource and without conditions count.
+func allowsAction(policies []Policy, action string, unrestricted bool) bool {
+	for _, p := range policies {
+		for _, s := range p.Statements {
+			if !strings.EqualFold(s.Effect, "Allow") || !statementActionMatches(s, action) {
+				continue
+			}
+			if unrestricted && (len(s.Condition) > 0 || !coversEveryResource(s)) {
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// deniedEverywhere reports whether a Deny statement without conditions denies the action on
+// every resource.
+func deniedEverywhere(identity []Policy, boundary *Policy, action string) bool {
+	policies := identity
+	if boundary != nil {
+		policies = append(append([]Policy(nil), identity...), *boundary)
+	}
+	for _, p := range policies {
+		for _, s := range p.Statements {
+			if !strings.EqualFold(s.Effect, "Deny") || len(s.Condition) > 0 {
+				continue
+			}
+			if statementActionMatches(s, action) && coversEveryResource(s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func coversEveryResource(s Statement) bool {
+	if len(s.NotResource) > 0 {
+		return false
+	}
+	for _, resource := range s.Resource {
+		if resource == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestExpandActions(t *testing.T) {
+	got := ExpandActions([]string{"sts:Assume*", "IAM:passrole"})
+	want := []string{"iam:PassRole", "sts:AssumeRole", "sts:AssumeRoleWithSAML", "sts:AssumeRoleWithWebIdentity"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExpandActions() = %v, want %v", got, want)
+	}
+	if got := ExpandActions([]string{"*"}); len(got) != len(Actions()) {
+		t.Errorf("* expanded to %d actions, the catalog has %d", len(got), len(Actions()))
+	}
+}
+
+func TestEffective(t *testing.T) {
+	admin := `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"*","Resource":"*"}}`
+	iamFull := `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"iam:*","Resource":"*"}}`
+	readOnly := `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":["s3:Get*","s3:List*"],"Resource":"*"}}`
+	passRole := `{"Version":"2012-10-17","Statement":[
+		{"Effect":"Allow","Action":"iam:PassRole","Resource":"arn:aws:iam::111111111111:role/app"},
+		{"Effect":"Allow","Action":"ec2:RunInstances","Resource":"*"}]}`
+	denyIAM := `{"Version":"2012-10-17","Statement":{"Effect":"Deny","Action":"iam:*","Resource":"*"}}`
+	conditionalDeny := `{"Version":"2012-10-17","Statement":{"Effect":"Deny","Action":"*","Resource":"*","Condition":{"Bool":{"aws:MultiFactorAuthPresent":"false"}}}}`
+	boundaryS3 := `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"s3:*","Resource":"*"}}`
+
+	t.Run("admin", func(t *testing.T) {
+		got := Effective([]Policy{mustParse(t, admin)}, nil)
+		if !got.IsAdminEquivalent {
+			t.Errorf("* on * is not admin equivalent")
+		}
+		if len(got.AllowedActions) != len(Actions()) {
+			t.Errorf("allowed %d actions, want the whole catalog", len(got.AllowedActions))
+		}
+		if len(got.PrivilegeEscalationPaths) != len(escalationPaths) {
+			t.Errorf("got %d escalation paths, want all of them", len(got.PrivilegeEscalationPaths))
+		}
+	})
+
+	t.Run("iam full access", func(t *testing.T) {
+		got := Effective([]Policy{mustParse(t, iamFull)}, nil)
+		if !got.IsAdminEquivalent {
+			t.Errorf("iam:* on * is not admin equivalent")
+		}
+	})
+
+	t.Run("read only", func(t *testing.T) {
+		got := Effective([]Policy{mustParse(t, readOnly)}, nil)
+		if got.IsAdminEquivalent {
+			t.Errorf("read only is admin equivalent")
+		}
+		if len(got.PrivilegeEscalationPaths) != 0 {
+			t.Errorf("read only opens escalation paths %v", got.PrivilegeEscalationPaths)
+		}
+		for _, action := range got.AllowedActions {
+			if action != "s3:ListAllMyBuckets" && action[:6] != "s3:Get" && action[:7] != "s3:List" {
+				t.Errorf("unexpected allowed action %s", action)
+			}
+		}
+	})
+
+	t.Run("pass role to ec2", func(t *testing.T) {
+		got := Effective([]Policy{mustParse(t, passRole)}, nil)
+		want := []EscalationPath{{Name: "PassRoleToEC2", Actions: []string{"iam:PassRole", "ec2:RunInstances"}}}
+		if !reflect.DeepEqual(got.PrivilegeEscalationPaths, want) {
+			t.Errorf("escalation paths = %v, want %v", got.PrivilegeEscalationPaths, want)
+		}
+	})
+
+	t.Run("deny removes actions", func(t *testing.T) {
+		got := Effective([]Policy{mustParse(t, admin), mustParse(t, denyIAM)}, nil)
+		if got.IsAdminEquivalent {
+			t.Errorf("admin without iam is admin equivalent")
+		}
+		for _, action := range got.AllowedActions {
+			if action[:4] == "iam:" {
+				t.Fatalf("denied action %s is allowed", action)
+			}
+		}
+	})
+
+	t.Run("conditional deny keeps actions", func(t *testing.T) {
+		got := Effective([]Policy{mustParse(t, admin), mustParse(t, conditionalDeny)}, nil)
+		if len(got.AllowedActions) != len(Actions()) {
+			t.Errorf("allowed %d actions, want the whole catalog", len(got.AllowedActions))
+		}
+	})
+
+	t.Run("permissions boundary", func(t *testing.T) {
+		boundary := mustParse(t, boundaryS3)
+		got := Effective([]Policy{mustParse(t, admin)}, &boundary)
+		if got.IsAdminEquivalent {
+			t.Errorf("admin bounded to s3 is admin equivalent")
+		}
+		want := ExpandActions([]string{"s3:*"})
+		if !reflect.DeepEqual(got.AllowedActions, want) {
+			t.Errorf("allowed actions = %v, want %v", got.AllowedActions, want)
+		}
+	})
+}
+
+// TestActionCatalogMatchesReference checks the catalog against the action counts of the Service
+// Authorization Reference recorded when it was generated, so a partial or hand edited catalog
+// fails.
+func TestActionCatalogMatchesReference(t *testing.T) {
+	content, err := os.ReadFile("catalog/reference.json")
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Skip("catalog/reference.json is missing, run make update-action-catalog")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reference struct {
+		Services map[string]int `json:"services"`
+	}
+	if err := json.Unmarshal(content, &reference); err != nil {
+		t.Fatal(err)
+	}
+
+	var catalog map[string][]string
+	if err := json.Unmarshal(actionCatalogJSON, &catalog); err != nil {
+		t.Fatal(err)
+	}
+	for service, count := range reference.Services {
+		if got := len(catalog[service]); got != count {
+			t.Errorf("%s has %d actions in the catalog, the reference lists %d", service, got, count)
+		}
+	}
+	for service := range catalog {
+		if _, ok := reference.Services[service]; !ok {
+			t.Errorf("%s is in the catalog but not in the reference", service)
+		}
+	}
+}
@@ -63,3 +63,17 @@ func ExtractTagsAndNames(logger *zap.Logger, plg *plugin.Plugin, resourceType st
 	}
 	return steampipe.ExtractTagsAndNames(plg, logger, pluginTableName, resourceType, source, AWSDescriptionMap)
 }
+
+// AWSResourceToRecord converts a resource document, as sent to the sink, to the columns of the
+// table of its resource type.
+func AWSResourceToRecord(logger *zap.Logger, plg *plugin.Plugin, resourceType string, source interface{}) (map[string]*proto.Column, error) {
+	pluginTableName := ExtractTableName(resourceType)
+	if pluginTableName == "" {
+		return nil, fmt.Errorf("cannot find table name for resourceType: %s", resourceType)
+	}
+	desc, err := steampipe.ConvertToDescription(logger, resourceType, source, AWSDescriptionMap)
+	if err != nil {
+		return nil, err
+	}
+	return steampipe.DescriptionToRecord(logger, plg, desc, pluginTableName)
+}
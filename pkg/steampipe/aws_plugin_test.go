@@ -0,0 +1,101 @@
+package steampipe
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	awsdescriber "github.com/opengovern/og-aws-describer/aws"
+	"github.com/opengovern/og-util/pkg/es"
+)
+
+const opengovernanceSDKPackage = "github.com/opengovern/og-aws-describer/pkg/opengovernance-es-sdk."
+
+// indexBackedTables are the tables that must be answered from the index their resource type is
+// described into, rather than by calling aws.
+var indexBackedTables = []string{
+	"aws_auditmanager_assessment",
+	"aws_auditmanager_control",
+	"aws_auditmanager_evidence",
+	"aws_auditmanager_evidence_folder",
+	"aws_auditmanager_framework",
+	"aws_backup_resource_coverage",
+	"aws_ec2_network_exposure",
+	"aws_iam_effective_permission",
+	"aws_identitystore_group",
+	"aws_identitystore_group_membership",
+	"aws_identitystore_user",
+	"aws_organizations_account_effective_policy",
+	"aws_rds_db_cluster",
+	"aws_rds_db_cluster_parameter_group",
+	"aws_rds_db_cluster_snapshot",
+	"aws_rds_db_engine_version",
+	"aws_rds_db_event_subscription",
+	"aws_rds_db_instance",
+	"aws_rds_db_instance_automated_backup",
+	"aws_rds_db_option_group",
+	"aws_rds_db_parameter_group",
+	"aws_rds_db_proxy",
+	"aws_rds_db_recommendation",
+	"aws_rds_db_snapshot",
+	"aws_rds_db_subnet_group",
+	"aws_rds_global_cluster",
+	"aws_rds_reserved_db_instance",
+	"aws_ssoadmin_account_assignment",
+	"aws_ssoadmin_instance",
+	"aws_ssoadmin_managed_policy_attachment",
+	"aws_ssoadmin_permission_set",
+	"aws_user_effective_access",
+}
+
+func TestIndexBackedTables(t *testing.T) {
+	plg := Plugin()
+
+	for _, tableName := range indexBackedTables {
+		t.Run(tableName, func(t *testing.T) {
+			resourceType, ok := AWSReverseMap[tableName]
+			if !ok {
+				t.Fatalf("table is not mapped to a resource type")
+			}
+
+			rt, err := awsdescriber.GetResourceType(resourceType)
+			if err != nil {
+				t.Fatalf("resource type %s is not discovered: %v", resourceType, err)
+			}
+			if rt.ListDescriber == nil {
+				t.Errorf("resource type %s has no list describer", resourceType)
+			}
+
+			index := es.ResourceTypeToESIndex(resourceType)
+			if _, err := os.Stat(filepath.Join("..", "opengovernance-es-sdk", "index_templates", index+".json")); err != nil {
+				t.Errorf("index %s has no index template: %v", index, err)
+			}
+
+			table, ok := plg.TableMap[tableName]
+			if !ok {
+				t.Fatalf("table is not registered in the plugin")
+			}
+			if table.List == nil {
+				t.Fatalf("table has no list config")
+			}
+			if name := funcName(table.List.Hydrate); !strings.HasPrefix(name, opengovernanceSDKPackage+"List") {
+				t.Errorf("list hydrate %s does not read the index", name)
+			}
+			if table.Get != nil {
+				if name := funcName(table.Get.Hydrate); !strings.HasPrefix(name, opengovernanceSDKPackage+"Get") {
+					t.Errorf("get hydrate %s does not read the index", name)
+				}
+			}
+			if table.GetMatrixItemFunc != nil {
+				t.Errorf("table has a region matrix, every region would list the whole index")
+			}
+		})
+	}
+}
+
+func funcName(f any) string {
+	return runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+}
@@ -0,0 +1,430 @@
+package steampipe
+
+import (
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/opengovern/og-util/pkg/steampipe"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// knownColumnProblems lists the columns that already did not resolve when the contract test
+// was added, one "<resource type> <column>: <problem>" per line. Columns fixed are removed from
+// it and new problems fail the test. It is rewritten with -update, by a run of every resource
+// type: go test ./pkg/steampipe -run TestDescriptionColumns -update.
+const knownColumnProblems = "testdata/description_columns.txt"
+
+var update = flag.Bool("update", false, "rewrite "+knownColumnProblems+" with the problems found")
+
+var sampleTime = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+const (
+	// sampleARN has enough resource segments for the transforms splitting ARNs of nested
+	// resources, listeners or tasks for instance.
+	sampleARN    = "arn:aws:sample:us-east-1:123456789012:sample/sample/sample/sample/sample"
+	sampleURL    = "https://sample.us-east-1.amazonaws.com/123456789012/sample"
+	samplePolicy = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"sample:Get","Resource":"*"}]}`
+)
+
+// sampleFields are the values of string fields the transforms of their column parse in a
+// format the sample cannot tell from the field name or the column type.
+var sampleFields = map[string]map[string]string{
+	// The policy of a rest api is a JSON string without its quotes.
+	"AWS::ApiGateway::RestApi": {"Description.RestAPI.Policy": strings.Trim(strconv.Quote(samplePolicy), `"`)},
+	"AWS::S3::Bucket":          {"Description.LifecycleRules": "[]"},
+}
+
+// TestDescriptionColumns builds a populated sample of the description model of every resource
+// type, converts it to the columns of its table with AWSDescriptionToRecord and reports every
+// column whose field path does not resolve in the model, resolves to a NULL column, or whose
+// transforms fail on the sample.
+func TestDescriptionColumns(t *testing.T) {
+	plg := Plugin()
+
+	resourceTypes := make([]string, 0, len(AWSDescriptionMap))
+	for resourceType := range AWSDescriptionMap {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+
+	known := map[string]bool{}
+	if content, err := os.ReadFile(knownColumnProblems); err == nil {
+		for _, line := range strings.Split(string(content), "\n") {
+			if line != "" {
+				known[line] = true
+			}
+		}
+	} else if !*update {
+		t.Fatalf("failed to read %s: %v", knownColumnProblems, err)
+	}
+
+	var all []string
+	for _, resourceType := range resourceTypes {
+		t.Run(resourceType, func(t *testing.T) {
+			tableName := ExtractTableName(resourceType)
+			table, ok := plg.TableMap[tableName]
+			if !ok {
+				t.Skipf("table %s is not registered in the plugin", tableName)
+			}
+
+			problems := describeColumnProblems(plg, table, resourceType)
+			all = append(all, problems...)
+			if *update {
+				return
+			}
+
+			reported := map[string]bool{}
+			for _, problem := range problems {
+				reported[problem] = true
+				if !known[problem] {
+					t.Error(problem)
+				}
+			}
+			for problem := range known {
+				if strings.HasPrefix(problem, resourceType+" ") && !reported[problem] {
+					t.Errorf("%s is fixed, remove it from %s", problem, knownColumnProblems)
+				}
+			}
+		})
+	}
+
+	// A run limited to some resource types would drop the problems of the others.
+	if *update && !strings.Contains(flag.Lookup("test.run").Value.String(), "/") {
+		sort.Strings(all)
+		content := strings.Join(all, "\n")
+		if content != "" {
+			content += "\n"
+		}
+		if err := os.MkdirAll(filepath.Dir(knownColumnProblems), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(knownColumnProblems, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// describeColumnProblems converts a sample of the description of resourceType to the columns of
+// table and returns the problems found, one per column.
+func describeColumnProblems(plg *plugin.Plugin, table *plugin.Table, resourceType string) []string {
+	var problems []string
+	report := func(column *plugin.Column, format string, args ...any) {
+		problems = append(problems, fmt.Sprintf("%s %s: %s", resourceType, column.Name, fmt.Sprintf(format, args...)))
+	}
+
+	var columns []*plugin.Column
+	for _, column := range table.Columns {
+		if column != nil && column.Transform != nil {
+			columns = append(columns, column)
+		}
+	}
+
+	v := newSample(reflect.TypeOf(AWSDescriptionMap[resourceType]))
+	for _, column := range columns {
+		value := sampleString(column)
+		for _, path := range fieldPaths(column) {
+			addSampleKeys(v, path, value)
+			if value != "sample" {
+				setSampleString(v, path, value)
+			}
+		}
+	}
+	for path, value := range sampleFields[resourceType] {
+		if field, ok := stringField(v, path); ok {
+			field.SetString(value)
+		}
+	}
+	sample := v.Interface()
+
+	record, err := AWSDescriptionToRecord(nil, sample, table.Name)
+	if err != nil {
+		// The conversion stops at the first column failing, convert the columns one by one to
+		// report all of them.
+		record = map[string]*proto.Column{}
+		for _, column := range columns {
+			single := &plugin.Plugin{TableMap: map[string]*plugin.Table{
+				table.Name: {Name: table.Name, Columns: []*plugin.Column{column}},
+			}}
+			cells, err := steampipe.DescriptionToRecord(nil, single, sample, table.Name)
+			if err != nil {
+				report(column, "%v", err)
+				continue
+			}
+			record[column.Name] = cells[column.Name]
+		}
+	}
+
+	for _, column := range columns {
+		paths := fieldPaths(column)
+		if len(paths) == 0 {
+			continue
+		}
+		resolved := false
+		for _, path := range paths {
+			if _, ok := lookupFieldPath(v, path); ok {
+				resolved = true
+			}
+		}
+		c, converted := record[column.Name]
+		switch {
+		case !resolved:
+			report(column, "%s does not resolve in %T", strings.Join(paths, ", "), sample)
+		case converted && isNullColumn(c):
+			report(column, "%s is NULL", strings.Join(paths, ", "))
+		}
+	}
+	return problems
+}
+
+// fieldPaths returns the field paths the transforms of column start from, the parameters of a
+// leading transform.FromField.
+func fieldPaths(column *plugin.Column) []string {
+	calls := column.Transform.Transforms
+	if len(calls) == 0 || funcName(calls[0].Transform) != funcName(transform.FieldValue) {
+		return nil
+	}
+	switch p := calls[0].Param.(type) {
+	case string:
+		return []string{p}
+	case []string:
+		return p
+	}
+	return nil
+}
+
+// lookupFieldPath returns the field path points at in v, resolved the way transform.FieldValue
+// resolves it. Paths going through a map or an interface resolve to that map or interface, their
+// keys are not known statically.
+func lookupFieldPath(v reflect.Value, path string) (reflect.Value, bool) {
+	for _, segment := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return v, true
+			}
+			v = v.Elem()
+		}
+		if v.Kind() == reflect.Map || v.Kind() == reflect.Interface {
+			return v, true
+		}
+		if v.Kind() != reflect.Struct {
+			return v, false
+		}
+
+		name, index := segment, -1
+		if i := strings.Index(segment, "["); i > 0 && strings.HasSuffix(segment, "]") {
+			n, err := strconv.Atoi(segment[i+1 : len(segment)-1])
+			if err != nil {
+				return v, false
+			}
+			name, index = segment[:i], n
+		}
+		field, ok := v.Type().FieldByName(name)
+		if !ok || !field.IsExported() {
+			return v, false
+		}
+		v = v.FieldByIndex(field.Index)
+		if index >= 0 {
+			if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+				return v, false
+			}
+			if index >= v.Len() {
+				return v, true
+			}
+			v = v.Index(index)
+		}
+	}
+	return v, true
+}
+
+// addSampleKeys adds the keys path looks up in the string keyed maps of v, attributes for
+// instance, with value or a sample of the map values. Interfaces path goes through are set to
+// nested maps ending with value.
+func addSampleKeys(v reflect.Value, path, value string) {
+	segments := strings.Split(path, ".")
+	if value == "sample" {
+		value = sampleFieldString(segments[len(segments)-1])
+	}
+	for i, segment := range segments {
+		m, ok := lookupFieldPath(v, strings.Join(segments[:i], "."))
+		for ok && m.Kind() == reflect.Pointer && !m.IsNil() {
+			m = m.Elem()
+		}
+		if ok && m.Kind() == reflect.Interface && m.CanSet() {
+			var nested any = value
+			for j := len(segments) - 1; j >= i; j-- {
+				nested = map[string]any{segments[j]: nested}
+			}
+			m.Set(reflect.ValueOf(nested))
+			return
+		}
+		if !ok || m.Kind() != reflect.Map || m.IsNil() || m.Type().Key().Kind() != reflect.String {
+			continue
+		}
+
+		key := reflect.ValueOf(segment).Convert(m.Type().Key())
+		if m.MapIndex(key).IsValid() {
+			return
+		}
+		var elem reflect.Value
+		switch {
+		case m.Type().Elem().Kind() == reflect.String:
+			elem = reflect.ValueOf(value).Convert(m.Type().Elem())
+		case m.Type().Elem().Kind() == reflect.Interface:
+			var nested any = value
+			for j := len(segments) - 1; j > i; j-- {
+				nested = map[string]any{segments[j]: nested}
+			}
+			elem = reflect.ValueOf(nested)
+		default:
+			elem = newSample(m.Type().Elem())
+		}
+		m.SetMapIndex(key, elem)
+		return
+	}
+}
+
+// setSampleString sets the string field path points at in v, if any and its name did not tell
+// what it holds.
+func setSampleString(v reflect.Value, path, value string) {
+	if field, ok := stringField(v, path); ok && field.String() == "sample" {
+		field.SetString(value)
+	}
+}
+
+// stringField returns the string field path points at in v.
+func stringField(v reflect.Value, path string) (reflect.Value, bool) {
+	field, ok := lookupFieldPath(v, path)
+	for ok && field.Kind() == reflect.Pointer && !field.IsNil() {
+		field = field.Elem()
+	}
+	return field, ok && field.Kind() == reflect.String && field.CanSet()
+}
+
+// sampleString returns a string the transforms of column accept, for the string field its path
+// points at.
+func sampleString(column *plugin.Column) string {
+	switch column.Type {
+	case proto.ColumnType_TIMESTAMP:
+		return sampleTime.Format(time.RFC3339)
+	case proto.ColumnType_IPADDR, proto.ColumnType_INET:
+		return "10.0.0.1"
+	case proto.ColumnType_CIDR:
+		return "10.0.0.0/16"
+	case proto.ColumnType_BOOL:
+		return "true"
+	case proto.ColumnType_INT, proto.ColumnType_DOUBLE:
+		return "1"
+	case proto.ColumnType_JSON:
+		// A JSON column read from a string field parses it, as a policy most of the time.
+		if len(column.Transform.Transforms) > 1 {
+			return samplePolicy
+		}
+	}
+	return "sample"
+}
+
+func isNullColumn(c *proto.Column) bool {
+	if c == nil {
+		return true
+	}
+	_, ok := c.Value.(*proto.Column_NullValue)
+	return ok
+}
+
+// newSample returns a value of t with every field set: strings, numbers and booleans are set to
+// a non zero value, slices and maps get one element and empty interfaces hold a string.
+// Recursive types stop at their first repetition. Strings are set from the name of their field
+// when it tells what they hold.
+func newSample(t reflect.Type) reflect.Value {
+	v := reflect.New(t).Elem()
+	fillSample(v, "", map[reflect.Type]bool{})
+	return v
+}
+
+func fillSample(v reflect.Value, name string, visiting map[reflect.Type]bool) {
+	t := v.Type()
+	if t == reflect.TypeOf(time.Time{}) {
+		v.Set(reflect.ValueOf(sampleTime))
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(sampleFieldString(name))
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1)
+	case reflect.Pointer:
+		if visiting[t.Elem()] {
+			return
+		}
+		p := reflect.New(t.Elem())
+		fillSample(p.Elem(), name, visiting)
+		v.Set(p)
+	case reflect.Slice:
+		if visiting[t.Elem()] {
+			return
+		}
+		s := reflect.MakeSlice(t, 1, 1)
+		fillSample(s.Index(0), name, visiting)
+		v.Set(s)
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			fillSample(v.Index(i), name, visiting)
+		}
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			v.Set(reflect.ValueOf(sampleFieldString(name)))
+		}
+	case reflect.Map:
+		key := reflect.New(t.Key()).Elem()
+		fillSample(key, "", visiting)
+		elem := reflect.New(t.Elem()).Elem()
+		if !visiting[t.Elem()] {
+			fillSample(elem, "", visiting)
+		}
+		m := reflect.MakeMapWithSize(t, 1)
+		m.SetMapIndex(key, elem)
+		v.Set(m)
+	case reflect.Struct:
+		visiting[t] = true
+		defer delete(visiting, t)
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				fillSample(v.Field(i), t.Field(i).Name, visiting)
+			}
+		}
+	}
+}
+
+func sampleFieldString(name string) string {
+	switch {
+	case strings.HasSuffix(name, "Arn") || strings.HasSuffix(name, "ARN"):
+		return sampleARN
+	case strings.HasSuffix(name, "Url") || strings.HasSuffix(name, "URL"):
+		return sampleURL
+	case strings.HasSuffix(name, "Policy") || strings.HasSuffix(name, "PolicyDocument"):
+		return samplePolicy
+	case strings.HasSuffix(name, "Time") || strings.HasSuffix(name, "Date"):
+		return sampleTime.Format(time.RFC3339)
+	case name == "UserData":
+		return base64.StdEncoding.EncodeToString([]byte("sample"))
+	}
+	return "sample"
+}
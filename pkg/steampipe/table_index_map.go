@@ -448,6 +448,10 @@ var awsMap = map[string]string{
 	"AWS::IdentityStore::User":                           "aws_identitystore_user",
 	"AWS::IdentityStore::Group":                          "aws_identitystore_group",
 	"AWS::IdentityStore::GroupMembership":                "aws_identitystore_group_membership",
+	"AWS::IAM::EffectivePermission":                      "aws_iam_effective_permission",
+	"AWS::Organizations::AccountEffectivePolicy":         "aws_organizations_account_effective_policy",
+	"AWS::EC2::NetworkExposure":                          "aws_ec2_network_exposure",
+	"AWS::Backup::ResourceCoverage":                      "aws_backup_resource_coverage",
 }
 
 var AWSDescriptionMap = map[string]interface{}{
@@ -894,6 +898,10 @@ var AWSDescriptionMap = map[string]interface{}{
 	"AWS::IdentityStore::User":                           opengovernance.IdentityStoreUser{},
 	"AWS::IdentityStore::Group":                          opengovernance.IdentityStoreGroup{},
 	"AWS::IdentityStore::GroupMembership":                opengovernance.IdentityStoreGroupMembership{},
+	"AWS::IAM::EffectivePermission":                      opengovernance.IAMEffectivePermission{},
+	"AWS::Organizations::AccountEffectivePolicy":         opengovernance.OrganizationsAccountEffectivePolicy{},
+	"AWS::EC2::NetworkExposure":                          opengovernance.EC2NetworkExposure{},
+	"AWS::Backup::ResourceCoverage":                      opengovernance.BackupResourceCoverage{},
 }
 
 var AWSReverseMap = map[string]string{
@@ -1340,4 +1348,8 @@ var AWSReverseMap = map[string]string{
 	"aws_identitystore_user":                          "AWS::IdentityStore::User",
 	"aws_identitystore_group":                         "AWS::IdentityStore::Group",
 	"aws_identitystore_group_membership":              "AWS::IdentityStore::GroupMembership",
+	"aws_iam_effective_permission":                    "AWS::IAM::EffectivePermission",
+	"aws_organizations_account_effective_policy":      "AWS::Organizations::AccountEffectivePolicy",
+	"aws_ec2_network_exposure":                        "AWS::EC2::NetworkExposure",
+	"aws_backup_resource_coverage":                    "AWS::Backup::ResourceCoverage",
 }
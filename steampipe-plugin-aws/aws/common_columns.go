@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/opengovern/og-aws-describer/pkg/opengovernance-es-sdk"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
@@ -68,6 +69,24 @@ func commonKaytuColumnsForAccountResource() []*plugin.Column {
 	}
 }
 
+// historyColumns select the snapshots of a past describe job instead of the latest resources.
+func historyColumns() []*plugin.Column {
+	return []*plugin.Column{
+		{
+			Name:        opengovernance.AsOfColumn,
+			Type:        proto.ColumnType_TIMESTAMP,
+			Description: "Point in time to read the resources at, the resources described by the last describe job before it are returned.",
+			Transform:   transform.FromQual(opengovernance.AsOfColumn),
+		},
+		{
+			Name:        opengovernance.DescribeJobIDColumn,
+			Type:        proto.ColumnType_INT,
+			Description: "The ID of the describe job that described the resource.",
+			Transform:   transform.FromField("ResourceJobID"),
+		},
+	}
+}
+
 func commonAwsKaytuRegionalColumns() []*plugin.Column {
 	return []*plugin.Column{
 		{
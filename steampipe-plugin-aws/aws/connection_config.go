@@ -1,9 +1,17 @@
 package aws
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/opengovern/og-aws-describer/pkg/opengovernance-es-sdk"
+	essdk "github.com/opengovern/og-util/pkg/opengovernance-es-sdk"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/schema"
 )
@@ -20,6 +28,9 @@ type awsConfig struct {
 	IgnoreErrorCodes      []string `cty:"ignore_error_codes"`
 	EndpointUrl           *string  `cty:"endpoint_url"`
 	S3ForcePathStyle      *bool    `cty:"s3_force_path_style"`
+	LiveFallback          *bool    `cty:"live_fallback"`
+	LiveFallbackStaleness *string  `cty:"live_fallback_staleness"`
+	LiveFallbackSourceID  *string  `cty:"live_fallback_source_id"`
 }
 
 var ConfigSchema = map[string]*schema.Attribute{
@@ -58,6 +69,15 @@ var ConfigSchema = map[string]*schema.Attribute{
 	"s3_force_path_style": {
 		Type: schema.TypeBool,
 	},
+	"live_fallback": {
+		Type: schema.TypeBool,
+	},
+	"live_fallback_staleness": {
+		Type: schema.TypeString,
+	},
+	"live_fallback_source_id": {
+		Type: schema.TypeString,
+	},
 }
 
 func ConfigInstance() interface{} {
@@ -69,7 +89,12 @@ func GetConfig(connection *plugin.Connection) awsConfig {
 	if connection == nil || connection.Config == nil {
 		return awsConfig{}
 	}
-	config, _ := connection.Config.(awsConfig)
+	config, ok := connection.Config.(awsConfig)
+	if !ok {
+		if c, isConnectionConfig := connection.Config.(connectionConfig); isConnectionConfig {
+			config = c.awsConfig()
+		}
+	}
 
 	if config.Regions != nil {
 		if len(config.Regions) == 0 {
@@ -91,3 +116,147 @@ func NormalizeRegion(region string) string {
 	// and comparisons
 	return strings.ToLower(region)
 }
+
+// connectionConfig is the connection config of the plugin. It holds the es client config the
+// tables read from and the aws credentials the live fallback describes resources with.
+type connectionConfig struct {
+	Addresses     []string `cty:"addresses"`
+	Username      *string  `cty:"username"`
+	Password      *string  `cty:"password"`
+	IsOpenSearch  *bool    `cty:"is_open_search"`
+	AwsRegion     *string  `cty:"aws_region"`
+	AssumeRoleArn *string  `cty:"assume_role_arn"`
+	ExternalID    *string  `cty:"external_id"`
+
+	Regions               []string `cty:"regions"`
+	DefaultRegion         *string  `cty:"default_region"`
+	Profile               *string  `cty:"profile"`
+	AccessKey             *string  `cty:"access_key"`
+	SecretKey             *string  `cty:"secret_key"`
+	SessionToken          *string  `cty:"session_token"`
+	MaxErrorRetryAttempts *int     `cty:"max_error_retry_attempts"`
+	MinErrorRetryDelay    *int     `cty:"min_error_retry_delay"`
+	IgnoreErrorCodes      []string `cty:"ignore_error_codes"`
+	EndpointUrl           *string  `cty:"endpoint_url"`
+	S3ForcePathStyle      *bool    `cty:"s3_force_path_style"`
+
+	// LiveFallback lets tables describe resources directly when the index has no data for the
+	// query, or only data older than LiveFallbackStaleness, e.g. "6h".
+	LiveFallback          *bool   `cty:"live_fallback"`
+	LiveFallbackStaleness *string `cty:"live_fallback_staleness"`
+	// LiveFallbackSourceID is the id in opengovernance of the account the live fallback describes,
+	// written to the resources like the describer does for kaytu_account_id.
+	LiveFallbackSourceID *string `cty:"live_fallback_source_id"`
+}
+
+var (
+	_ opengovernance.ESConfigProvider = connectionConfig{}
+	_ opengovernance.LiveConfig       = connectionConfig{}
+)
+
+func connectionConfigSchema() map[string]*schema.Attribute {
+	s := essdk.ConfigSchema()
+	for k, v := range ConfigSchema {
+		s[k] = v
+	}
+	return s
+}
+
+func connectionConfigInstance() interface{} {
+	return &connectionConfig{}
+}
+
+func (c connectionConfig) ESConfig() essdk.ClientConfig {
+	return essdk.ClientConfig{
+		Addresses:     c.Addresses,
+		Username:      c.Username,
+		Password:      c.Password,
+		IsOpenSearch:  c.IsOpenSearch,
+		AwsRegion:     c.AwsRegion,
+		AssumeRoleArn: c.AssumeRoleArn,
+		ExternalID:    c.ExternalID,
+	}
+}
+
+func (c connectionConfig) awsConfig() awsConfig {
+	return awsConfig{
+		Regions:               c.Regions,
+		DefaultRegion:         c.DefaultRegion,
+		Profile:               c.Profile,
+		AccessKey:             c.AccessKey,
+		SecretKey:             c.SecretKey,
+		SessionToken:          c.SessionToken,
+		MaxErrorRetryAttempts: c.MaxErrorRetryAttempts,
+		MinErrorRetryDelay:    c.MinErrorRetryDelay,
+		IgnoreErrorCodes:      c.IgnoreErrorCodes,
+		EndpointUrl:           c.EndpointUrl,
+		S3ForcePathStyle:      c.S3ForcePathStyle,
+		LiveFallback:          c.LiveFallback,
+		LiveFallbackStaleness: c.LiveFallbackStaleness,
+		LiveFallbackSourceID:  c.LiveFallbackSourceID,
+	}
+}
+
+func (c connectionConfig) LiveFallbackSettings() (time.Duration, bool) {
+	if c.LiveFallback == nil || !*c.LiveFallback {
+		return 0, false
+	}
+	if c.LiveFallbackStaleness == nil {
+		return 0, true
+	}
+	staleness, err := time.ParseDuration(*c.LiveFallbackStaleness)
+	if err == nil && staleness < 0 {
+		err = fmt.Errorf("negative duration")
+	}
+	if err != nil {
+		// the sdk has no hook to reject the connection config, an invalid staleness is ignored
+		// so the fallback still covers resources missing from the index.
+		log.Printf("[WARN] connection config has invalid value %q for \"live_fallback_staleness\", ignoring it: %v", *c.LiveFallbackStaleness, err)
+		return 0, true
+	}
+	return staleness, true
+}
+
+func (c connectionConfig) LiveSourceID() string {
+	if c.LiveFallbackSourceID == nil {
+		return ""
+	}
+	return *c.LiveFallbackSourceID
+}
+
+func (c connectionConfig) LiveAWSConfig(ctx context.Context) (aws.Config, []string, error) {
+	var opts []func(*config.LoadOptions) error
+	if c.Profile != nil {
+		opts = append(opts, config.WithSharedConfigProfile(*c.Profile))
+	}
+	if c.AccessKey != nil && c.SecretKey != nil {
+		sessionToken := ""
+		if c.SessionToken != nil {
+			sessionToken = *c.SessionToken
+		}
+		opts = append(opts, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(*c.AccessKey, *c.SecretKey, sessionToken)))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return aws.Config{}, nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	if c.DefaultRegion != nil {
+		cfg.Region = NormalizeRegion(*c.DefaultRegion)
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+
+	// wildcard regions are not expanded, the describers need the region names
+	var regions []string
+	for _, r := range c.Regions {
+		if !strings.Contains(r, "*") {
+			regions = append(regions, NormalizeRegion(r))
+		}
+	}
+	if len(regions) == 0 {
+		regions = []string{cfg.Region}
+	}
+	return cfg, regions, nil
+}
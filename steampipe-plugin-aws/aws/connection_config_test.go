@@ -0,0 +1,34 @@
+package aws
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLiveFallbackSettings(t *testing.T) {
+	enabled := true
+	tests := map[string]struct {
+		fallback  *bool
+		staleness string
+		want      time.Duration
+		enabled   bool
+	}{
+		"disabled":           {staleness: "1h"},
+		"no staleness":       {fallback: &enabled, enabled: true},
+		"staleness":          {fallback: &enabled, staleness: "90m", want: 90 * time.Minute, enabled: true},
+		"invalid staleness":  {fallback: &enabled, staleness: "a day", enabled: true},
+		"negative staleness": {fallback: &enabled, staleness: "-1h", enabled: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := connectionConfig{LiveFallback: tt.fallback}
+			if tt.staleness != "" {
+				c.LiveFallbackStaleness = &tt.staleness
+			}
+			got, enabled := c.LiveFallbackSettings()
+			if got != tt.want || enabled != tt.enabled {
+				t.Errorf("got %v, %v, want %v, %v", got, enabled, tt.want, tt.enabled)
+			}
+		})
+	}
+}
@@ -8,7 +8,8 @@ package aws
 import (
 	"context"
 
-	"github.com/opengovern/og-util/pkg/opengovernance-es-sdk"
+	"github.com/opengovern/og-aws-describer/pkg/opengovernance-es-sdk"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 )
@@ -40,8 +41,8 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			ShouldIgnoreErrorFunc: shouldIgnoreErrorPluginDefault(),
 		},
 		ConnectionConfigSchema: &plugin.ConnectionConfigSchema{
-			NewInstance: opengovernance.ConfigInstance,
-			Schema:      opengovernance.ConfigSchema(),
+			NewInstance: connectionConfigInstance,
+			Schema:      connectionConfigSchema(),
 		},
 		TableMap: map[string]*plugin.Table{
 			"aws_accessanalyzer_analyzer":                                  tableAwsAccessAnalyzer(ctx),
@@ -87,6 +88,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_backup_protected_resource":                                tableAwsBackupProtectedResource(ctx),
 			"aws_backup_recovery_point":                                    tableAwsBackupRecoveryPoint(ctx),
 			"aws_backup_report_plan":                                       tableAwsBackupReportPlan(ctx),
+			"aws_backup_resource_coverage":                                 tableAwsBackupResourceCoverage(ctx),
 			"aws_backup_selection":                                         tableAwsBackupSelection(ctx),
 			"aws_backup_vault":                                             tableAwsBackupVault(ctx),
 			"aws_backup_region_settings":                                   tableAwsBackupRegionSetting(ctx),
@@ -195,6 +197,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_ec2_load_balancer_listener":                               tableAwsEc2ApplicationLoadBalancerListener(ctx),
 			"aws_ec2_managed_prefix_list":                                  tableAwsEc2ManagedPrefixList(ctx),
 			"aws_ec2_managed_prefix_list_entry":                            tableAwsEc2ManagedPrefixListEntry(ctx),
+			"aws_ec2_network_exposure":                                     tableAwsEc2NetworkExposure(ctx),
 			"aws_ec2_network_interface":                                    tableAwsEc2NetworkInterface(ctx),
 			"aws_ec2_network_load_balancer_metric_net_flow_count_daily":    tableAwsEc2NetworkLoadBalancerMetricNetFlowCountDaily(ctx),
 			"aws_ec2_network_load_balancer_metric_net_flow_count":          tableAwsEc2NetworkLoadBalancerMetricNetFlowCount(ctx),
@@ -283,6 +286,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_iam_account_summary":                                      tableAwsIamAccountSummary(ctx),
 			"aws_iam_action":                                               tableAwsIamAction(ctx),
 			"aws_iam_credential_report":                                    tableAwsIamCredentialReport(ctx),
+			"aws_iam_effective_permission":                                 tableAwsIamEffectivePermission(ctx),
 			"aws_iam_group":                                                tableAwsIamGroup(ctx),
 			"aws_iam_open_id_connect_provider":                             tableAwsIamOpenIdConnectProvider(ctx),
 			"aws_iam_policy_attachment":                                    tableAwsIamPolicyAttachment(ctx),
@@ -337,6 +341,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_oam_sink":                                                 tableAwsOAMSink(ctx),
 			"aws_opensearch_domain":                                        tableAwsOpenSearchDomain(ctx),
 			"aws_organizations_account":                                    tableAwsOrganizationsAccount(ctx),
+			"aws_organizations_account_effective_policy":                   tableAwsOrganizationsAccountEffectivePolicy(ctx),
 			"aws_organizations_policy":                                     tableAwsOrganizationsPolicy(ctx),
 			"aws_organizations_policy_target":                              tableAwsOrganizationsPolicyTarget(ctx),
 			"aws_pinpoint_app":                                             tableAwsPinpointApp(ctx),
@@ -578,19 +583,36 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 		}
 
 		kaytuTable := false
+		taggedTable := false
 		for _, col := range table.Columns {
 			if col != nil && col.Name == "kaytu_account_id" {
 				kaytuTable = true
 			}
+			if col != nil && col.Name == opengovernance.TagsColumn && col.Type == proto.ColumnType_JSON {
+				taggedTable = true
+			}
 		}
 
 		if kaytuTable {
+			// every indexed table can read the snapshots of past describe jobs
+			table.Columns = append(table.Columns, historyColumns()...)
+
 			if table.Get != nil {
 				table.Get.KeyColumns = append(table.Get.KeyColumns, plugin.OptionalColumns([]string{"kaytu_account_id", "kaytu_resource_id"})...)
+				table.Get.KeyColumns = append(table.Get.KeyColumns, plugin.OptionalColumns([]string{opengovernance.AsOfColumn, opengovernance.DescribeJobIDColumn})...)
 			}
 
 			if table.List != nil {
 				table.List.KeyColumns = append(table.List.KeyColumns, plugin.OptionalColumns([]string{"kaytu_account_id", "kaytu_resource_id"})...)
+				table.List.KeyColumns = append(table.List.KeyColumns, plugin.OptionalColumns([]string{opengovernance.AsOfColumn, opengovernance.DescribeJobIDColumn})...)
+			}
+			// tag qualifiers are pushed down to the canonical tags of the documents
+			if table.List != nil && taggedTable {
+				table.List.KeyColumns = append(table.List.KeyColumns, &plugin.KeyColumn{
+					Name:      opengovernance.TagsColumn,
+					Operators: opengovernance.TagQualOperators,
+					Require:   plugin.Optional,
+				})
 			}
 		}
 	}
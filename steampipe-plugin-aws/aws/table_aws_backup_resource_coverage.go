@@ -0,0 +1,111 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	"github.com/opengovern/og-aws-describer/pkg/opengovernance-es-sdk"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsBackupResourceCoverage(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_backup_resource_coverage",
+		Description: "AWS Backup Resource Coverage",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("resource_arn"),
+			Hydrate:    opengovernance.GetBackupResourceCoverage,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: opengovernance.ListBackupResourceCoverage,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "resource_type", Require: plugin.Optional},
+				{Name: "is_protected", Require: plugin.Optional, Operators: []string{"=", "<>"}},
+			},
+		},
+		Columns: awsKaytuRegionalColumns([]*plugin.Column{
+			{
+				Name:        "resource_arn",
+				Description: "The Amazon Resource Name (ARN) of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.ResourceArn"),
+			},
+			{
+				Name:        "resource_type",
+				Description: "The AWS Backup type of the resource, one of RDS, Aurora, Neptune, DocumentDB, EBS, EFS, DynamoDB or S3.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.ResourceType"),
+			},
+			{
+				Name:        "is_protected",
+				Description: "True if a backup selection assigns the resource to a backup plan.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Description.IsProtected"),
+			},
+			{
+				Name:        "tags_unknown",
+				Description: "True if the tags of the resource could not be read, selections by tag are then evaluated without them.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Description.TagsUnknown"),
+			},
+			{
+				Name:        "covering_rules",
+				Description: "The backup plan rules that back up the resource, with the selection that assigns it to the plan.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.CoveringRules"),
+			},
+			{
+				Name:        "last_recovery_point_arn",
+				Description: "The Amazon Resource Name (ARN) of the latest completed recovery point of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.LastRecoveryPointArn"),
+			},
+			{
+				Name:        "last_recovery_point_time",
+				Description: "The time the latest completed recovery point of the resource was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Description.LastRecoveryPointTime"),
+			},
+			{
+				Name:        "last_recovery_point_age_in_hours",
+				Description: "The age, in hours, of the latest completed recovery point of the resource.",
+				Type:        proto.ColumnType_DOUBLE,
+				Transform:   transform.From(backupResourceCoverageRecoveryPointAge),
+			},
+
+			// Standard columns for all tables
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.Tags"),
+			},
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.ResourceArn"),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ARN").Transform(arnToAkas),
+			},
+		}),
+	}
+}
+
+//// TRANSFORM FUNCTIONS
+
+func backupResourceCoverageRecoveryPointAge(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	lastTime := d.HydrateItem.(opengovernance.BackupResourceCoverage).Description.LastRecoveryPointTime
+	if lastTime == nil {
+		return nil, nil
+	}
+	return time.Since(*lastTime).Hours(), nil
+}
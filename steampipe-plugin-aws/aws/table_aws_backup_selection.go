@@ -75,6 +75,18 @@ func tableAwsBackupSelection(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_JSON,
 				Transform:   transform.FromField("Description.Resources"),
 			},
+			{
+				Name:        "not_resources",
+				Description: "The Amazon Resource Names (ARNs) of the resources excluded from the backup plan.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.NotResources"),
+			},
+			{
+				Name:        "conditions",
+				Description: "The tag conditions the resources assigned to the backup plan have to meet.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.Conditions"),
+			},
 
 			// Steampipe standard columns
 			{
@@ -0,0 +1,113 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/opengovern/og-aws-describer/pkg/opengovernance-es-sdk"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsEc2NetworkExposure(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_ec2_network_exposure",
+		Description: "AWS EC2 Network Exposure",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("resource_id"),
+			Hydrate:    opengovernance.GetEC2NetworkExposure,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: opengovernance.ListEC2NetworkExposure,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "resource_type", Require: plugin.Optional},
+				{Name: "vpc_id", Require: plugin.Optional},
+				{Name: "instance_id", Require: plugin.Optional},
+			},
+		},
+		Columns: awsKaytuRegionalColumns([]*plugin.Column{
+			{
+				Name:        "resource_id",
+				Description: "The ID of the network interface, or the ARN of the load balancer.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.ResourceId"),
+			},
+			{
+				Name:        "resource_type",
+				Description: "The type of the resource, network_interface or load_balancer.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.ResourceType"),
+			},
+			{
+				Name:        "instance_id",
+				Description: "The ID of the instance the network interface is attached to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.InstanceId"),
+			},
+			{
+				Name:        "vpc_id",
+				Description: "The ID of the VPC.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.VpcId"),
+			},
+			{
+				Name:        "subnet_ids",
+				Description: "The subnets of the network interface or of the load balancer.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.SubnetIds"),
+			},
+			{
+				Name:        "public_ips",
+				Description: "The public IPv4 and IPv6 addresses of the resource.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.PublicIps"),
+			},
+			{
+				Name:        "security_group_ids",
+				Description: "The security groups of the resource.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.SecurityGroupIds"),
+			},
+			{
+				Name:        "network_acl_ids",
+				Description: "The network ACLs of the subnets of the resource.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.NetworkAclIds"),
+			},
+			{
+				Name:        "internet_gateway_id",
+				Description: "The internet gateway the subnets of the resource route to, if any.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.InternetGatewayId"),
+			},
+			{
+				Name:        "is_internet_reachable",
+				Description: "True if some port of the resource can be reached from the internet.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Description.IsInternetReachable"),
+			},
+			{
+				Name:        "exposed_ports",
+				Description: "The port ranges open to the internet, with the protocol and the source CIDRs allowed to reach them.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.ExposedPorts"),
+			},
+
+			// Standard columns for all tables
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.ResourceId"),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ARN").Transform(arnToAkas),
+			},
+		}),
+	}
+}
@@ -111,6 +111,18 @@ func tableAwsEcrRepository(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_JSON,
 				Transform:   transform.FromField("Description.Policy.PolicyText").Transform(unescape).Transform(policyToCanonical),
 			},
+			{
+				Name:        "is_public",
+				Description: "True if the repository policy grants access to anyone, derived by evaluating the policy.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Description.Policy.PolicyText").Transform(unescape).Transform(policyIsPublic),
+			},
+			{
+				Name:        "cross_account_principals",
+				Description: "The principals outside the account the repository policy grants access to.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.Policy.PolicyText").Transform(unescape).Transform(policyCrossAccountPrincipals),
+			},
 			{
 				Name:        "tags_src",
 				Description: "A list of tags assigned to the Repository.",
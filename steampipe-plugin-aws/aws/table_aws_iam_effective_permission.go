@@ -0,0 +1,106 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/opengovern/og-aws-describer/pkg/opengovernance-es-sdk"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsIamEffectivePermission(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_iam_effective_permission",
+		Description: "AWS IAM Effective Permission",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("principal_arn"),
+			Hydrate:    opengovernance.GetIAMEffectivePermission,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: opengovernance.ListIAMEffectivePermission,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "principal_type", Require: plugin.Optional},
+				{Name: "principal_name", Require: plugin.Optional},
+			},
+		},
+		Columns: awsKaytuRegionalColumns([]*plugin.Column{
+			{
+				Name:        "principal_arn",
+				Description: "The Amazon Resource Name (ARN) of the user, role or group.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.PrincipalArn"),
+			},
+			{
+				Name:        "principal_name",
+				Description: "The friendly name of the user, role or group.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.PrincipalName"),
+			},
+			{
+				Name:        "principal_type",
+				Description: "The type of the principal, user, role or group.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.PrincipalType"),
+			},
+			{
+				Name:        "attached_policy_arns",
+				Description: "The managed policies attached to the principal.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.AttachedPolicyArns"),
+			},
+			{
+				Name:        "inline_policy_names",
+				Description: "The names of the inline policies of the principal.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.InlinePolicyNames"),
+			},
+			{
+				Name:        "group_names",
+				Description: "The groups the user belongs to, their policies are part of the effective permissions.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.GroupNames"),
+			},
+			{
+				Name:        "permissions_boundary_arn",
+				Description: "The ARN of the policy set as the permissions boundary of the principal.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.PermissionsBoundaryArn"),
+			},
+			{
+				Name:        "allowed_actions",
+				Description: "The actions the principal is allowed on at least some resource, with the wildcards of its policies expanded.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.AllowedActions"),
+			},
+			{
+				Name:        "is_admin_equivalent",
+				Description: "True if the principal is allowed every action, or every IAM action, on every resource.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Description.IsAdminEquivalent"),
+			},
+			{
+				Name:        "privilege_escalation_paths",
+				Description: "The known privilege escalation paths the allowed actions open, with the actions each of them needs.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.PrivilegeEscalationPaths"),
+			},
+
+			// Standard columns for all tables
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.PrincipalName"),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.PrincipalArn").Transform(arnToAkas),
+			},
+		}),
+	}
+}
@@ -18,6 +18,9 @@ func tableAwsIdentityStoreGroup(_ context.Context) *plugin.Table {
 			IgnoreConfig: &plugin.IgnoreConfig{
 				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException"}),
 			},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "identity_store_id", Require: plugin.Optional},
+			},
 		},
 
 		Columns: awsKaytuRegionalColumns([]*plugin.Column{
@@ -2,7 +2,6 @@ package aws
 
 import (
 	"context"
-	identitystorev1 "github.com/aws/aws-sdk-go/service/identitystore"
 	"github.com/opengovern/og-aws-describer/pkg/opengovernance-es-sdk"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
@@ -19,8 +18,11 @@ func tableAwsIdentityStoreGroupMembership(_ context.Context) *plugin.Table {
 			IgnoreConfig: &plugin.IgnoreConfig{
 				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException"}),
 			},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "identity_store_id", Require: plugin.Optional},
+				{Name: "group_id", Require: plugin.Optional},
+			},
 		},
-		GetMatrixItemFunc: SupportedRegionMatrix(identitystorev1.EndpointsID),
 		Columns: awsKaytuRegionalColumns([]*plugin.Column{
 			{
 				Name:        "membership_id",
@@ -19,6 +19,9 @@ func tableAwsIdentityStoreUser(_ context.Context) *plugin.Table {
 			IgnoreConfig: &plugin.IgnoreConfig{
 				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException"}),
 			},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "identity_store_id", Require: plugin.Optional},
+			},
 		},
 
 		Columns: awsKaytuRegionalColumns([]*plugin.Column{
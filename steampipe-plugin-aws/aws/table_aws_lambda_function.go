@@ -206,6 +206,18 @@ func tableAwsLambdaFunction(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_JSON,
 				Transform:   transform.FromField("Description.Policy.Policy").Transform(policyToCanonical),
 			},
+			{
+				Name:        "is_public",
+				Description: "True if the function policy grants access to anyone, derived by evaluating the policy.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Description.Policy.Policy").Transform(policyIsPublic),
+			},
+			{
+				Name:        "cross_account_principals",
+				Description: "The principals outside the account the function policy grants access to.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.Policy.Policy").Transform(policyCrossAccountPrincipals),
+			},
 			{
 				Name:        "tracing_config",
 				Description: "The function's X-Ray tracing configuration.",
@@ -0,0 +1,110 @@
+package aws
+
+import (
+	"context"
+	"github.com/opengovern/og-aws-describer/pkg/opengovernance-es-sdk"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableAwsOrganizationsAccountEffectivePolicy(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_organizations_account_effective_policy",
+		Description: "AWS Organizations Account Effective Policy",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("account_id"),
+			Hydrate:    opengovernance.GetOrganizationsAccountEffectivePolicy,
+		},
+		List: &plugin.ListConfig{
+			Hydrate: opengovernance.ListOrganizationsAccountEffectivePolicy,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "root_id", Require: plugin.Optional},
+				{Name: "parent_id", Require: plugin.Optional},
+			},
+		},
+		Columns: awsKaytuColumns([]*plugin.Column{
+			{
+				Name:        "account_id",
+				Description: "The unique identifier (ID) of the member account.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.AccountId"),
+			},
+			{
+				Name:        "account_name",
+				Description: "The friendly name of the member account.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.AccountName"),
+			},
+			{
+				Name:        "root_id",
+				Description: "The unique identifier (ID) of the root the account is under.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.RootId"),
+			},
+			{
+				Name:        "parent_id",
+				Description: "The unique identifier (ID) of the root or organizational unit the account is directly under.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.ParentId"),
+			},
+			{
+				Name:        "ou_path",
+				Description: "The root and the organizational units above the account, from the root down to its parent.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.OUPath"),
+			},
+			{
+				Name:        "path",
+				Description: "The path from the root to the parent of the account, in the same form as the organizational unit path.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.Path"),
+			},
+			{
+				Name:        "service_control_policies",
+				Description: "The service control policies attached to the account and inherited from its root and organizational units.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.ServiceControlPolicies"),
+			},
+			{
+				Name:        "tag_policies",
+				Description: "The tag policies attached to the account and inherited from its root and organizational units.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.TagPolicies"),
+			},
+			{
+				Name:        "backup_policies",
+				Description: "The backup policies attached to the account and inherited from its root and organizational units.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.BackupPolicies"),
+			},
+			{
+				Name:        "effective_tag_policy",
+				Description: "The tag policy in effect for the account, the tag policies merged following the inheritance rules.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.EffectiveTagPolicy").Transform(UnmarshalYAMLorJSONNoUnescape),
+			},
+			{
+				Name:        "effective_backup_policy",
+				Description: "The backup policy in effect for the account, the backup policies merged following the inheritance rules.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.EffectiveBackupPolicy").Transform(UnmarshalYAMLorJSONNoUnescape),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Description.AccountName"),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ARN").Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
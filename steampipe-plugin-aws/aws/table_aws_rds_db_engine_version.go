@@ -4,8 +4,6 @@ import (
 	"context"
 	"github.com/opengovern/og-aws-describer/pkg/opengovernance-es-sdk"
 
-	"github.com/aws/aws-sdk-go-v2/service/rds/types"
-
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -236,7 +234,7 @@ func tableAwsRDSDBEngineVersion(_ context.Context) *plugin.Table {
 //// TRANSFORM FUNCTIONS
 
 func getRDSDBEngineVersionTurbotTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
-	engineVersion := d.HydrateItem.(types.DBEngineVersion)
+	engineVersion := d.HydrateItem.(opengovernance.RDSDBEngineVersion).Description.EngineVersion
 
 	if engineVersion.TagList != nil {
 		turbotTagsMap := map[string]string{}
@@ -249,7 +247,7 @@ func getRDSDBEngineVersionTurbotTags(_ context.Context, d *transform.TransformDa
 }
 
 func getRDSDBEngineVersionAka(ctx context.Context, d *transform.TransformData) (interface{}, error) {
-	engineVersion := d.HydrateItem.(types.DBEngineVersion)
+	engineVersion := d.HydrateItem.(opengovernance.RDSDBEngineVersion).Description.EngineVersion
 
 	if engineVersion.DBEngineVersionArn == nil {
 		return []string{}, nil
@@ -138,6 +138,18 @@ func tableAwsS3Bucket(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_JSON,
 				Transform:   transform.FromField("Description.Policy").Transform(policyToCanonical),
 			},
+			{
+				Name:        "is_public",
+				Description: "True if the bucket policy grants access to anyone, derived by evaluating the policy.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Description.Policy").Transform(policyIsPublic),
+			},
+			{
+				Name:        "cross_account_principals",
+				Description: "The principals outside the account the bucket policy grants access to.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.Policy").Transform(policyCrossAccountPrincipals),
+			},
 			{
 				Name:        "replication",
 				Description: "The replication configuration of a bucket.",
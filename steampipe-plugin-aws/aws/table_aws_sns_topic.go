@@ -177,6 +177,18 @@ func tableAwsSnsTopic(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_JSON,
 				Transform:   transform.FromField("Description.Attributes.Policy").Transform(unescape).Transform(policyToCanonical),
 			},
+			{
+				Name:        "is_public",
+				Description: "True if the topic policy grants access to anyone, derived by evaluating the policy.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("Description.Attributes.Policy").Transform(unescape).Transform(policyIsPublic),
+			},
+			{
+				Name:        "cross_account_principals",
+				Description: "The principals outside the account the topic policy grants access to.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Description.Attributes.Policy").Transform(unescape).Transform(policyCrossAccountPrincipals),
+			},
 
 			{
 				Name:        "delivery_policy",